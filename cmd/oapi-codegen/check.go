@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// checkOutput compares generated code to the existing output file, for the
+// -check flag, without writing anything. It returns a unified diff from the
+// file to the code, or "" when the file is up to date.
+func checkOutput(outputFile, code string) (string, error) {
+	current, err := os.ReadFile(outputFile)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("error reading generated code from file: %w", err)
+	}
+	if string(current) == code {
+		return "", nil
+	}
+	fromFile := outputFile
+	if err != nil {
+		fromFile += " (missing)"
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(current)),
+		B:        splitLines(code),
+		FromFile: fromFile,
+		ToFile:   outputFile + " (generated)",
+		Context:  3,
+	})
+}
+
+// splitLines splits s into lines, keeping their line endings, as the diff
+// expects.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOutput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.gen.go")
+	require.NoError(t, os.WriteFile(path, []byte("package api\n\ntype A int\n"), 0o644))
+
+	diff, err := checkOutput(path, "package api\n\ntype A int\n")
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = checkOutput(path, "package api\n\ntype A string\n")
+	require.NoError(t, err)
+	assert.Equal(t, "--- "+path+"\n+++ "+path+" (generated)\n@@ -1,3 +1,3 @@\n package api\n \n-type A int\n+type A string\n", diff)
+
+	// The file is left alone.
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "package api\n\ntype A int\n", string(current))
+
+	diff, err = checkOutput(filepath.Join(dir, "missing.gen.go"), "package api\n")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "missing.gen.go (missing)")
+	assert.Contains(t, diff, "+package api\n")
+}
+
+func TestRunWorkspaceCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, workspaceFiles)
+	manifest := filepath.Join(dir, "oapi-codegen.workspace.yaml")
+
+	err := runWorkspace(manifest, true)
+	assert.ErrorContains(t, err, "admin.gen.go is out of date")
+	assert.ErrorContains(t, err, "common.gen.go is out of date")
+	_, err = os.Stat(filepath.Join(dir, "admin", "admin.gen.go"))
+	assert.True(t, os.IsNotExist(err), "check mode wrote a file")
+
+	require.NoError(t, runWorkspace(manifest, false))
+	require.NoError(t, runWorkspace(manifest, true))
+
+	common := filepath.Join(dir, "common", "common.gen.go")
+	require.NoError(t, os.WriteFile(common, []byte("package common\n"), 0o644))
+	err = runWorkspace(manifest, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "common.gen.go is out of date")
+	assert.NotContains(t, err.Error(), "admin.gen.go")
+}
+
+func TestRunWorkspaceEmbeddedSpecFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, workspaceFiles)
+	writeFiles(t, dir, map[string]string{
+		"common/cfg.yaml": `
+output: common.gen.go
+generate:
+  models: true
+  embedded-spec: true
+output-options:
+  skip-prune: true
+  embedded-spec-file: openapi.json
+`,
+	})
+	manifest := filepath.Join(dir, "oapi-codegen.workspace.yaml")
+
+	// The spec is written next to the code.
+	require.NoError(t, runWorkspace(manifest, false))
+	spec, err := os.ReadFile(filepath.Join(dir, "common", "openapi.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(spec), `"title": "Common"`)
+	require.NoError(t, runWorkspace(manifest, true))
+
+	// And it's checked, as the code is.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "openapi.json"), []byte("{}\n"), 0o644))
+	err = runWorkspace(manifest, true)
+	assert.ErrorContains(t, err, "common.gen.go is out of date")
+}
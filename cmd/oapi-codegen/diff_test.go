@@ -0,0 +1,88 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cfg.yaml": `
+package: pets
+generate:
+  models: true
+  client: true
+  std-http-server: true
+`,
+		"old.yaml": `
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200":
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`,
+		"new.yaml": `
+openapi: "3.0.0"
+info: {title: Pets, version: 2.0.0}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        "200":
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        age: {type: integer}
+`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	var out strings.Builder
+	err := runDiff([]string{"-config", path("cfg.yaml"), path("old.yaml"), path("old.yaml")}, &out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	err = runDiff([]string{"-config", path("cfg.yaml"), path("old.yaml"), path("new.yaml")}, &out)
+	assert.ErrorContains(t, err, "breaking")
+	report := out.String()
+	assert.Contains(t, report, "compatible: Pet.Age: added field\n")
+	assert.Contains(t, report, "breaking: Pet.Name: field type changed from *string to string\n")
+	assert.Contains(t, report, "breaking: ServerInterface.GetPet: method type changed from func(w http.ResponseWriter, r *http.Request, id string) to func(w http.ResponseWriter, r *http.Request, id int)\n")
+	assert.Contains(t, report, "breaking: ClientInterface.GetPet: method type changed")
+
+	err = runDiff([]string{path("old.yaml")}, &out)
+	assert.ErrorContains(t, err, "diff needs -config")
+}
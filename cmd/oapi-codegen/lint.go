@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// runLint runs the lint subcommand:
+//
+//	oapi-codegen lint [-config cfg.yaml] [-enable rules] [-disable rules] [-fixes overlay.yaml] spec
+//
+// It writes what the rules of codegen.Lint find in the spec to out, with how
+// to fix each, and fails when they find anything. The fixes which are overlay
+// actions are written to the -fixes file as an overlay, to be given to
+// output-options.overlay or applied to the spec.
+func runLint(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen lint [flags] spec\n\n"+
+			"Reports what will make the generation of a spec fail, or its code awkward.\n\n")
+		flags.PrintDefaults()
+		_, _ = fmt.Fprintf(flags.Output(), "\nRules:\n")
+		for _, rule := range codegen.LintRules {
+			_, _ = fmt.Fprintf(flags.Output(), "  %s\n    \t%s\n", rule.Name, rule.Description)
+		}
+	}
+	configFile := flags.String("config", "", "The YAML config file the code is generated with. Without it, the spec is linted for any configuration.")
+	enable := flags.String("enable", "", "A comma-separated list of the only rules to run.")
+	disable := flags.String("disable", "", "A comma-separated list of rules not to run.")
+	fixesFile := flags.String("fixes", "", "Where to write an overlay with the fixes of the findings.")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("lint needs one spec file")
+	}
+	specPath := flags.Arg(0)
+
+	opts := configuration{Configuration: codegen.Configuration{PackageName: "api"}}
+	if *configFile != "" {
+		var err error
+		if opts, err = readConfiguration(*configFile); err != nil {
+			return err
+		}
+		opts.Configuration = opts.UpdateDefaults()
+		if opts.PackageName == "" {
+			opts.PackageName = "api"
+		}
+		if err := opts.Validate(); err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+	}
+	swagger, err := util.LoadSwaggerWithOverlay(specPath, specLoadOptions(opts))
+	if err != nil {
+		return fmt.Errorf("error loading swagger spec in %s: %w", specPath, err)
+	}
+
+	findings, err := codegen.Lint(swagger, opts.Configuration, codegen.LintOptions{
+		Enable:  splitRuleNames(*enable),
+		Disable: splitRuleNames(*disable),
+	})
+	if err != nil {
+		return err
+	}
+
+	var fixes []codegen.OverlayAction
+	for _, f := range findings {
+		_, _ = fmt.Fprintln(out, f)
+		if f.Advice != "" {
+			_, _ = fmt.Fprintf(out, "\t%s\n", f.Advice)
+		}
+		if f.Fix != nil {
+			update, err := json.Marshal(f.Fix.Update)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "\tfix: update %s with %s\n", f.Fix.Target, update)
+			fixes = append(fixes, *f.Fix)
+		}
+	}
+
+	if *fixesFile != "" && len(fixes) > 0 {
+		buf, err := yaml.Marshal(codegen.NewOverlay("oapi-codegen lint fixes for "+specPath, fixes))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*fixesFile, buf, 0o644); err != nil {
+			return fmt.Errorf("error writing fixes to %s: %w", *fixesFile, err)
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%d lint finding(s)", len(findings))
+	}
+	return nil
+}
+
+// splitRuleNames splits a comma-separated list of rule names.
+func splitRuleNames(list string) []string {
+	var names []string
+	for name := range strings.SplitSeq(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
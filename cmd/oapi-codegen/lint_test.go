@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cfg.yaml": `
+package: pets
+generate:
+  models: true
+  std-http-server: true
+`,
+		"api.yaml": `openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets/{id}:apply:
+    post:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "204":
+          description: Applied
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        size:
+          type: string
+          enum: [small, big]
+`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	var out strings.Builder
+	err := runLint([]string{"-config", path("cfg.yaml"), "-fixes", path("fixes.yaml"), path("api.yaml")}, &out)
+	assert.EqualError(t, err, "3 lint finding(s)")
+	report := out.String()
+	assert.Contains(t, report, "api.yaml:16:9: inline schema is generated as type PetSize, named after where it's declared (inline-schema-name)\n"+
+		"\tName the type with x-go-type-name, or move the schema to components/schemas.\n"+
+		"\tfix: update $.components.schemas.Pet.properties.size with {\"x-go-type-name\":\"PetSize\"}\n")
+	assert.Contains(t, report, "api.yaml:5:5: POST /pets/{id}:apply has no operationId, so its handler is named PostPetsIdApply (missing-operation-id)\n")
+	assert.Contains(t, report, "api.yaml:4:3: path \"/pets/{id}:apply\": segment")
+
+	fixes, err := os.ReadFile(path("fixes.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(fixes), `overlay: 1.0.0
+info:
+    title: oapi-codegen lint fixes for `)
+	assert.Contains(t, string(fixes), `actions:
+    - target: $.components.schemas.Pet.properties.size
+      update:
+        x-go-type-name: PetSize
+    - target: $.paths['/pets/{id}:apply'].post
+      update:
+        operationId: PostPetsIdApply
+`)
+
+	out.Reset()
+	err = runLint([]string{"-enable", "enum-value-collision", path("api.yaml")}, &out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	err = runLint([]string{"-disable", "no-such-rule", path("api.yaml")}, &out)
+	assert.EqualError(t, err, `unknown lint rule "no-such-rule"`)
+}
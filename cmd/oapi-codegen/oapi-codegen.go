@@ -15,6 +15,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -23,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"go.yaml.in/yaml/v3"
 
@@ -48,6 +50,10 @@ var (
 	flagPrintUsage     bool
 	flagGenerate       string
 	flagTemplatesDir   string
+	flagWorkspace      string
+	flagCheck          bool
+	flagReport         string
+	flagWatch          bool
 
 	// Deprecated: The options below will be removed in a future
 	// release. Please use the new config file format.
@@ -90,6 +96,25 @@ type oldConfiguration struct {
 var noVCSVersionOverride string
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:], os.Stdout); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		if err := runLint(os.Args[2:], os.Stdout); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "pin" {
+		if err := runPin(os.Args[2:], os.Stdout); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+
 	flag.StringVar(&flagOutputFile, "o", "", "Where to output generated code, stdout is default.")
 	flag.BoolVar(&flagOldConfigStyle, "old-config-style", false, "Whether to use the older style config file format.")
 	flag.BoolVar(&flagOutputConfig, "output-config", false, "When true, outputs a configuration file for oapi-codegen using current settings.")
@@ -98,6 +123,10 @@ func main() {
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 	flag.BoolVar(&flagPrintUsage, "h", false, "Same as -help.")
+	flag.BoolVar(&flagCheck, "check", false, "Check that the output file(s) are up to date, printing a diff and exiting non-zero when they aren't, without writing anything.")
+	flag.StringVar(&flagReport, "report", "", "Print a report of the generated types and operations to stdout, in the given format: json. Needs an output file for the code.")
+	flag.BoolVar(&flagWatch, "watch", false, "Keep running, and regenerate the output file each time the spec, a file it refers to, the overlay, a user template or the config changes.")
+	flag.StringVar(&flagWorkspace, "workspace", "", "A YAML workspace manifest listing specs, configs and outputs to generate in one run, instead of a single spec.")
 
 	// All flags below are deprecated, and will be removed in a future release. Please do not
 	// update their behavior.
@@ -135,12 +164,153 @@ func main() {
 		return
 	}
 
+	if flagReport != "" && flagReport != reportFormatJSON {
+		errExit("unsupported -report format %q; the supported format is %s\n", flagReport, reportFormatJSON)
+	}
+	if flagReport != "" && (flagCheck || flagWorkspace != "") {
+		errExit("-report can't be used with -check or -workspace\n")
+	}
+	if flagWatch && (flagCheck || flagReport != "" || flagWorkspace != "" || flagOutputConfig) {
+		errExit("-watch can't be used with -check, -report, -workspace or -output-config\n")
+	}
+
+	if flagWorkspace != "" {
+		if flag.NArg() > 0 || flagConfigFile != "" || flagOutputFile != "" {
+			errExit("-workspace can't be used with a spec file, -config or -o; list them in the workspace manifest instead\n")
+		}
+		if err := runWorkspace(flagWorkspace, flagCheck); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+
 	if flag.NArg() < 1 {
 		errExit("Please specify a path to a OpenAPI 3.0 spec file\n")
 	} else if flag.NArg() > 1 {
 		errExit("Only one OpenAPI 3.0 spec file is accepted and it must be the last CLI argument\n")
 	}
 
+	opts, err := loadConfiguration()
+	if err != nil {
+		errExit("%s\n", err)
+	}
+
+	if warnings := opts.GenerateWarnings(); len(warnings) > 0 {
+		var out strings.Builder
+		out.WriteString("WARNING: A number of warning(s) were returned when validating the GenerateOptions:")
+		for k, v := range warnings {
+			out.WriteString("\n- " + k + ": " + v)
+		}
+
+		_, _ = fmt.Fprint(os.Stderr, out.String())
+	}
+
+	if warnings := opts.Warnings(); len(warnings) > 0 {
+		var out strings.Builder
+		out.WriteString("WARNING: A number of cross-field configuration warning(s) were returned:")
+		for k, v := range warnings {
+			out.WriteString("\n- " + k + ": " + v)
+		}
+		out.WriteString("\n")
+
+		_, _ = fmt.Fprint(os.Stderr, out.String())
+	}
+
+	// If the user asked to output configuration, output it to stdout and exit
+	if flagOutputConfig {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(opts); err != nil {
+			errExit("error YAML marshaling configuration: %v\n", err)
+		}
+		_ = enc.Close()
+		fmt.Print(buf.String())
+		return
+	}
+
+	if flagCheck && opts.OutputFile == "" {
+		errExit("-check needs an output file to compare with; set it with -o or `output` in the config\n")
+	}
+	if flagReport != "" && opts.OutputFile == "" {
+		errExit("-report prints to stdout, so it needs an output file for the code; set it with -o or `output` in the config\n")
+	}
+	if opts.OutputOptions.EmbeddedSpecFile != "" && opts.OutputFile == "" {
+		errExit("`embedded-spec-file` is written next to the output file, so it needs one; set it with -o or `output` in the config\n")
+	}
+	if flagWatch {
+		if opts.OutputFile == "" {
+			errExit("-watch needs an output file to write; set it with -o or `output` in the config\n")
+		}
+		w := &watcher{
+			specPath:     flag.Arg(0),
+			load:         loadConfiguration,
+			configPath:   flagConfigFile,
+			templatesDir: flagTemplatesDir,
+			out:          os.Stdout,
+			interval:     250 * time.Millisecond,
+			debounce:     200 * time.Millisecond,
+		}
+		// It runs until it's interrupted.
+		w.run(nil)
+		return
+	}
+
+	swagger, err := util.LoadSwaggerWithOverlay(flag.Arg(0), specLoadOptions(opts))
+	if err != nil {
+		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
+	}
+
+	if len(noVCSVersionOverride) > 0 {
+		opts.NoVCSVersionOverride = &noVCSVersionOverride
+	}
+
+	artifacts, genErr := codegen.GenerateArtifacts(swagger, opts.Configuration)
+
+	if flagCheck {
+		if genErr != nil {
+			errExit("error generating code: %s\n", genErr)
+		}
+		diff, err := checkOutputFiles(outputFiles(opts.OutputFile, artifacts))
+		if err != nil {
+			errExit("%s\n", err)
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			errExit("%s is out of date\n", opts.OutputFile)
+		}
+		return
+	}
+
+	// Always emit any generated code to the requested destination, even when
+	// generation returned an error (e.g. the formatter rejected the output).
+	// Writing to the output file lets the user inspect the broken source
+	// directly instead of having it interleaved with stderr.
+	if len(artifacts.Files) > 0 {
+		if opts.OutputFile != "" {
+			if err := writeOutput(outputFiles(opts.OutputFile, artifacts)); err != nil {
+				errExit("%s\n", err)
+			}
+		} else {
+			fmt.Print(string(artifacts.Files[0].Contents))
+		}
+	}
+
+	if flagReport != "" {
+		if err := writeReport(os.Stdout, artifacts.Report); err != nil {
+			errExit("error writing report: %s\n", err)
+		}
+	}
+
+	if genErr != nil {
+		errExit("error generating code: %s\n", genErr)
+	}
+}
+
+// loadConfiguration loads the configuration given by the flags: the config
+// file of -config, in the old or new style, with the flags applied to it and
+// the defaults filled in, and validates it.
+func loadConfiguration() (configuration, error) {
 	// We will try to infer whether the user has an old-style config, or a new
 	// style. Start with the command line argument. If it's true, we know it's
 	// old config style.
@@ -154,7 +324,7 @@ func main() {
 	if oldConfigStyle == nil && (flagConfigFile != "") {
 		configFile, err := os.ReadFile(flagConfigFile)
 		if err != nil {
-			errExit("error reading config file '%s': %v\n", flagConfigFile, err)
+			return configuration{}, fmt.Errorf("error reading config file '%s': %w", flagConfigFile, err)
 		}
 		var oldConfig oldConfiguration
 		oldDec := yaml.NewDecoder(bytes.NewReader(configFile))
@@ -175,7 +345,7 @@ func main() {
 			t := true
 			oldConfigStyle = &t
 		} else if oldErr != nil && newErr != nil {
-			errExit("error parsing configuration style as old version or new version\n\nerror when parsing using old config version:\n%v\n\nerror when parsing using new config version:\n%v\n", oldErr, newErr)
+			return configuration{}, fmt.Errorf("error parsing configuration style as old version or new version\n\nerror when parsing using old config version:\n%v\n\nerror when parsing using new config version:\n%v", oldErr, newErr)
 		}
 		// Else we fall through, and we still don't know, so we need to infer it from flags.
 	}
@@ -214,11 +384,11 @@ func main() {
 		if flagConfigFile != "" {
 			buf, err := os.ReadFile(flagConfigFile)
 			if err != nil {
-				errExit("error reading config file '%s': %v\n", flagConfigFile, err)
+				return configuration{}, fmt.Errorf("error reading config file '%s': %w", flagConfigFile, err)
 			}
 			err = yaml.Unmarshal(buf, &opts)
 			if err != nil {
-				errExit("error parsing'%s' as YAML: %v\n", flagConfigFile, err)
+				return configuration{}, fmt.Errorf("error parsing'%s' as YAML: %w", flagConfigFile, err)
 			}
 		} else {
 			// In the case where no config file is provided, we assume some
@@ -238,25 +408,25 @@ func main() {
 		}
 
 		if err := updateConfigFromFlags(&opts); err != nil {
-			errExit("error processing flags: %v\n", err)
+			return configuration{}, fmt.Errorf("error processing flags: %w", err)
 		}
 	} else {
 		var oldConfig oldConfiguration
 		if flagConfigFile != "" {
 			buf, err := os.ReadFile(flagConfigFile)
 			if err != nil {
-				errExit("error reading config file '%s': %v\n", flagConfigFile, err)
+				return configuration{}, fmt.Errorf("error reading config file '%s': %w", flagConfigFile, err)
 			}
 			err = yaml.Unmarshal(buf, &oldConfig)
 			if err != nil {
-				errExit("error parsing'%s' as YAML: %v\n", flagConfigFile, err)
+				return configuration{}, fmt.Errorf("error parsing'%s' as YAML: %w", flagConfigFile, err)
 			}
 		}
 		var err error
 		opts, err = newConfigFromOldConfig(oldConfig)
 		if err != nil {
 			flag.PrintDefaults()
-			errExit("error creating new config from old config: %v\n", err)
+			return configuration{}, fmt.Errorf("error creating new config from old config: %w", err)
 		}
 
 	}
@@ -266,89 +436,32 @@ func main() {
 	opts.Configuration = opts.UpdateDefaults()
 
 	if err := detectPackageName(&opts); err != nil {
-		errExit("%s\n", err)
+		return configuration{}, err
 	}
 
 	// Now, ensure that the config options are valid.
 	if err := opts.Validate(); err != nil {
-		errExit("configuration error: %v\n", err)
-	}
-
-	if warnings := opts.Generate.Warnings(); len(warnings) > 0 {
-		var out strings.Builder
-		out.WriteString("WARNING: A number of warning(s) were returned when validating the GenerateOptions:")
-		for k, v := range warnings {
-			out.WriteString("\n- " + k + ": " + v)
-		}
-
-		_, _ = fmt.Fprint(os.Stderr, out.String())
-	}
-
-	if warnings := opts.Warnings(); len(warnings) > 0 {
-		var out strings.Builder
-		out.WriteString("WARNING: A number of cross-field configuration warning(s) were returned:")
-		for k, v := range warnings {
-			out.WriteString("\n- " + k + ": " + v)
-		}
-		out.WriteString("\n")
-
-		_, _ = fmt.Fprint(os.Stderr, out.String())
+		return configuration{}, fmt.Errorf("configuration error: %w", err)
 	}
 
-	// If the user asked to output configuration, output it to stdout and exit
-	if flagOutputConfig {
-		var buf bytes.Buffer
-		enc := yaml.NewEncoder(&buf)
-		enc.SetIndent(2)
-		if err := enc.Encode(opts); err != nil {
-			errExit("error YAML marshaling configuration: %v\n", err)
-		}
-		_ = enc.Close()
-		fmt.Print(buf.String())
-		return
-	}
+	return opts, nil
+}
 
-	overlayOpts := util.LoadSwaggerWithOverlayOpts{
+// specLoadOptions returns the options of loading the spec of opts: with its
+// overlay, and in hermetic mode, its sandbox.
+func specLoadOptions(opts configuration) util.LoadSwaggerWithOverlayOpts {
+	loadOpts := util.LoadSwaggerWithOverlayOpts{
 		Path: opts.OutputOptions.Overlay.Path,
 		// default to strict, but can be overridden
 		Strict: true,
 	}
-
 	if opts.OutputOptions.Overlay.Strict != nil {
-		overlayOpts.Strict = *opts.OutputOptions.Overlay.Strict
+		loadOpts.Strict = *opts.OutputOptions.Overlay.Strict
 	}
-
-	swagger, err := util.LoadSwaggerWithOverlay(flag.Arg(0), overlayOpts)
-	if err != nil {
-		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
-	}
-
-	if len(noVCSVersionOverride) > 0 {
-		opts.NoVCSVersionOverride = &noVCSVersionOverride
-	}
-
-	code, genErr := codegen.Generate(swagger, opts.Configuration)
-
-	// Always emit any generated code to the requested destination, even when
-	// generation returned an error (e.g. the formatter rejected the output).
-	// Writing to the output file lets the user inspect the broken source
-	// directly instead of having it interleaved with stderr.
-	if code != "" {
-		if opts.OutputFile != "" {
-			if err := os.MkdirAll(filepath.Dir(opts.OutputFile), 0o755); err != nil {
-				errExit("error unable to create directory: %s\n", err)
-			}
-			if err := os.WriteFile(opts.OutputFile, []byte(code), 0o644); err != nil {
-				errExit("error writing generated code to file: %s\n", err)
-			}
-		} else {
-			fmt.Print(code)
-		}
-	}
-
-	if genErr != nil {
-		errExit("error generating code: %s\n", genErr)
+	if opts.Hermetic != nil {
+		loadOpts.Sandbox = opts.Hermetic.Sandbox()
 	}
+	return loadOpts
 }
 
 func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
@@ -392,6 +505,10 @@ func detectPackageName(cfg *configuration) error {
 	if cfg.PackageName != "" {
 		return nil
 	}
+	if cfg.Hermetic != nil {
+		// It'd be found with the go command, or from the spec file name.
+		return errors.New("hermetic mode needs the package name; set it with -package or `package` in the config")
+	}
 
 	if cfg.OutputFile != "" {
 		// Determine from the package name of the output file.
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// outputFile is a generated file, and where it's written.
+type outputFile struct {
+	path     string
+	contents []byte
+}
+
+// outputFiles returns where the files of a are written: the code to
+// codeFile, and the files it embeds, such as the embedded-spec-file, next to
+// it.
+func outputFiles(codeFile string, a *codegen.Artifacts) []outputFile {
+	var files []outputFile
+	for i, f := range a.Files {
+		path := codeFile
+		if i > 0 {
+			path = filepath.Join(filepath.Dir(codeFile), f.Name)
+		}
+		files = append(files, outputFile{path: path, contents: f.Contents})
+	}
+	return files
+}
+
+// writeOutput writes files, creating their directories.
+func writeOutput(files []outputFile) error {
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+			return fmt.Errorf("error unable to create directory: %w", err)
+		}
+		if err := os.WriteFile(f.path, f.contents, 0o644); err != nil {
+			return fmt.Errorf("error writing generated code to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkOutputFiles is checkOutput for each of files, returning their diffs
+// together.
+func checkOutputFiles(files []outputFile) (string, error) {
+	var diffs string
+	for _, f := range files {
+		diff, err := checkOutput(f.path, string(f.contents))
+		if err != nil {
+			return "", err
+		}
+		diffs += diff
+	}
+	return diffs, nil
+}
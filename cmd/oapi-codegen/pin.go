@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// runPin runs the pin subcommand:
+//
+//	oapi-codegen pin -config cfg.yaml [-o pins.yaml] spec
+//
+// It writes an overlay pinning the names of the code generated for the spec
+// with the configuration, to the -o file or out.
+func runPin(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("pin", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen pin -config cfg.yaml [-o pins.yaml] spec\n\n"+
+			"Writes an overlay which pins the names of the generated code, so that they stay the same\n"+
+			"across versions of oapi-codegen and changes of the spec.\n\n")
+		flags.PrintDefaults()
+	}
+	configFile := flags.String("config", "", "The YAML config file the code is generated with.")
+	outputFile := flags.String("o", "", "Where to write the overlay, stdout is default.")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if *configFile == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("pin needs -config, and a spec file")
+	}
+	specPath := flags.Arg(0)
+
+	opts, err := readConfiguration(*configFile)
+	if err != nil {
+		return err
+	}
+	opts.Configuration = opts.UpdateDefaults()
+	if opts.PackageName == "" {
+		opts.PackageName = "api"
+	}
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	swagger, err := util.LoadSwaggerWithOverlay(specPath, specLoadOptions(opts))
+	if err != nil {
+		return fmt.Errorf("error loading swagger spec in %s: %w", specPath, err)
+	}
+
+	actions, err := codegen.PinNames(swagger, opts.Configuration)
+	if err != nil {
+		return fmt.Errorf("error generating code for %s: %w", specPath, err)
+	}
+	buf, err := yaml.Marshal(codegen.NewOverlay("oapi-codegen pinned names for "+specPath, actions))
+	if err != nil {
+		return err
+	}
+
+	if *outputFile == "" {
+		_, err = out.Write(buf)
+		return err
+	}
+	if err := os.WriteFile(*outputFile, buf, 0o644); err != nil {
+		return fmt.Errorf("error writing overlay to %s: %w", *outputFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPin(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cfg.yaml": `
+package: pets
+generate:
+  models: true
+output-options:
+  skip-prune: true
+`,
+		"api.yaml": `openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        pet_id: {type: string}
+`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	const pins = `actions:
+    - target: $.components.schemas.Pet
+      update:
+        x-go-name: Pet
+    - target: $.components.schemas.Pet.properties.pet_id
+      update:
+        x-go-name: PetId
+`
+	var out strings.Builder
+	err := runPin([]string{"-config", path("cfg.yaml"), path("api.yaml")}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "overlay: 1.0.0\n")
+	assert.Contains(t, out.String(), pins)
+
+	err = runPin([]string{"-config", path("cfg.yaml"), "-o", path("pins.yaml"), path("api.yaml")}, &out)
+	require.NoError(t, err)
+	written, err := os.ReadFile(path("pins.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), pins)
+
+	err = runPin([]string{path("api.yaml")}, &out)
+	assert.ErrorContains(t, err, "pin needs -config")
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// reportFormatJSON is the format of -report=json, the only one so far.
+const reportFormatJSON = "json"
+
+// writeReport writes the report of a generation run as indented JSON.
+func writeReport(w io.Writer, report *codegen.Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+func TestWriteReport(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, writeReport(&out, &codegen.Report{
+		Types:    []codegen.ReportType{{Name: "Pet", Source: "#/components/schemas/Pet"}},
+		Warnings: []codegen.ReportWarning{{Code: "std-http-server", Message: "go.mod not found"}},
+	}))
+
+	assert.JSONEq(t, `{
+		"types": [{"name": "Pet", "source": "#/components/schemas/Pet"}],
+		"operations": null,
+		"name-resolutions": null,
+		"pruned-components": null,
+		"skipped-media-types": null,
+		"warnings": [{"code": "std-http-server", "message": "go.mod not found"}]
+	}`, out.String())
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// watcher regenerates the code of a spec each time one of its inputs
+// changes: the spec, the files it refers to, its overlay, the user templates
+// and the config file. It polls their modification times, so that it works
+// alike on all platforms and file systems, including network mounts and
+// containers' bind mounts, where file system events are unreliable.
+type watcher struct {
+	specPath string
+	// load loads the configuration, again for each generation.
+	load func() (configuration, error)
+	// configPath and templatesDir are watched as well as the files the
+	// configuration refers to.
+	configPath   string
+	templatesDir string
+	out          io.Writer
+
+	// interval is how often the inputs are polled, and debounce how long
+	// they must be unchanged after a change before the code is regenerated,
+	// so that an editor saving several files regenerates it once.
+	interval time.Duration
+	debounce time.Duration
+}
+
+// fileState is what's polled of a watched file. The zero value is a file
+// which doesn't exist.
+type fileState struct {
+	modTime int64
+	size    int64
+}
+
+// run generates the code, and then regenerates it each time its inputs
+// change, until stop is closed. It writes a line to out for each generation,
+// with a summary of the code, or its first error.
+func (w *watcher) run(stop <-chan struct{}) {
+	paths := w.generate()
+	last := w.snapshot(paths)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	var changedAt time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if current := w.snapshot(paths); !maps.Equal(current, last) {
+				last = current
+				changedAt = now
+				continue
+			}
+			if changedAt.IsZero() || now.Sub(changedAt) < w.debounce {
+				continue
+			}
+			changedAt = time.Time{}
+			// The inputs may have changed with the generation, e.g. when a
+			// $ref to a new file was added, so they're polled again.
+			paths = w.generate()
+			last = w.snapshot(paths)
+		}
+	}
+}
+
+// snapshot polls the files at paths, and those in the templates directory.
+func (w *watcher) snapshot(paths []string) map[string]fileState {
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		states[path] = fileState{}
+		if info, err := os.Stat(path); err == nil {
+			states[path] = fileState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+		}
+	}
+	if w.templatesDir != "" {
+		// A template added to the directory is a change, too.
+		_ = filepath.WalkDir(w.templatesDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				states[path] = fileState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return states
+}
+
+// generate generates the code and writes it to the output file, and prints
+// what it did. It returns the paths of the files it read, which may be
+// missing when the generation failed, so that their creation is a change.
+func (w *watcher) generate() []string {
+	start := time.Now()
+	var paths []string
+	if w.configPath != "" {
+		paths = append(paths, w.configPath)
+	}
+
+	summary, err := func() (string, error) {
+		opts, err := w.load()
+		if err != nil {
+			return "", err
+		}
+		if opts.OutputFile == "" {
+			return "", errors.New("-watch needs an output file to write; set it with -o or `output` in the config")
+		}
+		paths = append(paths, userTemplateFiles(opts.OutputOptions.UserTemplates)...)
+
+		overlayOpts := specLoadOptions(opts)
+		// The files are read again for each generation, rather than from
+		// the cache of the default reader, and recorded to be watched.
+		overlayOpts.ReadFromURIFunc = openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+			if location.Host == "" && (location.Scheme == "" || location.Scheme == "file") {
+				paths = append(paths, filepath.FromSlash(location.Path))
+			}
+			return openapi3.ReadFromFile(loader, location)
+		})
+		if overlayOpts.Path != "" {
+			paths = append(paths, overlayOpts.Path)
+		}
+		if opts.Hermetic != nil {
+			// The sandbox reads the files it maps, instead.
+			paths = append(paths, slices.Collect(maps.Values(opts.Hermetic.Files))...)
+		}
+		if !isURL(w.specPath) {
+			// The spec is watched even when it can't be read.
+			paths = append(paths, w.specPath)
+		}
+		swagger, err := util.LoadSwaggerWithOverlay(w.specPath, overlayOpts)
+		if err != nil {
+			return "", fmt.Errorf("error loading swagger spec in %s: %w", w.specPath, err)
+		}
+
+		if len(noVCSVersionOverride) > 0 {
+			opts.NoVCSVersionOverride = &noVCSVersionOverride
+		}
+		a, genErr := codegen.GenerateArtifacts(swagger, opts.Configuration)
+		// As without -watch, the code is written even when it failed to be
+		// formatted, to be inspected.
+		if err := writeOutput(outputFiles(opts.OutputFile, a)); err != nil {
+			return "", err
+		}
+		if genErr != nil {
+			return "", fmt.Errorf("error generating code: %w", genErr)
+		}
+		return fmt.Sprintf("wrote %s: %d types, %d operations", opts.OutputFile, len(a.Report.Types), len(a.Report.Operations)), nil
+	}()
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		_, _ = fmt.Fprintf(w.out, "%s %s\n", start.Format(time.TimeOnly), firstError(err))
+	} else {
+		_, _ = fmt.Fprintf(w.out, "%s %s in %s\n", start.Format(time.TimeOnly), summary, elapsed)
+	}
+
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// userTemplateFiles returns the paths of the files of the user templates
+// given by path, rather than inline or by URL.
+func userTemplateFiles(templates map[string]string) []string {
+	var paths []string
+	for _, template := range templates {
+		if strings.Contains(template, "\n") || isURL(template) {
+			continue
+		}
+		paths = append(paths, template)
+	}
+	return paths
+}
+
+// firstError returns the first line of err, with how many more errors it
+// joins, to print a concise error for each generation.
+func firstError(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		if n := len(joined.Unwrap()); n > 1 {
+			msg += fmt.Sprintf(" (and %d more errors)", n-1)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// lineWriter sends each line written to it to a channel.
+type lineWriter chan string
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	for line := range strings.Lines(string(p)) {
+		w <- strings.TrimSuffix(line, "\n")
+	}
+	return len(p), nil
+}
+
+func nextLine(t *testing.T, lines lineWriter) string {
+	t.Helper()
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "no generation in time")
+		return ""
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"api.yaml": `
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    $ref: "./pets.yaml#/paths/~1pets"
+`,
+		"pets.yaml": `
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+`,
+	})
+	output := filepath.Join(dir, "api", "api.gen.go")
+
+	lines := make(lineWriter, 10)
+	w := &watcher{
+		specPath: filepath.Join(dir, "api.yaml"),
+		load: func() (configuration, error) {
+			return configuration{
+				Configuration: codegen.Configuration{
+					PackageName: "api",
+					Generate:    codegen.GenerateOptions{Models: true, Client: true},
+				},
+				OutputFile: output,
+			}, nil
+		},
+		out:      lines,
+		interval: 5 * time.Millisecond,
+		debounce: 20 * time.Millisecond,
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		w.run(stop)
+		close(done)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	assert.Contains(t, nextLine(t, lines), "wrote "+output+": 0 types, 1 operations in ")
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.NotContains(t, string(code), "Tag ")
+
+	// A change to the file the spec refers to regenerates the code.
+	writeFiles(t, dir, map[string]string{"pets.yaml": `
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                  tag:
+                    type: string
+`})
+	assert.Contains(t, nextLine(t, lines), "wrote "+output)
+	code, err = os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "Tag ")
+
+	// An error is printed on one line, and the watch goes on.
+	writeFiles(t, dir, map[string]string{"pets.yaml": "paths: [\n"})
+	line := nextLine(t, lines)
+	assert.Contains(t, line, "error loading swagger spec in "+filepath.Join(dir, "api.yaml"))
+	assert.NotContains(t, line, "\n")
+
+	writeFiles(t, dir, map[string]string{"pets.yaml": `
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: string
+`})
+	assert.Contains(t, nextLine(t, lines), "wrote "+output)
+}
+
+func TestWatchFiles(t *testing.T) {
+	assert.Equal(t, []string{"tmpl/client.tmpl"}, userTemplateFiles(map[string]string{
+		"client.tmpl":  "tmpl/client.tmpl",
+		"chi/chi.tmpl": "https://example.com/chi.tmpl",
+		"typedef.tmpl": "{{range .Types}}\n{{end}}",
+	}))
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/mod/modfile"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// workspace is a manifest of the packages to generate in a single run with
+// the -workspace flag, e.g. from an oapi-codegen.workspace.yaml:
+//
+//	packages:
+//	  - spec: api/common.yaml
+//	    config: common/cfg.yaml
+//	  - spec: api/admin.yaml
+//	    config: admin/cfg.yaml
+//
+// Where the spec of one package has a $ref to the spec of another, the
+// import-mapping between them is added to its configuration, so the configs
+// needn't list the other packages of the workspace.
+type workspace struct {
+	Packages []workspacePackage `yaml:"packages"`
+}
+
+// workspacePackage is one generated file of a workspace. Its paths are
+// relative to the directory of the manifest.
+type workspacePackage struct {
+	// Spec is the path or URL of the OpenAPI spec.
+	Spec string `yaml:"spec"`
+	// Config is the path of the configuration file, as given to -config. The
+	// paths of its `output`, `output-options.overlay` and `hermetic.files`
+	// are relative to its directory, as when it's run with go:generate from
+	// there.
+	Config string `yaml:"config"`
+	// Output, if set, overrides the `output` of the configuration.
+	Output string `yaml:"output,omitempty"`
+	// ImportPath is the Go import path of the generated package, used in the
+	// import-mapping of the packages referring to it. By default it's found
+	// from the go.mod of the module containing the output file.
+	ImportPath string `yaml:"import-path,omitempty"`
+}
+
+// workspaceJob is a workspace package with its configuration resolved.
+type workspaceJob struct {
+	workspacePackage
+	opts configuration
+	// specPath is the spec as an absolute path, unless it's a URL.
+	specPath   string
+	importPath string
+	// diff is the diff of the output file, when it's checked and out of
+	// date.
+	diff string
+}
+
+// runWorkspace generates all the packages of the workspace manifest at
+// manifestPath, and reports the errors of all that failed. With check, it
+// writes nothing, but prints the diffs of the output files which are out of
+// date, and reports them as errors.
+func runWorkspace(manifestPath string, check bool) error {
+	buf, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("error reading workspace manifest '%s': %w", manifestPath, err)
+	}
+	var ws workspace
+	dec := yaml.NewDecoder(bytes.NewReader(buf))
+	dec.KnownFields(true)
+	if err := dec.Decode(&ws); err != nil {
+		return fmt.Errorf("error parsing workspace manifest '%s': %w", manifestPath, err)
+	}
+	if len(ws.Packages) == 0 {
+		return fmt.Errorf("workspace manifest '%s' lists no packages", manifestPath)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return err
+	}
+	jobs := make([]*workspaceJob, len(ws.Packages))
+	for i, p := range ws.Packages {
+		if jobs[i], err = newWorkspaceJob(dir, p); err != nil {
+			return fmt.Errorf("workspace package %d (%s): %w", i+1, p.Spec, err)
+		}
+	}
+	mapWorkspaceImports(jobs)
+
+	// Load each distinct spec once, with its overlay and hermetic files,
+	// before generating. The loads, and then the jobs, are independent, so
+	// they run in parallel.
+	specs := map[string]*workspaceSpec{}
+	var loads []*workspaceSpec
+	for _, job := range jobs {
+		key := job.specKey()
+		if specs[key] == nil {
+			specs[key] = &workspaceSpec{job: job}
+			loads = append(loads, specs[key])
+		}
+	}
+	parallel(len(loads), func(i int) {
+		spec := loads[i]
+		spec.swagger, spec.err = util.LoadSwaggerWithOverlay(spec.job.specPath, specLoadOptions(spec.job.opts))
+	})
+
+	// Generation filters and prunes its spec in place, so each job has its
+	// own copy.
+	errs := make([]error, len(jobs))
+	parallel(len(jobs), func(i int) {
+		job := jobs[i]
+		spec := specs[job.specKey()]
+		if spec.err != nil {
+			errs[i] = fmt.Errorf("%s: error loading swagger spec in %s: %w", job.opts.OutputFile, job.Spec, spec.err)
+			return
+		}
+		if err := job.run(util.CopySwagger(spec.swagger), check); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", job.opts.OutputFile, err)
+		}
+	})
+
+	// Print the diffs once all are done, so they don't interleave.
+	for i, job := range jobs {
+		if job.diff != "" {
+			fmt.Print(job.diff)
+			errs[i] = fmt.Errorf("%s is out of date", job.opts.OutputFile)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newWorkspaceJob reads the configuration of a workspace package, and
+// resolves its paths.
+func newWorkspaceJob(dir string, p workspacePackage) (*workspaceJob, error) {
+	if p.Spec == "" || p.Config == "" {
+		return nil, errors.New("both `spec` and `config` must be set")
+	}
+	job := &workspaceJob{workspacePackage: p, specPath: p.Spec}
+	if !isURL(p.Spec) {
+		job.specPath = resolvePath(dir, p.Spec)
+	}
+
+	configPath := resolvePath(dir, p.Config)
+	var err error
+	if job.opts, err = readConfiguration(configPath); err != nil {
+		return nil, err
+	}
+
+	configDir := filepath.Dir(configPath)
+	switch {
+	case p.Output != "":
+		job.opts.OutputFile = resolvePath(dir, p.Output)
+	case job.opts.OutputFile != "":
+		job.opts.OutputFile = resolvePath(configDir, job.opts.OutputFile)
+	default:
+		return nil, errors.New("no output file is set, in the workspace or the configuration")
+	}
+	if job.opts.OutputOptions.Overlay.Path != "" {
+		job.opts.OutputOptions.Overlay.Path = resolvePath(configDir, job.opts.OutputOptions.Overlay.Path)
+	}
+	if job.opts.Hermetic != nil {
+		files := make(map[string]string, len(job.opts.Hermetic.Files))
+		for name, file := range job.opts.Hermetic.Files {
+			files[name] = resolvePath(configDir, file)
+		}
+		job.opts.Hermetic = &codegen.HermeticOptions{Files: files}
+	}
+
+	job.opts.Configuration = job.opts.UpdateDefaults()
+	if job.opts.PackageName == "" {
+		// Name the package after its directory, as the go:generate run
+		// there would find it.
+		job.opts.PackageName = codegen.LowercaseFirstCharacter(codegen.ToCamelCase(filepath.Base(filepath.Dir(job.opts.OutputFile))))
+	}
+	if err := job.opts.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	if len(noVCSVersionOverride) > 0 {
+		job.opts.NoVCSVersionOverride = &noVCSVersionOverride
+	}
+
+	job.importPath = p.ImportPath
+	if job.importPath == "" && job.opts.Hermetic != nil {
+		// It'd be found from the go.mod of the output.
+		return nil, errors.New("hermetic mode needs the `import-path` of the package in the workspace")
+	}
+	if job.importPath == "" {
+		job.importPath, err = goImportPath(filepath.Dir(job.opts.OutputFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+// workspaceSpec is a spec loaded for the jobs sharing it.
+type workspaceSpec struct {
+	// job is the first of the jobs, whose options it's loaded with.
+	job     *workspaceJob
+	swagger *openapi3.T
+	err     error
+}
+
+// specKey identifies the spec of the job as loaded, with its overlay and
+// hermetic files, which jobs with the same key can share.
+func (job *workspaceJob) specKey() string {
+	loadOpts := specLoadOptions(job.opts)
+	var files map[string]string
+	if job.opts.Hermetic != nil {
+		files = job.opts.Hermetic.Files
+	}
+	// %v prints maps sorted by key.
+	return fmt.Sprintf("%q %q %t %v", job.specPath, loadOpts.Path, loadOpts.Strict, files)
+}
+
+// parallel calls fn with each of 0 to n-1, running up to GOMAXPROCS calls at
+// once, and returns when all are done.
+func parallel(n int, fn func(i int)) {
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// run generates the code of the job from its copy of the spec, and writes it
+// to its output file, or with check, compares it to the file.
+func (job *workspaceJob) run(swagger *openapi3.T, check bool) error {
+	var err error
+	a, genErr := codegen.GenerateArtifacts(swagger, job.opts.Configuration)
+	if check {
+		if genErr != nil {
+			return fmt.Errorf("error generating code: %w", genErr)
+		}
+		job.diff, err = checkOutputFiles(outputFiles(job.opts.OutputFile, a))
+		return err
+	}
+
+	// As with a single spec, write whatever was generated, even on error.
+	if err := writeOutput(outputFiles(job.opts.OutputFile, a)); err != nil {
+		return err
+	}
+	if genErr != nil {
+		return fmt.Errorf("error generating code: %w", genErr)
+	}
+	return nil
+}
+
+// mapWorkspaceImports adds to the import-mapping of each job the external
+// references of its spec to the specs of other jobs, mapped to the package
+// which has the models of the referenced spec. Mappings set in a
+// configuration are kept.
+func mapWorkspaceImports(jobs []*workspaceJob) {
+	// The package of a spec's models is the first one generating them.
+	modelsBySpec := map[string]*workspaceJob{}
+	for _, job := range jobs {
+		if _, ok := modelsBySpec[job.specPath]; !ok && job.opts.Generate.Models {
+			modelsBySpec[job.specPath] = job
+		}
+	}
+
+	for _, job := range jobs {
+		if isURL(job.specPath) {
+			continue
+		}
+		refs, err := specFileRefs(job.specPath)
+		if err != nil {
+			// Loading the spec fails the same way, which is reported along
+			// with the results of the other jobs.
+			continue
+		}
+		for _, ref := range refs {
+			if _, ok := job.opts.ImportMapping[ref]; ok {
+				continue
+			}
+			target, ok := modelsBySpec[resolvePath(filepath.Dir(job.specPath), ref)]
+			if !ok || target.specPath == job.specPath {
+				continue
+			}
+			if job.opts.ImportMapping == nil {
+				job.opts.ImportMapping = map[string]string{}
+			}
+			if target.importPath == job.importPath {
+				job.opts.ImportMapping[ref] = "-"
+			} else {
+				job.opts.ImportMapping[ref] = target.importPath
+			}
+		}
+	}
+}
+
+// specFileRefs returns the distinct files, other than itself, referred to by
+// the $refs of the spec at path, as written there.
+func specFileRefs(path string) ([]string, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading spec '%s': %w", path, err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(buf, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing spec '%s': %w", path, err)
+	}
+
+	var refs []string
+	seen := map[string]bool{}
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if ref, ok := value.(string); ok && key == "$ref" {
+					if file, _, _ := strings.Cut(ref, "#"); file != "" && !isURL(file) && !seen[file] {
+						seen[file] = true
+						refs = append(refs, file)
+					}
+					continue
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(doc)
+	return refs, nil
+}
+
+// goImportPath returns the Go import path of the package in dir, from the
+// go.mod of the module containing it.
+func goImportPath(dir string) (string, error) {
+	for modDir := dir; ; {
+		buf, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+		if err == nil {
+			modulePath := modfile.ModulePath(buf)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module path in %s", filepath.Join(modDir, "go.mod"))
+			}
+			rel, err := filepath.Rel(modDir, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		parent := filepath.Dir(modDir)
+		if parent == modDir {
+			return "", fmt.Errorf("no go.mod found for %s; please set the `import-path` of the package", dir)
+		}
+		modDir = parent
+	}
+}
+
+// resolvePath returns path relative to dir, unless it's absolute.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
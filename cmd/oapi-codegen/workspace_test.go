@@ -0,0 +1,257 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+var workspaceFiles = map[string]string{
+	"go.mod": "module example.com/ws\n",
+	"oapi-codegen.workspace.yaml": `
+packages:
+  - spec: api/admin.yaml
+    config: admin/cfg.yaml
+  - spec: api/common.yaml
+    config: common/cfg.yaml
+`,
+	"api/common.yaml": `
+openapi: "3.0.0"
+info: {title: Common, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`,
+	"api/admin.yaml": `
+openapi: "3.0.0"
+info: {title: Admin, version: 1.0.0}
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        "200":
+          description: The users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "./common.yaml#/components/schemas/User"
+components:
+  schemas:
+    Admin:
+      type: object
+      properties:
+        user:
+          $ref: "./common.yaml#/components/schemas/User"
+`,
+	"admin/cfg.yaml": `
+package: admin
+output: admin.gen.go
+generate:
+  models: true
+  client: true
+`,
+	"common/cfg.yaml": `
+output: common.gen.go
+generate:
+  models: true
+output-options:
+  skip-prune: true
+`,
+}
+
+func TestRunWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, workspaceFiles)
+
+	require.NoError(t, runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false))
+
+	common, err := os.ReadFile(filepath.Join(dir, "common", "common.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(common), "package common\n")
+	assert.Contains(t, string(common), "type User struct {")
+
+	admin, err := os.ReadFile(filepath.Join(dir, "admin", "admin.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(admin), "package admin\n")
+	assert.Contains(t, string(admin), `externalRef0 "example.com/ws/common"`)
+	assert.Contains(t, string(admin), "JSON200 *[]externalRef0.User")
+}
+
+func TestRunWorkspaceReportsAllFailures(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, workspaceFiles)
+	writeFiles(t, dir, map[string]string{
+		"oapi-codegen.workspace.yaml": `
+packages:
+  - spec: api/common.yaml
+    config: common/cfg.yaml
+  - spec: api/missing.yaml
+    config: common/cfg.yaml
+    output: missing/missing.gen.go
+    import-path: example.com/other/missing
+`,
+	})
+
+	err := runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.gen.go")
+	assert.Contains(t, err.Error(), "api/missing.yaml")
+
+	// The other package is still generated.
+	_, err = os.Stat(filepath.Join(dir, "common", "common.gen.go"))
+	assert.NoError(t, err)
+}
+
+func TestRunWorkspaceInvalidManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ws.yaml":  "packages:\n  - spec: api.yaml\n    config: cfg.yaml\n    unknown: true\n",
+		"ws2.yaml": "packages:\n  - spec: api.yaml\n    config: cfg.yaml\n",
+		"cfg.yaml": "package: api\ngenerate:\n  models: true\n",
+	})
+
+	err := runWorkspace(filepath.Join(dir, "ws.yaml"), false)
+	assert.ErrorContains(t, err, "field unknown not found")
+
+	err = runWorkspace(filepath.Join(dir, "ws2.yaml"), false)
+	assert.ErrorContains(t, err, "no output file")
+}
+
+func TestRunWorkspaceHermetic(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, workspaceFiles)
+	writeFiles(t, dir, map[string]string{
+		"admin/cfg.yaml": `
+package: admin
+output: admin.gen.go
+generate:
+  models: true
+  client: true
+hermetic:
+  files:
+    common.yaml: ../api/common.yaml
+`,
+		"common/cfg.yaml": `
+package: common
+output: common.gen.go
+generate:
+  models: true
+output-options:
+  skip-prune: true
+hermetic: {}
+`,
+	})
+
+	// The import paths aren't found from the go.mod.
+	err := runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false)
+	assert.ErrorContains(t, err, "hermetic mode needs the `import-path`")
+
+	writeFiles(t, dir, map[string]string{
+		"oapi-codegen.workspace.yaml": `
+packages:
+  - spec: api/admin.yaml
+    config: admin/cfg.yaml
+    import-path: example.com/ws/admin
+  - spec: api/common.yaml
+    config: common/cfg.yaml
+    import-path: example.com/ws/common
+`,
+	})
+	require.NoError(t, runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false))
+	admin, err := os.ReadFile(filepath.Join(dir, "admin", "admin.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(admin), `externalRef0 "example.com/ws/common"`)
+
+	// The spec may only refer to the files of the configuration.
+	writeFiles(t, dir, map[string]string{
+		"admin/cfg.yaml": "package: admin\noutput: admin.gen.go\ngenerate:\n  models: true\nhermetic: {}\n",
+	})
+	err = runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false)
+	assert.ErrorContains(t, err, "hermetic mode doesn't allow reading:\n- common.yaml: not in the hermetic files")
+}
+
+func TestRunWorkspaceSharedSpec(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/ws\n",
+		"oapi-codegen.workspace.yaml": `
+packages:
+  - spec: api.yaml
+    config: pets/cfg.yaml
+  - spec: api.yaml
+    config: users/cfg.yaml
+`,
+		"pets/cfg.yaml":  "output: pets.gen.go\ngenerate:\n  models: true\n  client: true\noutput-options:\n  include-tags: [pets]\n",
+		"users/cfg.yaml": "output: users.gen.go\ngenerate:\n  models: true\n  client: true\noutput-options:\n  include-tags: [users]\n",
+		"api.yaml": `
+openapi: "3.0.0"
+info: {title: Shared, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+  /users:
+    get:
+      operationId: listUsers
+      tags: [users]
+      responses:
+        "200":
+          description: The users
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+components:
+  schemas:
+    Pet:
+      type: object
+    User:
+      type: object
+`,
+	})
+	// The spec is loaded once, but each package filters and prunes its own
+	// copy.
+	require.NoError(t, runWorkspace(filepath.Join(dir, "oapi-codegen.workspace.yaml"), false))
+
+	pets, err := os.ReadFile(filepath.Join(dir, "pets", "pets.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pets), "func (c *Client) ListPets(")
+	assert.Contains(t, string(pets), "type Pet ")
+	assert.NotContains(t, string(pets), "ListUsers")
+	assert.NotContains(t, string(pets), "type User ")
+
+	users, err := os.ReadFile(filepath.Join(dir, "users", "users.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(users), "func (c *Client) ListUsers(")
+	assert.Contains(t, string(users), "type User ")
+	assert.NotContains(t, string(users), "ListPets")
+	assert.NotContains(t, string(users), "type Pet ")
+}
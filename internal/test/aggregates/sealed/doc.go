@@ -0,0 +1,6 @@
+// Package aggregatessealed exercises the sealed-discriminated-unions output
+// option: a discriminated oneOf generated as a sealed interface, held in
+// struct fields, slices, maps, aliases and request/response bodies.
+package aggregatessealed
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
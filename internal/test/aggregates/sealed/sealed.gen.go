@@ -0,0 +1,2541 @@
+//go:build go1.22
+
+// Package aggregatessealed provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package aggregatessealed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Cat defines model for Cat.
+type Cat struct {
+	Indoor  *bool  `json:"indoor,omitempty"`
+	Name    string `json:"name"`
+	PetType string `json:"petType"`
+}
+
+// Dog defines model for Dog.
+type Dog struct {
+	Breed   *string `json:"breed,omitempty"`
+	Name    string  `json:"name"`
+	PetType string  `json:"petType"`
+}
+
+// Lizard defines model for Lizard.
+type Lizard struct {
+	PetType string `json:"petType"`
+	Scales  *int   `json:"scales,omitempty"`
+}
+
+// Owner defines model for Owner.
+type Owner struct {
+	Companion  Owner_Companion `json:"companion,omitempty"`
+	Favourite  PetAlias        `json:"favourite,omitempty"`
+	Name       string          `json:"name"`
+	Pet        Pet             `json:"pet"`
+	Pets       *[]Pet          `json:"pets,omitempty"`
+	PetsByName *map[string]Pet `json:"petsByName,omitempty"`
+}
+
+// Owner_Companion defines model for Owner.Companion.
+type Owner_Companion interface {
+	isOwner_Companion()
+}
+
+// Pet defines model for Pet.
+type Pet interface {
+	isPet()
+}
+
+// PetAlias defines model for PetAlias.
+type PetAlias = Pet
+
+// Pets defines model for Pets.
+type Pets []Pet
+
+// Shelter defines model for Shelter.
+type Shelter struct {
+	Resident             Pet               `json:"resident,omitempty"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// PetResponse defines model for PetResponse.
+type PetResponse = Pet
+
+// AddShelter200JSONResponseBody defines parameters for AddShelter.
+type AddShelter200JSONResponseBody struct {
+	Residents *[]Pet `json:"residents,omitempty"`
+}
+
+// AddVisitJSONBody defines parameters for AddVisit.
+type AddVisitJSONBody []Pet
+
+// AddVisit200JSONResponseBody defines parameters for AddVisit.
+type AddVisit200JSONResponseBody interface {
+	isAddVisit200JSONResponseBody()
+}
+
+// AddOwnerJSONRequestBody defines body for AddOwner for application/json ContentType.
+type AddOwnerJSONRequestBody = Owner
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody struct {
+	Pet
+}
+
+func (t AddPetJSONRequestBody) MarshalJSON() ([]byte, error) {
+	return MarshalPet(t.Pet)
+}
+
+func (t *AddPetJSONRequestBody) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalPet(b)
+	if err != nil {
+		return err
+	}
+	t.Pet = v
+	return nil
+}
+
+// AddShelterJSONRequestBody defines body for AddShelter for application/json ContentType.
+type AddShelterJSONRequestBody = Shelter
+
+// AddVisitJSONRequestBody defines body for AddVisit for application/json ContentType.
+type AddVisitJSONRequestBody AddVisitJSONBody
+
+func (t AddVisitJSONRequestBody) MarshalJSON() ([]byte, error) {
+	return AddVisitJSONBody(t).MarshalJSON()
+}
+
+func (t *AddVisitJSONRequestBody) UnmarshalJSON(b []byte) error {
+	return (*AddVisitJSONBody)(t).UnmarshalJSON(b)
+}
+
+// Getter for additional properties for Shelter. Returns the specified
+// element and whether it was found
+func (a Shelter) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Shelter
+func (a *Shelter) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Shelter to handle AdditionalProperties
+func (a *Shelter) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["resident"]; found {
+		v, err := UnmarshalPet(raw)
+		if err != nil {
+			return fmt.Errorf("error reading 'resident': %w", err)
+		}
+		a.Resident = v
+		delete(object, "resident")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Shelter to handle AdditionalProperties
+func (a Shelter) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.Resident != nil {
+		raw, err := MarshalPet(a.Resident)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'resident': %w", err)
+		}
+		object["resident"] = raw
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+func (Cat) isOwner_Companion() {}
+
+func (Dog) isOwner_Companion() {}
+
+// UnmarshalOwner_Companion decodes a Owner_Companion, selecting the member type from
+// the "petType" discriminator. A JSON null decodes to a nil Owner_Companion.
+func UnmarshalOwner_Companion(b []byte) (Owner_Companion, error) {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil, nil
+	}
+	var discriminator struct {
+		Discriminator string `json:"petType"`
+	}
+	if err := json.Unmarshal(b, &discriminator); err != nil {
+		return nil, err
+	}
+	switch discriminator.Discriminator {
+	case "Cat":
+		var v Cat
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "Dog":
+		var v Dog
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator.Discriminator)
+	}
+}
+
+// MarshalOwner_Companion encodes a Owner_Companion, setting the "petType"
+// discriminator of its member type. A nil Owner_Companion encodes as JSON null.
+func MarshalOwner_Companion(v Owner_Companion) ([]byte, error) {
+	var patch string
+	switch v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case Cat, *Cat:
+		patch = `{"petType":"Cat"}`
+	case Dog, *Dog:
+		patch = `{"petType":"Dog"}`
+	}
+	b, err := json.Marshal(v)
+	if err != nil || patch == "" || string(b) == "null" {
+		return b, err
+	}
+	return runtime.JSONMerge(b, []byte(patch))
+}
+
+// UnmarshalOwner_CompanionSlice decodes a JSON array of Owner_Companion; see UnmarshalOwner_Companion.
+func UnmarshalOwner_CompanionSlice(b []byte) ([]Owner_Companion, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make([]Owner_Companion, len(items))
+	for i, item := range items {
+		v, err := UnmarshalOwner_Companion(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading item %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// MarshalOwner_CompanionSlice encodes a JSON array of Owner_Companion; see MarshalOwner_Companion.
+func MarshalOwner_CompanionSlice(values []Owner_Companion) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		b, err := MarshalOwner_Companion(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling item %d: %w", i, err)
+		}
+		items[i] = b
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalOwner_CompanionMap decodes a JSON object of Owner_Companion; see UnmarshalOwner_Companion.
+func UnmarshalOwner_CompanionMap(b []byte) (map[string]Owner_Companion, error) {
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make(map[string]Owner_Companion, len(items))
+	for k, item := range items {
+		v, err := UnmarshalOwner_Companion(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", k, err)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// MarshalOwner_CompanionMap encodes a JSON object of Owner_Companion; see MarshalOwner_Companion.
+func MarshalOwner_CompanionMap(values map[string]Owner_Companion) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make(map[string]json.RawMessage, len(values))
+	for k, v := range values {
+		b, err := MarshalOwner_Companion(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", k, err)
+		}
+		items[k] = b
+	}
+	return json.Marshal(items)
+}
+
+func (Cat) isPet() {}
+
+func (Dog) isPet() {}
+
+func (Lizard) isPet() {}
+
+// UnmarshalPet decodes a Pet, selecting the member type from
+// the "petType" discriminator. A JSON null decodes to a nil Pet.
+func UnmarshalPet(b []byte) (Pet, error) {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil, nil
+	}
+	var discriminator struct {
+		Discriminator string `json:"petType"`
+	}
+	if err := json.Unmarshal(b, &discriminator); err != nil {
+		return nil, err
+	}
+	switch discriminator.Discriminator {
+	case "cat":
+		var v Cat
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "dog":
+		var v Dog
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "gecko", "lizard":
+		var v Lizard
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator.Discriminator)
+	}
+}
+
+// MarshalPet encodes a Pet, setting the "petType"
+// discriminator of its member type. A nil Pet encodes as JSON null.
+func MarshalPet(v Pet) ([]byte, error) {
+	var patch string
+	switch v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case Cat, *Cat:
+		patch = `{"petType":"cat"}`
+	case Dog, *Dog:
+		patch = `{"petType":"dog"}`
+	}
+	b, err := json.Marshal(v)
+	if err != nil || patch == "" || string(b) == "null" {
+		return b, err
+	}
+	return runtime.JSONMerge(b, []byte(patch))
+}
+
+// UnmarshalPetSlice decodes a JSON array of Pet; see UnmarshalPet.
+func UnmarshalPetSlice(b []byte) ([]Pet, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make([]Pet, len(items))
+	for i, item := range items {
+		v, err := UnmarshalPet(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading item %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// MarshalPetSlice encodes a JSON array of Pet; see MarshalPet.
+func MarshalPetSlice(values []Pet) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		b, err := MarshalPet(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling item %d: %w", i, err)
+		}
+		items[i] = b
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalPetMap decodes a JSON object of Pet; see UnmarshalPet.
+func UnmarshalPetMap(b []byte) (map[string]Pet, error) {
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make(map[string]Pet, len(items))
+	for k, item := range items {
+		v, err := UnmarshalPet(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", k, err)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// MarshalPetMap encodes a JSON object of Pet; see MarshalPet.
+func MarshalPetMap(values map[string]Pet) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make(map[string]json.RawMessage, len(values))
+	for k, v := range values {
+		b, err := MarshalPet(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", k, err)
+		}
+		items[k] = b
+	}
+	return json.Marshal(items)
+}
+
+func (Cat) isAddVisit200JSONResponseBody() {}
+
+func (Dog) isAddVisit200JSONResponseBody() {}
+
+// UnmarshalAddVisit200JSONResponseBody decodes a AddVisit200JSONResponseBody, selecting the member type from
+// the "petType" discriminator. A JSON null decodes to a nil AddVisit200JSONResponseBody.
+func UnmarshalAddVisit200JSONResponseBody(b []byte) (AddVisit200JSONResponseBody, error) {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil, nil
+	}
+	var discriminator struct {
+		Discriminator string `json:"petType"`
+	}
+	if err := json.Unmarshal(b, &discriminator); err != nil {
+		return nil, err
+	}
+	switch discriminator.Discriminator {
+	case "Cat":
+		var v Cat
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "Dog":
+		var v Dog
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator.Discriminator)
+	}
+}
+
+// MarshalAddVisit200JSONResponseBody encodes a AddVisit200JSONResponseBody, setting the "petType"
+// discriminator of its member type. A nil AddVisit200JSONResponseBody encodes as JSON null.
+func MarshalAddVisit200JSONResponseBody(v AddVisit200JSONResponseBody) ([]byte, error) {
+	var patch string
+	switch v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case Cat, *Cat:
+		patch = `{"petType":"Cat"}`
+	case Dog, *Dog:
+		patch = `{"petType":"Dog"}`
+	}
+	b, err := json.Marshal(v)
+	if err != nil || patch == "" || string(b) == "null" {
+		return b, err
+	}
+	return runtime.JSONMerge(b, []byte(patch))
+}
+
+// UnmarshalAddVisit200JSONResponseBodySlice decodes a JSON array of AddVisit200JSONResponseBody; see UnmarshalAddVisit200JSONResponseBody.
+func UnmarshalAddVisit200JSONResponseBodySlice(b []byte) ([]AddVisit200JSONResponseBody, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make([]AddVisit200JSONResponseBody, len(items))
+	for i, item := range items {
+		v, err := UnmarshalAddVisit200JSONResponseBody(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading item %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// MarshalAddVisit200JSONResponseBodySlice encodes a JSON array of AddVisit200JSONResponseBody; see MarshalAddVisit200JSONResponseBody.
+func MarshalAddVisit200JSONResponseBodySlice(values []AddVisit200JSONResponseBody) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		b, err := MarshalAddVisit200JSONResponseBody(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling item %d: %w", i, err)
+		}
+		items[i] = b
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalAddVisit200JSONResponseBodyMap decodes a JSON object of AddVisit200JSONResponseBody; see UnmarshalAddVisit200JSONResponseBody.
+func UnmarshalAddVisit200JSONResponseBodyMap(b []byte) (map[string]AddVisit200JSONResponseBody, error) {
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil || items == nil {
+		return nil, err
+	}
+	values := make(map[string]AddVisit200JSONResponseBody, len(items))
+	for k, item := range items {
+		v, err := UnmarshalAddVisit200JSONResponseBody(item)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", k, err)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// MarshalAddVisit200JSONResponseBodyMap encodes a JSON object of AddVisit200JSONResponseBody; see MarshalAddVisit200JSONResponseBody.
+func MarshalAddVisit200JSONResponseBodyMap(values map[string]AddVisit200JSONResponseBody) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+	items := make(map[string]json.RawMessage, len(values))
+	for k, v := range values {
+		b, err := MarshalAddVisit200JSONResponseBody(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", k, err)
+		}
+		items[k] = b
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalPetAlias decodes a PetAlias; see UnmarshalPet.
+func UnmarshalPetAlias(b []byte) (PetAlias, error) {
+	return UnmarshalPet(b)
+}
+
+// MarshalPetAlias encodes a PetAlias; see MarshalPet.
+func MarshalPetAlias(v PetAlias) ([]byte, error) {
+	return MarshalPet(v)
+}
+
+// UnmarshalPetAliasSlice decodes a JSON array of PetAlias; see UnmarshalPetSlice.
+func UnmarshalPetAliasSlice(b []byte) ([]PetAlias, error) {
+	return UnmarshalPetSlice(b)
+}
+
+// MarshalPetAliasSlice encodes a JSON array of PetAlias; see MarshalPetSlice.
+func MarshalPetAliasSlice(values []PetAlias) ([]byte, error) {
+	return MarshalPetSlice(values)
+}
+
+// UnmarshalPetAliasMap decodes a JSON object of PetAlias; see UnmarshalPetMap.
+func UnmarshalPetAliasMap(b []byte) (map[string]PetAlias, error) {
+	return UnmarshalPetMap(b)
+}
+
+// MarshalPetAliasMap encodes a JSON object of PetAlias; see MarshalPetMap.
+func MarshalPetAliasMap(values map[string]PetAlias) ([]byte, error) {
+	return MarshalPetMap(values)
+}
+
+// UnmarshalPetResponse decodes a PetResponse; see UnmarshalPet.
+func UnmarshalPetResponse(b []byte) (PetResponse, error) {
+	return UnmarshalPet(b)
+}
+
+// MarshalPetResponse encodes a PetResponse; see MarshalPet.
+func MarshalPetResponse(v PetResponse) ([]byte, error) {
+	return MarshalPet(v)
+}
+
+// UnmarshalPetResponseSlice decodes a JSON array of PetResponse; see UnmarshalPetSlice.
+func UnmarshalPetResponseSlice(b []byte) ([]PetResponse, error) {
+	return UnmarshalPetSlice(b)
+}
+
+// MarshalPetResponseSlice encodes a JSON array of PetResponse; see MarshalPetSlice.
+func MarshalPetResponseSlice(values []PetResponse) ([]byte, error) {
+	return MarshalPetSlice(values)
+}
+
+// UnmarshalPetResponseMap decodes a JSON object of PetResponse; see UnmarshalPetMap.
+func UnmarshalPetResponseMap(b []byte) (map[string]PetResponse, error) {
+	return UnmarshalPetMap(b)
+}
+
+// MarshalPetResponseMap encodes a JSON object of PetResponse; see MarshalPetMap.
+func MarshalPetResponseMap(values map[string]PetResponse) ([]byte, error) {
+	return MarshalPetMap(values)
+}
+
+// UnmarshalJSON decodes Owner, selecting the member type of each
+// sealed union field from its discriminator.
+func (t *Owner) UnmarshalJSON(b []byte) error {
+	type plain Owner
+	aux := struct {
+		*plain
+		Companion  json.RawMessage `json:"companion,omitempty"`
+		Favourite  json.RawMessage `json:"favourite,omitempty"`
+		Pet        json.RawMessage `json:"pet"`
+		Pets       json.RawMessage `json:"pets,omitempty"`
+		PetsByName json.RawMessage `json:"petsByName,omitempty"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.Companion != nil {
+		v, err := UnmarshalOwner_Companion(aux.Companion)
+		if err != nil {
+			return fmt.Errorf("error reading 'companion': %w", err)
+		}
+		t.Companion = v
+	}
+	if aux.Favourite != nil {
+		v, err := UnmarshalPetAlias(aux.Favourite)
+		if err != nil {
+			return fmt.Errorf("error reading 'favourite': %w", err)
+		}
+		t.Favourite = v
+	}
+	if aux.Pet != nil {
+		v, err := UnmarshalPet(aux.Pet)
+		if err != nil {
+			return fmt.Errorf("error reading 'pet': %w", err)
+		}
+		t.Pet = v
+	}
+	if aux.Pets != nil {
+		v, err := UnmarshalPetSlice(aux.Pets)
+		if err != nil {
+			return fmt.Errorf("error reading 'pets': %w", err)
+		}
+		if v != nil {
+			t.Pets = &v
+		}
+	}
+	if aux.PetsByName != nil {
+		v, err := UnmarshalPetMap(aux.PetsByName)
+		if err != nil {
+			return fmt.Errorf("error reading 'petsByName': %w", err)
+		}
+		if v != nil {
+			t.PetsByName = &v
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes Owner, setting the discriminator of each
+// sealed union field.
+func (t Owner) MarshalJSON() ([]byte, error) {
+	type plain Owner
+	aux := struct {
+		plain
+		Companion  json.RawMessage `json:"companion,omitempty"`
+		Favourite  json.RawMessage `json:"favourite,omitempty"`
+		Pet        json.RawMessage `json:"pet"`
+		Pets       json.RawMessage `json:"pets,omitempty"`
+		PetsByName json.RawMessage `json:"petsByName,omitempty"`
+	}{plain: plain(t)}
+	if t.Companion != nil {
+		raw, err := MarshalOwner_Companion(t.Companion)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'companion': %w", err)
+		}
+		aux.Companion = raw
+	}
+	if t.Favourite != nil {
+		raw, err := MarshalPetAlias(t.Favourite)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'favourite': %w", err)
+		}
+		aux.Favourite = raw
+	}
+	if t.Pet != nil {
+		raw, err := MarshalPet(t.Pet)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'pet': %w", err)
+		}
+		aux.Pet = raw
+	}
+	if t.Pets != nil {
+		raw, err := MarshalPetSlice(*t.Pets)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'pets': %w", err)
+		}
+		aux.Pets = raw
+	}
+	if t.PetsByName != nil {
+		raw, err := MarshalPetMap(*t.PetsByName)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'petsByName': %w", err)
+		}
+		aux.PetsByName = raw
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes Pets, selecting the member type of each
+// Pet from its discriminator.
+func (t *Pets) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalPetSlice(b)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalJSON encodes Pets, setting the discriminator of each Pet.
+func (t Pets) MarshalJSON() ([]byte, error) {
+	return MarshalPetSlice(t)
+}
+
+// UnmarshalJSON decodes AddShelter200JSONResponseBody, selecting the member type of each
+// sealed union field from its discriminator.
+func (t *AddShelter200JSONResponseBody) UnmarshalJSON(b []byte) error {
+	type plain AddShelter200JSONResponseBody
+	aux := struct {
+		*plain
+		Residents json.RawMessage `json:"residents,omitempty"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.Residents != nil {
+		v, err := UnmarshalPetSlice(aux.Residents)
+		if err != nil {
+			return fmt.Errorf("error reading 'residents': %w", err)
+		}
+		if v != nil {
+			t.Residents = &v
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes AddShelter200JSONResponseBody, setting the discriminator of each
+// sealed union field.
+func (t AddShelter200JSONResponseBody) MarshalJSON() ([]byte, error) {
+	type plain AddShelter200JSONResponseBody
+	aux := struct {
+		plain
+		Residents json.RawMessage `json:"residents,omitempty"`
+	}{plain: plain(t)}
+	if t.Residents != nil {
+		raw, err := MarshalPetSlice(*t.Residents)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'residents': %w", err)
+		}
+		aux.Residents = raw
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes AddVisitJSONBody, selecting the member type of each
+// Pet from its discriminator.
+func (t *AddVisitJSONBody) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalPetSlice(b)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalJSON encodes AddVisitJSONBody, setting the discriminator of each Pet.
+func (t AddVisitJSONBody) MarshalJSON() ([]byte, error) {
+	return MarshalPetSlice(t)
+}
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// AddOwnerWithBody performs a POST /owners (the `AddOwner` operationId) request,
+	// with any type of body and a specified content type.
+	AddOwnerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddOwner performs a POST /owners (the `AddOwner` operationId) request.
+	// Takes a body of the `application/json` content type.
+	AddOwner(ctx context.Context, body AddOwnerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets performs a GET /pets (the `ListPets` operationId) request.
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPetWithBody performs a POST /pets (the `AddPet` operationId) request,
+	// with any type of body and a specified content type.
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPet performs a POST /pets (the `AddPet` operationId) request.
+	// Takes a body of the `application/json` content type.
+	AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FirstPet performs a GET /pets/first (the `FirstPet` operationId) request.
+	FirstPet(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddShelterWithBody performs a POST /shelters (the `AddShelter` operationId) request,
+	// with any type of body and a specified content type.
+	AddShelterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddShelter performs a POST /shelters (the `AddShelter` operationId) request.
+	// Takes a body of the `application/json` content type.
+	AddShelter(ctx context.Context, body AddShelterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddVisitWithBody performs a POST /visits (the `AddVisit` operationId) request,
+	// with any type of body and a specified content type.
+	AddVisitWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddVisit performs a POST /visits (the `AddVisit` operationId) request.
+	// Takes a body of the `application/json` content type.
+	AddVisit(ctx context.Context, body AddVisitJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// AddOwnerWithBody performs a POST /owners (the `AddOwner` operationId) request,
+// with any type of body and a specified content type.
+func (c *Client) AddOwnerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddOwnerRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddOwner performs a POST /owners (the `AddOwner` operationId) request.
+// Takes a body of the `application/json` content type.
+func (c *Client) AddOwner(ctx context.Context, body AddOwnerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddOwnerRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListPets performs a GET /pets (the `ListPets` operationId) request.
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddPetWithBody performs a POST /pets (the `AddPet` operationId) request,
+// with any type of body and a specified content type.
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddPet performs a POST /pets (the `AddPet` operationId) request.
+// Takes a body of the `application/json` content type.
+func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// FirstPet performs a GET /pets/first (the `FirstPet` operationId) request.
+func (c *Client) FirstPet(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFirstPetRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddShelterWithBody performs a POST /shelters (the `AddShelter` operationId) request,
+// with any type of body and a specified content type.
+func (c *Client) AddShelterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddShelterRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddShelter performs a POST /shelters (the `AddShelter` operationId) request.
+// Takes a body of the `application/json` content type.
+func (c *Client) AddShelter(ctx context.Context, body AddShelterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddShelterRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddVisitWithBody performs a POST /visits (the `AddVisit` operationId) request,
+// with any type of body and a specified content type.
+func (c *Client) AddVisitWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddVisitRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// AddVisit performs a POST /visits (the `AddVisit` operationId) request.
+// Takes a body of the `application/json` content type.
+func (c *Client) AddVisit(ctx context.Context, body AddVisitJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddVisitRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewAddOwnerRequest calls the generic AddOwner builder with application/json body
+func NewAddOwnerRequest(server string, body AddOwnerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddOwnerRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddOwnerRequestWithBody constructs an http.Request for the AddOwner method, with any body, and a specified content type
+func NewAddOwnerRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/owners"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListPetsRequest constructs an http.Request for the ListPets method
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody constructs an http.Request for the AddPet method, with any body, and a specified content type
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewFirstPetRequest constructs an http.Request for the FirstPet method
+func NewFirstPetRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets/first"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddShelterRequest calls the generic AddShelter builder with application/json body
+func NewAddShelterRequest(server string, body AddShelterJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddShelterRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddShelterRequestWithBody constructs an http.Request for the AddShelter method, with any body, and a specified content type
+func NewAddShelterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/shelters"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAddVisitRequest calls the generic AddVisit builder with application/json body
+func NewAddVisitRequest(server string, body AddVisitJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddVisitRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddVisitRequestWithBody constructs an http.Request for the AddVisit method, with any body, and a specified content type
+func NewAddVisitRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/visits"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// AddOwnerWithBodyWithResponse performs a POST /owners (the `AddOwner` operationId) request,
+	// with any type of body and a specified content type.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	AddOwnerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddOwnerResponse, error)
+
+	// AddOwnerWithResponse performs a POST /owners (the `AddOwner` operationId) request.
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	AddOwnerWithResponse(ctx context.Context, body AddOwnerJSONRequestBody, reqEditors ...RequestEditorFn) (*AddOwnerResponse, error)
+
+	// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// AddPetWithBodyWithResponse performs a POST /pets (the `AddPet` operationId) request,
+	// with any type of body and a specified content type.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	// AddPetWithResponse performs a POST /pets (the `AddPet` operationId) request.
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	// FirstPetWithResponse performs a GET /pets/first (the `FirstPet` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	FirstPetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FirstPetResponse, error)
+
+	// AddShelterWithBodyWithResponse performs a POST /shelters (the `AddShelter` operationId) request,
+	// with any type of body and a specified content type.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	AddShelterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddShelterResponse, error)
+
+	// AddShelterWithResponse performs a POST /shelters (the `AddShelter` operationId) request.
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	AddShelterWithResponse(ctx context.Context, body AddShelterJSONRequestBody, reqEditors ...RequestEditorFn) (*AddShelterResponse, error)
+
+	// AddVisitWithBodyWithResponse performs a POST /visits (the `AddVisit` operationId) request,
+	// with any type of body and a specified content type.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	AddVisitWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddVisitResponse, error)
+
+	// AddVisitWithResponse performs a POST /visits (the `AddVisit` operationId) request.
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	AddVisitWithResponse(ctx context.Context, body AddVisitJSONRequestBody, reqEditors ...RequestEditorFn) (*AddVisitResponse, error)
+}
+
+type AddOwnerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Owner
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r AddOwnerResponse) GetJSON200() *Owner {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r AddOwnerResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r AddOwnerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddOwnerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r AddOwnerResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Pets
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListPetsResponse) GetJSON200() *Pets {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListPetsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListPetsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type AddPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Pet
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r AddPetResponse) GetJSON200() *Pet {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r AddPetResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r AddPetResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type FirstPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *PetResponse
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r FirstPetResponse) GetJSON200() *PetResponse {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r FirstPetResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r FirstPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FirstPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r FirstPetResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type AddShelterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *AddShelter200JSONResponseBody
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r AddShelterResponse) GetJSON200() *AddShelter200JSONResponseBody {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r AddShelterResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r AddShelterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddShelterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r AddShelterResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type AddVisitResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *AddVisit200JSONResponseBody
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r AddVisitResponse) GetJSON200() *AddVisit200JSONResponseBody {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r AddVisitResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r AddVisitResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddVisitResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r AddVisitResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// AddOwnerWithBodyWithResponse performs a POST /owners (the `AddOwner` operationId) request,
+// with any type of body and a specified content type.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddOwnerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddOwnerResponse, error) {
+	rsp, err := c.AddOwnerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddOwnerResponse(rsp)
+}
+
+// AddOwnerWithResponse performs a POST /owners (the `AddOwner` operationId) request.
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddOwnerWithResponse(ctx context.Context, body AddOwnerJSONRequestBody, reqEditors ...RequestEditorFn) (*AddOwnerResponse, error) {
+	rsp, err := c.AddOwner(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddOwnerResponse(rsp)
+}
+
+// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// AddPetWithBodyWithResponse performs a POST /pets (the `AddPet` operationId) request,
+// with any type of body and a specified content type.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+// AddPetWithResponse performs a POST /pets (the `AddPet` operationId) request.
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+// FirstPetWithResponse performs a GET /pets/first (the `FirstPet` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) FirstPetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FirstPetResponse, error) {
+	rsp, err := c.FirstPet(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFirstPetResponse(rsp)
+}
+
+// AddShelterWithBodyWithResponse performs a POST /shelters (the `AddShelter` operationId) request,
+// with any type of body and a specified content type.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddShelterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddShelterResponse, error) {
+	rsp, err := c.AddShelterWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddShelterResponse(rsp)
+}
+
+// AddShelterWithResponse performs a POST /shelters (the `AddShelter` operationId) request.
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddShelterWithResponse(ctx context.Context, body AddShelterJSONRequestBody, reqEditors ...RequestEditorFn) (*AddShelterResponse, error) {
+	rsp, err := c.AddShelter(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddShelterResponse(rsp)
+}
+
+// AddVisitWithBodyWithResponse performs a POST /visits (the `AddVisit` operationId) request,
+// with any type of body and a specified content type.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddVisitWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddVisitResponse, error) {
+	rsp, err := c.AddVisitWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddVisitResponse(rsp)
+}
+
+// AddVisitWithResponse performs a POST /visits (the `AddVisit` operationId) request.
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AddVisitWithResponse(ctx context.Context, body AddVisitJSONRequestBody, reqEditors ...RequestEditorFn) (*AddVisitResponse, error) {
+	rsp, err := c.AddVisit(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddVisitResponse(rsp)
+}
+
+// ParseAddOwnerResponse parses an HTTP response from a AddOwnerWithResponse call
+func ParseAddOwnerResponse(rsp *http.Response) (*AddOwnerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddOwnerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Owner
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pets
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseAddPetResponse(rsp *http.Response) (*AddPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		dest, err := UnmarshalPet(bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFirstPetResponse parses an HTTP response from a FirstPetWithResponse call
+func ParseFirstPetResponse(rsp *http.Response) (*FirstPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FirstPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		dest, err := UnmarshalPetResponse(bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddShelterResponse parses an HTTP response from a AddShelterWithResponse call
+func ParseAddShelterResponse(rsp *http.Response) (*AddShelterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddShelterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddShelter200JSONResponseBody
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddVisitResponse parses an HTTP response from a AddVisitWithResponse call
+func ParseAddVisitResponse(rsp *http.Response) (*AddVisitResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddVisitResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		dest, err := UnmarshalAddVisit200JSONResponseBody(bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (POST /owners)
+	AddOwner(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/first)
+	FirstPet(w http.ResponseWriter, r *http.Request)
+
+	// (POST /shelters)
+	AddShelter(w http.ResponseWriter, r *http.Request)
+
+	// (POST /visits)
+	AddVisit(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// AddOwner operation middleware
+func (siw *ServerInterfaceWrapper) AddOwner(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddOwner(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// FirstPet operation middleware
+func (siw *ServerInterfaceWrapper) FirstPet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FirstPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddShelter operation middleware
+func (siw *ServerInterfaceWrapper) AddShelter(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddShelter(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddVisit operation middleware
+func (siw *ServerInterfaceWrapper) AddVisit(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddVisit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of [http.ServeMux].
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	http.Handler
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/pets", wrapper.ListPets)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/pets", wrapper.AddPet)
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/pets/first", wrapper.FirstPet)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/owners", wrapper.AddOwner)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/visits", wrapper.AddVisit)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/shelters", wrapper.AddShelter)
+
+	return m
+}
+
+type PetResponseJSONResponse struct {
+	Pet
+}
+
+func (t PetResponseJSONResponse) MarshalJSON() ([]byte, error) {
+	return MarshalPet(t.Pet)
+}
+
+func (t *PetResponseJSONResponse) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalPet(b)
+	if err != nil {
+		return err
+	}
+	t.Pet = v
+	return nil
+}
+
+type AddOwnerRequestObject struct {
+	Body *AddOwnerJSONRequestBody
+}
+
+type AddOwnerResponseObject interface {
+	VisitAddOwnerResponse(w http.ResponseWriter) error
+}
+
+type AddOwner200JSONResponse Owner
+
+func (t AddOwner200JSONResponse) MarshalJSON() ([]byte, error) {
+	return Owner(t).MarshalJSON()
+}
+
+func (t *AddOwner200JSONResponse) UnmarshalJSON(b []byte) error {
+	return (*Owner)(t).UnmarshalJSON(b)
+}
+
+func (response AddOwner200JSONResponse) VisitAddOwnerResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type ListPetsRequestObject struct {
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200JSONResponse Pets
+
+func (t ListPets200JSONResponse) MarshalJSON() ([]byte, error) {
+	return Pets(t).MarshalJSON()
+}
+
+func (t *ListPets200JSONResponse) UnmarshalJSON(b []byte) error {
+	return (*Pets)(t).UnmarshalJSON(b)
+}
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AddPetRequestObject struct {
+	Body *AddPetJSONRequestBody
+}
+
+type AddPetResponseObject interface {
+	VisitAddPetResponse(w http.ResponseWriter) error
+}
+
+type AddPet200JSONResponse struct {
+	Pet
+}
+
+func (t AddPet200JSONResponse) MarshalJSON() ([]byte, error) {
+	return MarshalPet(t.Pet)
+}
+
+func (t *AddPet200JSONResponse) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalPet(b)
+	if err != nil {
+		return err
+	}
+	t.Pet = v
+	return nil
+}
+
+func (response AddPet200JSONResponse) VisitAddPetResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type FirstPetRequestObject struct {
+}
+
+type FirstPetResponseObject interface {
+	VisitFirstPetResponse(w http.ResponseWriter) error
+}
+
+type FirstPet200JSONResponse struct{ PetResponseJSONResponse }
+
+func (response FirstPet200JSONResponse) VisitFirstPetResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AddShelterRequestObject struct {
+	Body *AddShelterJSONRequestBody
+}
+
+type AddShelterResponseObject interface {
+	VisitAddShelterResponse(w http.ResponseWriter) error
+}
+
+type AddShelter200JSONResponse = AddShelter200JSONResponseBody
+
+func (response AddShelter200JSONResponse) VisitAddShelterResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AddVisitRequestObject struct {
+	Body *AddVisitJSONRequestBody
+}
+
+type AddVisitResponseObject interface {
+	VisitAddVisitResponse(w http.ResponseWriter) error
+}
+
+type AddVisit200JSONResponse struct {
+	AddVisit200JSONResponseBody
+}
+
+func (t AddVisit200JSONResponse) MarshalJSON() ([]byte, error) {
+	return MarshalAddVisit200JSONResponseBody(t.AddVisit200JSONResponseBody)
+}
+
+func (t *AddVisit200JSONResponse) UnmarshalJSON(b []byte) error {
+	v, err := UnmarshalAddVisit200JSONResponseBody(b)
+	if err != nil {
+		return err
+	}
+	t.AddVisit200JSONResponseBody = v
+	return nil
+}
+
+func (response AddVisit200JSONResponse) VisitAddVisitResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (POST /owners)
+	AddOwner(ctx context.Context, request AddOwnerRequestObject) (AddOwnerResponseObject, error)
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error)
+
+	// (GET /pets/first)
+	FirstPet(ctx context.Context, request FirstPetRequestObject) (FirstPetResponseObject, error)
+
+	// (POST /shelters)
+	AddShelter(ctx context.Context, request AddShelterRequestObject) (AddShelterResponseObject, error)
+
+	// (POST /visits)
+	AddVisit(ctx context.Context, request AddVisitRequestObject) (AddVisitResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error)
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	if options.RequestErrorHandlerFunc == nil {
+		options.RequestErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	if options.ResponseErrorHandlerFunc == nil {
+		options.ResponseErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// AddOwner operation middleware
+func (sh *strictHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
+	var request AddOwnerRequestObject
+
+	var body AddOwnerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.AddOwner(ctx, request.(AddOwnerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddOwner")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddOwnerResponseObject); ok {
+		if err := validResponse.VisitAddOwnerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(w http.ResponseWriter, r *http.Request) {
+	var request ListPetsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.ListPets(ctx, request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		if err := validResponse.VisitListPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPet operation middleware
+func (sh *strictHandler) AddPet(w http.ResponseWriter, r *http.Request) {
+	var request AddPetRequestObject
+
+	var body AddPetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.AddPet(ctx, request.(AddPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPetResponseObject); ok {
+		if err := validResponse.VisitAddPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FirstPet operation middleware
+func (sh *strictHandler) FirstPet(w http.ResponseWriter, r *http.Request) {
+	var request FirstPetRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.FirstPet(ctx, request.(FirstPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FirstPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FirstPetResponseObject); ok {
+		if err := validResponse.VisitFirstPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddShelter operation middleware
+func (sh *strictHandler) AddShelter(w http.ResponseWriter, r *http.Request) {
+	var request AddShelterRequestObject
+
+	var body AddShelterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.AddShelter(ctx, request.(AddShelterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddShelter")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddShelterResponseObject); ok {
+		if err := validResponse.VisitAddShelterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddVisit operation middleware
+func (sh *strictHandler) AddVisit(w http.ResponseWriter, r *http.Request) {
+	var request AddVisitRequestObject
+
+	var body AddVisitJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return sh.ssi.AddVisit(ctx, request.(AddVisitRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddVisit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddVisitResponseObject); ok {
+		if err := validResponse.VisitAddVisitResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
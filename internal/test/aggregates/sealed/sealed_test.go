@@ -0,0 +1,191 @@
+package aggregatessealed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalPetSelectsMemberByDiscriminator(t *testing.T) {
+	pet, err := UnmarshalPet([]byte(`{"petType":"dog","name":"Rex","breed":"collie"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Dog{PetType: "dog", Name: "Rex", Breed: ptr("collie")}, pet)
+
+	// Both mapping values select Lizard.
+	pet, err = UnmarshalPet([]byte(`{"petType":"gecko","scales":3}`))
+	require.NoError(t, err)
+	assert.Equal(t, Lizard{PetType: "gecko", Scales: ptr(3)}, pet)
+
+	pet, err = UnmarshalPet([]byte(`null`))
+	require.NoError(t, err)
+	assert.Nil(t, pet)
+
+	_, err = UnmarshalPet([]byte(`{"petType":"fish"}`))
+	assert.EqualError(t, err, "unknown discriminator value: fish")
+}
+
+func TestMarshalPetStampsDiscriminator(t *testing.T) {
+	b, err := MarshalPet(Cat{Name: "Tom"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"petType":"cat","name":"Tom"}`, string(b))
+
+	b, err = MarshalPet(&Dog{Name: "Rex"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"petType":"dog","name":"Rex"}`, string(b))
+
+	// Lizard maps to two discriminator values, so its own field is kept.
+	b, err = MarshalPet(Lizard{PetType: "gecko"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"petType":"gecko"}`, string(b))
+
+	b, err = MarshalPet(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestOwnerRoundTrip(t *testing.T) {
+	const body = `{
+		"name": "Ann",
+		"pet": {"petType": "cat", "name": "Tom"},
+		"favourite": {"petType": "dog", "name": "Rex"},
+		"pets": [{"petType": "dog", "name": "Fido"}, {"petType": "lizard"}],
+		"petsByName": {"Tom": {"petType": "cat", "name": "Tom"}},
+		"companion": {"petType": "Dog", "name": "Spot"}
+	}`
+
+	var owner Owner
+	require.NoError(t, json.Unmarshal([]byte(body), &owner))
+	assert.Equal(t, "Ann", owner.Name)
+	assert.Equal(t, Cat{PetType: "cat", Name: "Tom"}, owner.Pet)
+	assert.Equal(t, Dog{PetType: "dog", Name: "Rex"}, owner.Favourite)
+	require.NotNil(t, owner.Pets)
+	assert.Equal(t, []Pet{Dog{PetType: "dog", Name: "Fido"}, Lizard{PetType: "lizard"}}, *owner.Pets)
+	require.NotNil(t, owner.PetsByName)
+	assert.Equal(t, map[string]Pet{"Tom": Cat{PetType: "cat", Name: "Tom"}}, *owner.PetsByName)
+	assert.Equal(t, Dog{PetType: "Dog", Name: "Spot"}, owner.Companion)
+
+	b, err := json.Marshal(owner)
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(b))
+}
+
+func TestOwnerOmitsAbsentUnions(t *testing.T) {
+	b, err := json.Marshal(Owner{Name: "Ann", Pet: Cat{Name: "Tom"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Ann","pet":{"petType":"cat","name":"Tom"}}`, string(b))
+
+	var owner Owner
+	require.NoError(t, json.Unmarshal(b, &owner))
+	assert.Nil(t, owner.Favourite)
+	assert.Nil(t, owner.Pets)
+}
+
+func TestOwnerReportsUnknownDiscriminator(t *testing.T) {
+	var owner Owner
+	err := json.Unmarshal([]byte(`{"name":"Ann","pet":{"petType":"fish"}}`), &owner)
+	assert.EqualError(t, err, "error reading 'pet': unknown discriminator value: fish")
+}
+
+func TestPetsSlice(t *testing.T) {
+	var pets Pets
+	require.NoError(t, json.Unmarshal([]byte(`[{"petType":"cat","name":"Tom"}]`), &pets))
+	assert.Equal(t, Pets{Cat{PetType: "cat", Name: "Tom"}}, pets)
+
+	b, err := json.Marshal(Pets{Dog{Name: "Rex"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"petType":"dog","name":"Rex"}]`, string(b))
+}
+
+type server struct{}
+
+func (server) AddOwner(_ context.Context, request AddOwnerRequestObject) (AddOwnerResponseObject, error) {
+	return AddOwner200JSONResponse(*request.Body), nil
+}
+
+func (server) ListPets(context.Context, ListPetsRequestObject) (ListPetsResponseObject, error) {
+	return ListPets200JSONResponse{Cat{Name: "Tom"}, Dog{Name: "Rex"}}, nil
+}
+
+func (server) AddPet(_ context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return AddPet200JSONResponse{Pet: request.Body.Pet}, nil
+}
+
+func (server) FirstPet(context.Context, FirstPetRequestObject) (FirstPetResponseObject, error) {
+	return FirstPet200JSONResponse{PetResponseJSONResponse{Pet: Lizard{PetType: "gecko"}}}, nil
+}
+
+func (server) AddVisit(_ context.Context, request AddVisitRequestObject) (AddVisitResponseObject, error) {
+	if len(*request.Body) == 0 {
+		return AddVisit200JSONResponse{}, nil
+	}
+	if cat, ok := (*request.Body)[0].(Cat); ok {
+		return AddVisit200JSONResponse{AddVisit200JSONResponseBody: cat}, nil
+	}
+	return AddVisit200JSONResponse{AddVisit200JSONResponseBody: Dog{Name: "Rex"}}, nil
+}
+
+func (server) AddShelter(_ context.Context, request AddShelterRequestObject) (AddShelterResponseObject, error) {
+	return AddShelter200JSONResponse{Residents: &[]Pet{request.Body.Resident}}, nil
+}
+
+func TestShelterAdditionalProperties(t *testing.T) {
+	const body = `{"resident":{"petType":"cat","name":"Tom"},"city":"Leeds"}`
+
+	var shelter Shelter
+	require.NoError(t, json.Unmarshal([]byte(body), &shelter))
+	assert.Equal(t, Cat{PetType: "cat", Name: "Tom"}, shelter.Resident)
+	assert.Equal(t, map[string]string{"city": "Leeds"}, shelter.AdditionalProperties)
+
+	b, err := json.Marshal(shelter)
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(b))
+}
+
+func TestStrictServerAndClient(t *testing.T) {
+	srv := httptest.NewServer(Handler(NewStrictHandler(server{}, nil)))
+	defer srv.Close()
+
+	client, err := NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	addPet, err := client.AddPetWithResponse(ctx, AddPetJSONRequestBody{Pet: Dog{Name: "Rex"}})
+	require.NoError(t, err)
+	require.NotNil(t, addPet.JSON200)
+	assert.Equal(t, Dog{PetType: "dog", Name: "Rex"}, *addPet.JSON200)
+
+	owner := Owner{Name: "Ann", Pet: Cat{Name: "Tom"}, Pets: &[]Pet{Dog{Name: "Rex"}}}
+	addOwner, err := client.AddOwnerWithResponse(ctx, owner)
+	require.NoError(t, err)
+	require.NotNil(t, addOwner.JSON200)
+	assert.Equal(t, Cat{PetType: "cat", Name: "Tom"}, addOwner.JSON200.Pet)
+	assert.Equal(t, []Pet{Dog{PetType: "dog", Name: "Rex"}}, *addOwner.JSON200.Pets)
+
+	listPets, err := client.ListPetsWithResponse(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, listPets.JSON200)
+	assert.Equal(t, Pets{Cat{PetType: "cat", Name: "Tom"}, Dog{PetType: "dog", Name: "Rex"}}, *listPets.JSON200)
+
+	firstPet, err := client.FirstPetWithResponse(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, firstPet.JSON200)
+	assert.Equal(t, Lizard{PetType: "gecko"}, *firstPet.JSON200)
+
+	addVisit, err := client.AddVisitWithResponse(ctx, AddVisitJSONRequestBody{Cat{Name: "Tom"}})
+	require.NoError(t, err)
+	require.NotNil(t, addVisit.JSON200)
+	assert.Equal(t, Cat{PetType: "Cat", Name: "Tom"}, *addVisit.JSON200)
+
+	addShelter, err := client.AddShelterWithResponse(ctx, Shelter{Resident: Dog{Name: "Rex"}})
+	require.NoError(t, err)
+	require.NotNil(t, addShelter.JSON200)
+	assert.Equal(t, []Pet{Dog{PetType: "dog", Name: "Rex"}}, *addShelter.JSON200.Residents)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
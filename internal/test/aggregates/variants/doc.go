@@ -0,0 +1,6 @@
+// Package aggregatesvariants exercises the union-variant-detection output
+// option: Detect and Variant methods matching the data of oneOf and anyOf
+// unions without a discriminator against their members.
+package aggregatesvariants
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,969 @@
+// Package aggregatesvariants provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package aggregatesvariants
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for LabelledStyle.
+const (
+	Bold  LabelledStyle = "bold"
+	Plain LabelledStyle = "plain"
+)
+
+// Valid indicates whether the value is a known member of the LabelledStyle enum.
+func (e LabelledStyle) Valid() bool {
+	switch e {
+	case Bold:
+		return true
+	case Plain:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Mode0.
+const (
+	Auto   Mode0 = "auto"
+	Manual Mode0 = "manual"
+)
+
+// Valid indicates whether the value is a known member of the Mode0 enum.
+func (e Mode0) Valid() bool {
+	switch e {
+	case Auto:
+		return true
+	case Manual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Tag1.
+const (
+	Green Tag1 = "green"
+	Red   Tag1 = "red"
+)
+
+// Valid indicates whether the value is a known member of the Tag1 enum.
+func (e Tag1) Valid() bool {
+	switch e {
+	case Green:
+		return true
+	case Red:
+		return true
+	default:
+		return false
+	}
+}
+
+// Amount defines model for Amount.
+type Amount struct {
+	union json.RawMessage
+}
+
+// Amount0 defines model for Amount.0.
+type Amount0 = float32
+
+// Amount1 defines model for Amount.1.
+type Amount1 = int
+
+// Circle defines model for Circle.
+type Circle struct {
+	Radius float32 `json:"radius"`
+}
+
+// Labelled defines model for Labelled.
+type Labelled struct {
+	Label string         `json:"label"`
+	Style *LabelledStyle `json:"style,omitempty"`
+}
+
+// LabelledStyle defines model for Labelled.Style.
+type LabelledStyle string
+
+// Mode defines model for Mode.
+type Mode struct {
+	union json.RawMessage
+}
+
+// Mode0 defines model for Mode.0.
+type Mode0 string
+
+// Mode1 defines model for Mode.1.
+type Mode1 = int
+
+// Mode2 defines model for Mode.2.
+type Mode2 = bool
+
+// OptionalShape defines model for OptionalShape.
+type OptionalShape struct {
+	union json.RawMessage
+}
+
+// Shape defines model for Shape.
+type Shape struct {
+	union json.RawMessage
+}
+
+// Square defines model for Square.
+type Square struct {
+	Side int `json:"side"`
+}
+
+// Tag defines model for Tag.
+type Tag struct {
+	union json.RawMessage
+}
+
+// Tag0 defines model for Tag.0.
+type Tag0 = string
+
+// Tag1 defines model for Tag.1.
+type Tag1 string
+
+// Tag2 defines model for Tag.2.
+type Tag2 = []string
+
+// AsAmount0 returns the union data inside the Amount as a Amount0
+func (t Amount) AsAmount0() (Amount0, error) {
+	var body Amount0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAmount0 overwrites any union data inside the Amount as the provided Amount0
+func (t *Amount) FromAmount0(v Amount0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAmount0 performs a merge with any union data inside the Amount, using the provided Amount0
+func (t *Amount) MergeAmount0(v Amount0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsAmount1 returns the union data inside the Amount as a Amount1
+func (t Amount) AsAmount1() (Amount1, error) {
+	var body Amount1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAmount1 overwrites any union data inside the Amount as the provided Amount1
+func (t *Amount) FromAmount1(v Amount1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAmount1 performs a merge with any union data inside the Amount, using the provided Amount1
+func (t *Amount) MergeAmount1(v Amount1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AmountVariantError is returned by Amount.Detect when the
+// union data doesn't match exactly one of its members.
+type AmountVariantError struct {
+	// Matches are the members the data matches, by As method suffix.
+	Matches []string
+}
+
+func (e *AmountVariantError) Error() string {
+	if len(e.Matches) == 0 {
+		return "data matches no member of oneOf union Amount"
+	}
+	return fmt.Sprintf("data matches %d members of oneOf union Amount: %s", len(e.Matches), strings.Join(e.Matches, ", "))
+}
+
+// Detect returns the members of the Amount which the union data
+// structurally matches, by the As method suffix of each, in union order.
+// Exactly one member must match, otherwise a *AmountVariantError is
+// returned along with the matches.
+func (t Amount) Detect() ([]string, error) {
+	kind := func(raw json.RawMessage) string {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return ""
+		}
+		switch raw[0] {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		case '"':
+			return "string"
+		case 't', 'f':
+			return "boolean"
+		case 'n':
+			return "null"
+		}
+		// An integral value, however it's written (2, 2.0 or 2e0),
+		// is an integer.
+		if n, err := json.Number(raw).Float64(); err == nil && n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	dataKind := kind(t.union)
+	var matches []string
+	if slices.Contains([]string{"number", "integer"}, dataKind) {
+		matches = append(matches, "Amount0")
+	}
+	if slices.Contains([]string{"integer"}, dataKind) {
+		matches = append(matches, "Amount1")
+	}
+	if len(matches) == 0 || len(matches) > 1 {
+		return matches, &AmountVariantError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// Variant returns the union data inside the Amount decoded as the
+// member Detect finds it matches.
+func (t Amount) Variant() (any, error) {
+	matches, err := t.Detect()
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	switch matches[0] {
+	case "Amount0":
+		return t.AsAmount0()
+	case "Amount1":
+		return t.AsAmount1()
+	}
+	return nil, nil
+}
+
+func (t Amount) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Amount) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsMode0 returns the union data inside the Mode as a Mode0
+func (t Mode) AsMode0() (Mode0, error) {
+	var body Mode0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMode0 overwrites any union data inside the Mode as the provided Mode0
+func (t *Mode) FromMode0(v Mode0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMode0 performs a merge with any union data inside the Mode, using the provided Mode0
+func (t *Mode) MergeMode0(v Mode0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsMode1 returns the union data inside the Mode as a Mode1
+func (t Mode) AsMode1() (Mode1, error) {
+	var body Mode1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMode1 overwrites any union data inside the Mode as the provided Mode1
+func (t *Mode) FromMode1(v Mode1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMode1 performs a merge with any union data inside the Mode, using the provided Mode1
+func (t *Mode) MergeMode1(v Mode1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsMode2 returns the union data inside the Mode as a Mode2
+func (t Mode) AsMode2() (Mode2, error) {
+	var body Mode2
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMode2 overwrites any union data inside the Mode as the provided Mode2
+func (t *Mode) FromMode2(v Mode2) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMode2 performs a merge with any union data inside the Mode, using the provided Mode2
+func (t *Mode) MergeMode2(v Mode2) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// ModeVariantError is returned by Mode.Detect when the
+// union data doesn't match exactly one of its members.
+type ModeVariantError struct {
+	// Matches are the members the data matches, by As method suffix.
+	Matches []string
+}
+
+func (e *ModeVariantError) Error() string {
+	if len(e.Matches) == 0 {
+		return "data matches no member of oneOf union Mode"
+	}
+	return fmt.Sprintf("data matches %d members of oneOf union Mode: %s", len(e.Matches), strings.Join(e.Matches, ", "))
+}
+
+// Detect returns the members of the Mode which the union data
+// structurally matches, by the As method suffix of each, in union order.
+// Exactly one member must match, otherwise a *ModeVariantError is
+// returned along with the matches.
+func (t Mode) Detect() ([]string, error) {
+	kind := func(raw json.RawMessage) string {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return ""
+		}
+		switch raw[0] {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		case '"':
+			return "string"
+		case 't', 'f':
+			return "boolean"
+		case 'n':
+			return "null"
+		}
+		// An integral value, however it's written (2, 2.0 or 2e0),
+		// is an integer.
+		if n, err := json.Number(raw).Float64(); err == nil && n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	dataKind := kind(t.union)
+	var matches []string
+	if slices.Contains([]string{"string"}, dataKind) &&
+		slices.Contains([]string{"\"auto\"", "\"manual\""}, string(bytes.TrimSpace(t.union))) {
+		matches = append(matches, "Mode0")
+	}
+	if slices.Contains([]string{"integer"}, dataKind) {
+		matches = append(matches, "Mode1")
+	}
+	if slices.Contains([]string{"boolean"}, dataKind) {
+		matches = append(matches, "Mode2")
+	}
+	if len(matches) == 0 || len(matches) > 1 {
+		return matches, &ModeVariantError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// Variant returns the union data inside the Mode decoded as the
+// member Detect finds it matches.
+func (t Mode) Variant() (any, error) {
+	matches, err := t.Detect()
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	switch matches[0] {
+	case "Mode0":
+		return t.AsMode0()
+	case "Mode1":
+		return t.AsMode1()
+	case "Mode2":
+		return t.AsMode2()
+	}
+	return nil, nil
+}
+
+func (t Mode) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Mode) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsCircle returns the union data inside the OptionalShape as a Circle
+func (t OptionalShape) AsCircle() (Circle, error) {
+	var body Circle
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromCircle overwrites any union data inside the OptionalShape as the provided Circle
+func (t *OptionalShape) FromCircle(v Circle) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeCircle performs a merge with any union data inside the OptionalShape, using the provided Circle
+func (t *OptionalShape) MergeCircle(v Circle) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSquare returns the union data inside the OptionalShape as a Square
+func (t OptionalShape) AsSquare() (Square, error) {
+	var body Square
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSquare overwrites any union data inside the OptionalShape as the provided Square
+func (t *OptionalShape) FromSquare(v Square) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSquare performs a merge with any union data inside the OptionalShape, using the provided Square
+func (t *OptionalShape) MergeSquare(v Square) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// OptionalShapeVariantError is returned by OptionalShape.Detect when the
+// union data doesn't match exactly one of its members.
+type OptionalShapeVariantError struct {
+	// Matches are the members the data matches, by As method suffix.
+	Matches []string
+}
+
+func (e *OptionalShapeVariantError) Error() string {
+	if len(e.Matches) == 0 {
+		return "data matches no member of oneOf union OptionalShape"
+	}
+	return fmt.Sprintf("data matches %d members of oneOf union OptionalShape: %s", len(e.Matches), strings.Join(e.Matches, ", "))
+}
+
+// Detect returns the members of the OptionalShape which the union data
+// structurally matches, by the As method suffix of each, in union order.
+// Exactly one member must match, otherwise a *OptionalShapeVariantError is
+// returned along with the matches. JSON null matches no member, without error.
+func (t OptionalShape) Detect() ([]string, error) {
+	kind := func(raw json.RawMessage) string {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return ""
+		}
+		switch raw[0] {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		case '"':
+			return "string"
+		case 't', 'f':
+			return "boolean"
+		case 'n':
+			return "null"
+		}
+		// An integral value, however it's written (2, 2.0 or 2e0),
+		// is an integer.
+		if n, err := json.Number(raw).Float64(); err == nil && n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	dataKind := kind(t.union)
+	if dataKind == "null" {
+		return nil, nil
+	}
+	var object map[string]json.RawMessage
+	if dataKind == "object" {
+		if err := json.Unmarshal(t.union, &object); err != nil {
+			return nil, err
+		}
+	}
+	var matches []string
+	if slices.Contains([]string{"object"}, dataKind) &&
+		object["radius"] != nil &&
+		(object["radius"] == nil || slices.Contains([]string{"number", "integer"}, kind(object["radius"]))) &&
+		func() bool {
+			for name := range object {
+				if !slices.Contains([]string{"radius"}, name) {
+					return false
+				}
+			}
+			return true
+		}() {
+		matches = append(matches, "Circle")
+	}
+	if slices.Contains([]string{"object"}, dataKind) &&
+		object["side"] != nil &&
+		(object["side"] == nil || slices.Contains([]string{"integer"}, kind(object["side"]))) &&
+		func() bool {
+			for name := range object {
+				if !slices.Contains([]string{"side"}, name) {
+					return false
+				}
+			}
+			return true
+		}() {
+		matches = append(matches, "Square")
+	}
+	if len(matches) == 0 || len(matches) > 1 {
+		return matches, &OptionalShapeVariantError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// Variant returns the union data inside the OptionalShape decoded as the
+// member Detect finds it matches. JSON null is returned as nil.
+func (t OptionalShape) Variant() (any, error) {
+	matches, err := t.Detect()
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	switch matches[0] {
+	case "Circle":
+		return t.AsCircle()
+	case "Square":
+		return t.AsSquare()
+	}
+	return nil, nil
+}
+
+func (t OptionalShape) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *OptionalShape) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsCircle returns the union data inside the Shape as a Circle
+func (t Shape) AsCircle() (Circle, error) {
+	var body Circle
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromCircle overwrites any union data inside the Shape as the provided Circle
+func (t *Shape) FromCircle(v Circle) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeCircle performs a merge with any union data inside the Shape, using the provided Circle
+func (t *Shape) MergeCircle(v Circle) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSquare returns the union data inside the Shape as a Square
+func (t Shape) AsSquare() (Square, error) {
+	var body Square
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSquare overwrites any union data inside the Shape as the provided Square
+func (t *Shape) FromSquare(v Square) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSquare performs a merge with any union data inside the Shape, using the provided Square
+func (t *Shape) MergeSquare(v Square) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsLabelled returns the union data inside the Shape as a Labelled
+func (t Shape) AsLabelled() (Labelled, error) {
+	var body Labelled
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromLabelled overwrites any union data inside the Shape as the provided Labelled
+func (t *Shape) FromLabelled(v Labelled) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeLabelled performs a merge with any union data inside the Shape, using the provided Labelled
+func (t *Shape) MergeLabelled(v Labelled) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// ShapeVariantError is returned by Shape.Detect when the
+// union data doesn't match exactly one of its members.
+type ShapeVariantError struct {
+	// Matches are the members the data matches, by As method suffix.
+	Matches []string
+}
+
+func (e *ShapeVariantError) Error() string {
+	if len(e.Matches) == 0 {
+		return "data matches no member of oneOf union Shape"
+	}
+	return fmt.Sprintf("data matches %d members of oneOf union Shape: %s", len(e.Matches), strings.Join(e.Matches, ", "))
+}
+
+// Detect returns the members of the Shape which the union data
+// structurally matches, by the As method suffix of each, in union order.
+// Exactly one member must match, otherwise a *ShapeVariantError is
+// returned along with the matches.
+func (t Shape) Detect() ([]string, error) {
+	kind := func(raw json.RawMessage) string {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return ""
+		}
+		switch raw[0] {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		case '"':
+			return "string"
+		case 't', 'f':
+			return "boolean"
+		case 'n':
+			return "null"
+		}
+		// An integral value, however it's written (2, 2.0 or 2e0),
+		// is an integer.
+		if n, err := json.Number(raw).Float64(); err == nil && n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	dataKind := kind(t.union)
+	var object map[string]json.RawMessage
+	if dataKind == "object" {
+		if err := json.Unmarshal(t.union, &object); err != nil {
+			return nil, err
+		}
+	}
+	var matches []string
+	if slices.Contains([]string{"object"}, dataKind) &&
+		object["radius"] != nil &&
+		(object["radius"] == nil || slices.Contains([]string{"number", "integer"}, kind(object["radius"]))) &&
+		func() bool {
+			for name := range object {
+				if !slices.Contains([]string{"radius"}, name) {
+					return false
+				}
+			}
+			return true
+		}() {
+		matches = append(matches, "Circle")
+	}
+	if slices.Contains([]string{"object"}, dataKind) &&
+		object["side"] != nil &&
+		(object["side"] == nil || slices.Contains([]string{"integer"}, kind(object["side"]))) &&
+		func() bool {
+			for name := range object {
+				if !slices.Contains([]string{"side"}, name) {
+					return false
+				}
+			}
+			return true
+		}() {
+		matches = append(matches, "Square")
+	}
+	if slices.Contains([]string{"object"}, dataKind) &&
+		object["label"] != nil &&
+		(object["label"] == nil || slices.Contains([]string{"string"}, kind(object["label"]))) &&
+		(object["style"] == nil || slices.Contains([]string{"string"}, kind(object["style"]))) &&
+		(object["style"] == nil || slices.Contains([]string{"\"bold\"", "\"plain\""}, string(object["style"]))) {
+		matches = append(matches, "Labelled")
+	}
+	if len(matches) == 0 || len(matches) > 1 {
+		return matches, &ShapeVariantError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// Variant returns the union data inside the Shape decoded as the
+// member Detect finds it matches.
+func (t Shape) Variant() (any, error) {
+	matches, err := t.Detect()
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	switch matches[0] {
+	case "Circle":
+		return t.AsCircle()
+	case "Square":
+		return t.AsSquare()
+	case "Labelled":
+		return t.AsLabelled()
+	}
+	return nil, nil
+}
+
+func (t Shape) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Shape) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsTag0 returns the union data inside the Tag as a Tag0
+func (t Tag) AsTag0() (Tag0, error) {
+	var body Tag0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTag0 overwrites any union data inside the Tag as the provided Tag0
+func (t *Tag) FromTag0(v Tag0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTag0 performs a merge with any union data inside the Tag, using the provided Tag0
+func (t *Tag) MergeTag0(v Tag0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTag1 returns the union data inside the Tag as a Tag1
+func (t Tag) AsTag1() (Tag1, error) {
+	var body Tag1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTag1 overwrites any union data inside the Tag as the provided Tag1
+func (t *Tag) FromTag1(v Tag1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTag1 performs a merge with any union data inside the Tag, using the provided Tag1
+func (t *Tag) MergeTag1(v Tag1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTag2 returns the union data inside the Tag as a Tag2
+func (t Tag) AsTag2() (Tag2, error) {
+	var body Tag2
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTag2 overwrites any union data inside the Tag as the provided Tag2
+func (t *Tag) FromTag2(v Tag2) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTag2 performs a merge with any union data inside the Tag, using the provided Tag2
+func (t *Tag) MergeTag2(v Tag2) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// TagVariantError is returned by Tag.Detect when the
+// union data matches none of its members.
+type TagVariantError struct {
+	// Matches are the members the data matches, by As method suffix.
+	Matches []string
+}
+
+func (e *TagVariantError) Error() string {
+	if len(e.Matches) == 0 {
+		return "data matches no member of anyOf union Tag"
+	}
+	return fmt.Sprintf("data matches %d members of anyOf union Tag: %s", len(e.Matches), strings.Join(e.Matches, ", "))
+}
+
+// Detect returns the members of the Tag which the union data
+// structurally matches, by the As method suffix of each, in union order.
+// At least one member must match, otherwise a *TagVariantError is
+// returned along with the matches. JSON null matches no member, without error.
+func (t Tag) Detect() ([]string, error) {
+	kind := func(raw json.RawMessage) string {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return ""
+		}
+		switch raw[0] {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		case '"':
+			return "string"
+		case 't', 'f':
+			return "boolean"
+		case 'n':
+			return "null"
+		}
+		// An integral value, however it's written (2, 2.0 or 2e0),
+		// is an integer.
+		if n, err := json.Number(raw).Float64(); err == nil && n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	dataKind := kind(t.union)
+	if dataKind == "null" {
+		return nil, nil
+	}
+	var matches []string
+	if slices.Contains([]string{"string"}, dataKind) {
+		matches = append(matches, "Tag0")
+	}
+	if slices.Contains([]string{"string"}, dataKind) &&
+		slices.Contains([]string{"\"red\"", "\"green\""}, string(bytes.TrimSpace(t.union))) {
+		matches = append(matches, "Tag1")
+	}
+	if slices.Contains([]string{"array"}, dataKind) {
+		matches = append(matches, "Tag2")
+	}
+	if len(matches) == 0 {
+		return matches, &TagVariantError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// Variant returns the union data inside the Tag decoded as the
+// first member Detect finds it matches. JSON null is returned as nil.
+func (t Tag) Variant() (any, error) {
+	matches, err := t.Detect()
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	switch matches[0] {
+	case "Tag0":
+		return t.AsTag0()
+	case "Tag1":
+		return t.AsTag1()
+	case "Tag2":
+		return t.AsTag2()
+	}
+	return nil, nil
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Tag) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
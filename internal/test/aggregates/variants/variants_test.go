@@ -0,0 +1,125 @@
+package aggregatesvariants
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unmarshal[T any](t *testing.T, data string) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+	return v
+}
+
+func TestDetectMatchesObjectMembers(t *testing.T) {
+	matches, err := unmarshal[Shape](t, `{"radius":1.5}`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Circle"}, matches)
+
+	// An integral radius is still a number.
+	matches, err = unmarshal[Shape](t, `{"radius":2}`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Circle"}, matches)
+
+	// An integral side is an integer however it's written.
+	for _, side := range []string{"1.0", "1e3"} {
+		matches, err = unmarshal[Shape](t, `{"side":`+side+`}`).Detect()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Square"}, matches, side)
+	}
+
+	// A fractional side isn't an integer.
+	_, err = unmarshal[Shape](t, `{"side":2.5}`).Detect()
+	var variantErr *ShapeVariantError
+	require.ErrorAs(t, err, &variantErr)
+	assert.Empty(t, variantErr.Matches)
+
+	matches, err = unmarshal[Shape](t, `{"label":"x","style":"bold"}`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Labelled"}, matches)
+
+	_, err = unmarshal[Shape](t, `{"label":"x","style":"italic"}`).Detect()
+	assert.EqualError(t, err, "data matches no member of oneOf union Shape")
+}
+
+func TestDetectClosedObjectRejectsUnknownProperties(t *testing.T) {
+	// additionalProperties: false rules out Circle, Labelled is open.
+	matches, err := unmarshal[Shape](t, `{"radius":1,"label":"x"}`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Labelled"}, matches)
+}
+
+func TestDetectOneOfRejectsAmbiguity(t *testing.T) {
+	matches, err := unmarshal[Amount](t, `2.5`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Amount0"}, matches)
+
+	// An integer is also a number.
+	matches, err = unmarshal[Amount](t, `2`).Detect()
+	var variantErr *AmountVariantError
+	require.ErrorAs(t, err, &variantErr)
+	assert.Equal(t, []string{"Amount0", "Amount1"}, variantErr.Matches)
+	assert.Equal(t, variantErr.Matches, matches)
+	assert.EqualError(t, err, "data matches 2 members of oneOf union Amount: Amount0, Amount1")
+
+	for _, amount := range []string{"1.0", "1e3"} {
+		matches, err = unmarshal[Amount](t, amount).Detect()
+		require.ErrorAs(t, err, &variantErr)
+		assert.Equal(t, []string{"Amount0", "Amount1"}, matches, amount)
+	}
+
+	_, err = unmarshal[Amount](t, `2`).Variant()
+	assert.ErrorAs(t, err, &variantErr)
+
+	matches, err = unmarshal[Mode](t, `"auto"`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Mode0"}, matches)
+
+	_, err = unmarshal[Mode](t, `"other"`).Detect()
+	assert.EqualError(t, err, "data matches no member of oneOf union Mode")
+}
+
+func TestDetectAnyOfAllowsSeveralMatches(t *testing.T) {
+	matches, err := unmarshal[Tag](t, `"red"`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tag0", "Tag1"}, matches)
+
+	matches, err = unmarshal[Tag](t, `"blue"`).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tag0"}, matches)
+
+	matches, err = unmarshal[Tag](t, `null`).Detect()
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	matches, err = unmarshal[Tag](t, `3`).Detect()
+	var variantErr *TagVariantError
+	require.ErrorAs(t, err, &variantErr)
+	assert.Empty(t, matches)
+	assert.EqualError(t, err, "data matches no member of anyOf union Tag")
+}
+
+func TestVariantDecodesMatchingMember(t *testing.T) {
+	v, err := unmarshal[Shape](t, `{"side":3}`).Variant()
+	require.NoError(t, err)
+	assert.Equal(t, Square{Side: 3}, v)
+
+	v, err = unmarshal[Mode](t, `true`).Variant()
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	v, err = unmarshal[Tag](t, `["a","b"]`).Variant()
+	require.NoError(t, err)
+	assert.Equal(t, Tag2{"a", "b"}, v)
+
+	v, err = unmarshal[OptionalShape](t, `null`).Variant()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = unmarshal[OptionalShape](t, `{"radius":1,"side":1}`).Variant()
+	assert.EqualError(t, err, "data matches no member of oneOf union OptionalShape")
+}
@@ -0,0 +1,6 @@
+// Package mutualtls verifies the client option of a mutualTLS security
+// scheme, which presents the client certificate, and satisfies the scheme for
+// the operations requiring it, alone or with another scheme.
+package mutualtls
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
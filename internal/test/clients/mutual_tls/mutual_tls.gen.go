@@ -0,0 +1,475 @@
+// Package mutualtls provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package mutualtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// securityCredentials are the credentials of WithSecurity, by scheme.
+	securityCredentials map[string]SecurityCredentials
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// ListOwners performs a GET /owners (the `ListOwners` operationId) request.
+	ListOwners(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets performs a GET /pets (the `ListPets` operationId) request.
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// ListOwners performs a GET /owners (the `ListOwners` operationId) request.
+func (c *Client) ListOwners(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListOwnersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applySecurity(ctx, req, "ListOwners"); err != nil {
+		return nil, err
+	}
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListPets performs a GET /pets (the `ListPets` operationId) request.
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applySecurity(ctx, req, "ListPets"); err != nil {
+		return nil, err
+	}
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListOwnersRequest constructs an http.Request for the ListOwners method
+func NewListOwnersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/owners"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest constructs an http.Request for the ListPets method
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SecurityCredentials attach the credentials of a security scheme of the spec
+// to requests. WithSecurity attaches them to the requests of the operations
+// requiring the scheme.
+type SecurityCredentials interface {
+	// SecurityScheme is the name of the security scheme.
+	SecurityScheme() string
+	// Intercept attaches the credentials to req.
+	Intercept(ctx context.Context, req *http.Request) error
+}
+
+// BearerAuthCredentials attaches the credentials of the bearerAuth security scheme, an http bearer scheme,
+// to requests, such as with the Intercept of a securityprovider.SecurityProviderBearerToken.
+type BearerAuthCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (BearerAuthCredentials) SecurityScheme() string {
+	return "bearerAuth"
+}
+
+// Intercept implements SecurityCredentials.
+func (c BearerAuthCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// MtlsCredentials satisfies the mtls security scheme, a mutualTLS scheme, whose
+// client certificate is configured with WithMtlsCertificate, rather than attached
+// to requests.
+type MtlsCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (MtlsCredentials) SecurityScheme() string {
+	return "mtls"
+}
+
+// Intercept implements SecurityCredentials.
+func (c MtlsCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// WithMtlsCertificate configures the HTTP client of the Client to present
+// the client certificate of the mtls security scheme, and to verify the
+// server's certificate with rootCAs, or the system's roots when it's nil, and
+// satisfies the scheme for WithSecurity. It replaces the HttpRequestDoer of
+// WithHTTPClient.
+func WithMtlsCertificate(certificate tls.Certificate, rootCAs *x509.CertPool) ClientOption {
+	return func(c *Client) error {
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = defaultTransport.Clone()
+		}
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			RootCAs:      rootCAs,
+			MinVersion:   tls.VersionTLS12,
+		}
+		c.Client = &http.Client{Transport: transport}
+		return WithSecurity(MtlsCredentials(func(context.Context, *http.Request) error { return nil }))(c)
+	}
+}
+
+// WithSecurity attaches the credentials to the requests of the operations
+// whose security requirement includes their scheme. Of the alternatives of an
+// operation's requirement, the first whose schemes all have credentials is
+// satisfied with them. No credentials are attached to the requests of
+// operations without security, such as with `security: []`, or whose
+// requirement no credentials satisfy.
+func WithSecurity(credentials ...SecurityCredentials) ClientOption {
+	return func(c *Client) error {
+		if c.securityCredentials == nil {
+			c.securityCredentials = make(map[string]SecurityCredentials)
+		}
+		for _, cred := range credentials {
+			c.securityCredentials[cred.SecurityScheme()] = cred
+		}
+		return nil
+	}
+}
+
+// operationSecurity are the security requirements of the operations, by
+// operation ID: the alternatives, each the schemes it requires together.
+var operationSecurity = map[string][][]string{
+	"ListOwners": {{"bearerAuth", "mtls"}},
+	"ListPets":   {{"mtls"}},
+}
+
+// applySecurity attaches the credentials satisfying the security requirement
+// of the operation to req.
+func (c *Client) applySecurity(ctx context.Context, req *http.Request, operationID string) error {
+	for _, schemes := range operationSecurity[operationID] {
+		// An anonymous alternative is satisfied without credentials.
+		if len(schemes) == 0 || !c.hasSecurityCredentials(schemes) {
+			continue
+		}
+		for _, scheme := range schemes {
+			if err := c.securityCredentials[scheme].Intercept(ctx, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// hasSecurityCredentials returns whether there are credentials for each of
+// the schemes.
+func (c *Client) hasSecurityCredentials(schemes []string) bool {
+	for _, scheme := range schemes {
+		if _, ok := c.securityCredentials[scheme]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// ListOwnersWithResponse performs a GET /owners (the `ListOwners` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListOwnersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListOwnersResponse, error)
+
+	// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+}
+
+type ListOwnersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r ListOwnersResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListOwnersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListOwnersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListOwnersResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r ListPetsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListPetsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// ListOwnersWithResponse performs a GET /owners (the `ListOwners` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListOwnersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListOwnersResponse, error) {
+	rsp, err := c.ListOwners(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListOwnersResponse(rsp)
+}
+
+// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// ParseListOwnersResponse parses an HTTP response from a ListOwnersWithResponse call
+func ParseListOwnersResponse(rsp *http.Response) (*ListOwnersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListOwnersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,86 @@
+package mutualtls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientCertificate returns a self-signed client certificate, and the
+// pool verifying it.
+func newClientCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pets-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestWithMtlsCertificate(t *testing.T) {
+	clientCert, clientCAs := newClientCertificate(t)
+	server := httptest.NewUnstartedServer(securityprovider.NewMutualTLSMiddleware(securityprovider.MutualTLSOptions{})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peer, _ := securityprovider.MutualTLSPeerFromContext(r.Context())
+			_, _ = io.WriteString(w, peer.Subject().CommonName+" "+r.Header.Get("Authorization"))
+		})))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	bearer, err := securityprovider.NewSecurityProviderBearerToken("token")
+	require.NoError(t, err)
+	client, err := NewClient(server.URL, WithMtlsCertificate(clientCert, rootCAs), WithSecurity(BearerAuthCredentials(bearer.Intercept)))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		do   func(context.Context, ...RequestEditorFn) (*http.Response, error)
+		want string
+	}{
+		{client.ListPets, "pets-client "},
+		// The combined requirement is satisfied with the certificate.
+		{client.ListOwners, "pets-client Bearer token"},
+	} {
+		resp, err := tc.do(context.Background())
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, tc.want, string(body))
+	}
+
+	// Without the certificate, the server rejects the handshake.
+	client, err = NewClient(server.URL, WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	_, err = client.ListPets(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,7 @@
+// Package ratelimit verifies the rate limiting options of the client, with
+// the client-rate-limit output option: the token buckets of the client and
+// of its operations, its requests in flight, and its backoff from the rate
+// limit headers of the server.
+package ratelimit
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,656 @@
+// Package ratelimit provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// rateLimit is the state of the rate limiting options, if any.
+	rateLimit *rateLimit
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// ListPets performs a GET /pets (the `ListPets` operationId) request.
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPet performs a GET /pets/{id} (the `GetPet` operationId) request.
+	GetPet(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// ListPets performs a GET /pets (the `ListPets` operationId) request.
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "ListPets")
+}
+
+// GetPet performs a GET /pets/{id} (the `GetPet` operationId) request.
+func (c *Client) GetPet(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetPet")
+}
+
+// NewListPetsRequest constructs an http.Request for the ListPets method
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPetRequest constructs an http.Request for the GetPet method
+func NewGetPetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets/" + pathParam0
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithRateLimit limits the requests of the client to rate a second, with
+// bursts of up to burst requests, as a token bucket. Requests over the limit
+// wait for it, until their context is done.
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return func(c *Client) error {
+		bucket, err := newRateLimitBucket(rate, burst)
+		if err != nil {
+			return err
+		}
+		c.rateLimitState().bucket = bucket
+		return nil
+	}
+}
+
+// WithOperationRateLimit limits the requests of an operation as WithRateLimit
+// does, in addition to its limit. The operation is named by its operation ID,
+// such as "ListPets".
+func WithOperationRateLimit(operationID string, rate float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if _, ok := rateLimitOperations[operationID]; !ok {
+			return fmt.Errorf("rate limit of unknown operation %q", operationID)
+		}
+		bucket, err := newRateLimitBucket(rate, burst)
+		if err != nil {
+			return err
+		}
+		state := c.rateLimitState()
+		if state.operations == nil {
+			state.operations = make(map[string]*rateLimitBucket)
+		}
+		state.operations[operationID] = bucket
+		return nil
+	}
+}
+
+// WithMaxInFlight limits the requests the client has in flight at once to n,
+// from when they're sent until their response body is closed. Requests over
+// the limit wait for one of them, until their context is done.
+func WithMaxInFlight(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("max in flight %d is less than 1", n)
+		}
+		c.rateLimitState().inFlight = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// WithRateLimitBackoff makes the client wait, before its next requests, until
+// the rate limit of the server is reset, when a response says that it's
+// exhausted: with a RateLimit or RateLimit-Remaining header, or their
+// X-RateLimit- equivalents, of 0 remaining requests, and when it's reset, or
+// with the Retry-After header of a 429 Too Many Requests or 503 Service
+// Unavailable response. It waits for at most maxWait after the response,
+// however far away the reset is.
+func WithRateLimitBackoff(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxWait <= 0 {
+			return fmt.Errorf("rate limit backoff %v isn't positive", maxWait)
+		}
+		c.rateLimitState().maxBackoff = maxWait
+		return nil
+	}
+}
+
+// rateLimitOperations are the operation IDs of the operations of the client.
+var rateLimitOperations = map[string]struct{}{
+	"ListPets": {},
+	"GetPet":   {},
+}
+
+// rateLimit is the state of the rate limiting options of the client.
+type rateLimit struct {
+	bucket     *rateLimitBucket
+	operations map[string]*rateLimitBucket
+	inFlight   chan struct{}
+	// maxBackoff is the maxWait of WithRateLimitBackoff, when it's set.
+	maxBackoff time.Duration
+
+	mu sync.Mutex
+	// pausedUntil is when the rate limit of the server is reset, when a
+	// response says that it's exhausted, up to maxBackoff after it.
+	pausedUntil time.Time
+}
+
+func (c *Client) rateLimitState() *rateLimit {
+	if c.rateLimit == nil {
+		c.rateLimit = &rateLimit{}
+	}
+	return c.rateLimit
+}
+
+// do does req, the request of the operation, within the rate limits.
+func (c *Client) do(req *http.Request, operationID string) (*http.Response, error) {
+	limit := c.rateLimit
+	if limit == nil {
+		return c.Client.Do(req)
+	}
+	ctx := req.Context()
+	if limit.maxBackoff > 0 {
+		limit.mu.Lock()
+		pausedUntil := limit.pausedUntil
+		limit.mu.Unlock()
+		if err := sleepContext(ctx, time.Until(pausedUntil)); err != nil {
+			return nil, err
+		}
+	}
+	// The tokens taken are given back when the request isn't sent, after
+	// all.
+	var taken []*rateLimitBucket
+	refund := func() {
+		for _, bucket := range taken {
+			bucket.refund()
+		}
+	}
+	for _, bucket := range []*rateLimitBucket{limit.bucket, limit.operations[operationID]} {
+		if bucket == nil {
+			continue
+		}
+		if err := bucket.wait(ctx); err != nil {
+			refund()
+			return nil, err
+		}
+		taken = append(taken, bucket)
+	}
+	release := func() {}
+	if limit.inFlight != nil {
+		select {
+		case limit.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			refund()
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-limit.inFlight }) }
+	}
+
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if limit.maxBackoff > 0 {
+		now := time.Now()
+		if reset, ok := rateLimitReset(rsp, now); ok {
+			if latest := now.Add(limit.maxBackoff); reset.After(latest) {
+				reset = latest
+			}
+			limit.mu.Lock()
+			if reset.After(limit.pausedUntil) {
+				limit.pausedUntil = reset
+			}
+			limit.mu.Unlock()
+		}
+	}
+	if limit.inFlight != nil {
+		rsp.Body = &rateLimitBody{ReadCloser: rsp.Body, release: release}
+	}
+	return rsp, nil
+}
+
+// rateLimitBucket is a token bucket.
+type rateLimitBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimitBucket(rate float64, burst int) (*rateLimitBucket, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate limit %v isn't positive", rate)
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("rate limit burst %d is less than 1", burst)
+	}
+	return &rateLimitBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}, nil
+}
+
+// wait takes a token from the bucket, waiting for one, until ctx is done.
+func (b *rateLimitBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	// The token is taken now, so the waiting requests are let through in
+	// order; one which is canceled gives it back.
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if err := sleepContext(ctx, delay); err != nil {
+		b.refund()
+		return err
+	}
+	return nil
+}
+
+// refund gives back a token taken by wait, for a request which isn't sent.
+func (b *rateLimitBucket) refund() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// rateLimitBody releases the in-flight slot of its request when it's closed.
+type rateLimitBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *rateLimitBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// rateLimitReset returns when the rate limit of the server is reset, when
+// the response says that it's exhausted.
+func rateLimitReset(rsp *http.Response, now time.Time) (time.Time, bool) {
+	if rsp.StatusCode == http.StatusTooManyRequests || rsp.StatusCode == http.StatusServiceUnavailable {
+		if retryAfter := rsp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+			if date, err := http.ParseTime(retryAfter); err == nil {
+				return date, true
+			}
+		}
+	}
+	// The RateLimit header of the IETF draft has the remaining requests as
+	// its r parameter, and the seconds until they're reset as its t one.
+	if header := rsp.Header.Get("RateLimit"); header != "" {
+		var remaining, reset string
+		for _, param := range strings.Split(header, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			switch name {
+			case "r":
+				remaining = value
+			case "t":
+				reset = value
+			}
+		}
+		if at, ok := rateLimitExhausted(remaining, reset, now); ok {
+			return at, true
+		}
+	}
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		if at, ok := rateLimitExhausted(rsp.Header.Get(prefix+"Remaining"), rsp.Header.Get(prefix+"Reset"), now); ok {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// rateLimitExhausted returns when the rate limit is reset, when there are no
+// remaining requests. reset is the seconds until then, or, as some servers'
+// X-RateLimit-Reset is, the Unix time of then.
+func rateLimitExhausted(remaining, reset string, now time.Time) (time.Time, bool) {
+	if n, err := strconv.Atoi(strings.TrimSpace(remaining)); err != nil || n > 0 {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(reset), 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+	if seconds > 1e9 {
+		return time.Unix(seconds, 0), true
+	}
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
+
+// sleepContext sleeps for d, until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// GetPetWithResponse performs a GET /pets/{id} (the `GetPet` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	GetPetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetPetResponse, error)
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r ListPetsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListPetsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type GetPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r GetPetResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r GetPetResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// GetPetWithResponse performs a GET /pets/{id} (the `GetPet` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	rsp, err := c.GetPet(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPetResponse(rsp)
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParseGetPetResponse(rsp *http.Response) (*GetPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,215 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRateLimit(20, 2))
+	require.NoError(t, err)
+
+	// The burst goes through at once, and the next request waits for a token.
+	start := time.Now()
+	for range 3 {
+		resp, err := client.ListPets(context.Background())
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// The requests waiting for a token of their operation are canceled with
+	// their context.
+	client, err = NewClient(server.URL, WithOperationRateLimit("GetPet", 10, 1))
+	require.NoError(t, err)
+	resp, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	resp, err = client.GetPet(ctx, "1")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	_, err = client.GetPet(ctx, "1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A canceled request gives back the token it took of the client's limit,
+	// as well as its operation's.
+	client, err = NewClient(server.URL, WithRateLimit(1, 2), WithOperationRateLimit("GetPet", 1, 1))
+	require.NoError(t, err)
+	resp, err = client.GetPet(context.Background(), "1")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.GetPet(ctx, "1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	start = time.Now()
+	resp, err = client.ListPets(context.Background())
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	_, err = NewClient(server.URL, WithOperationRateLimit("DeletePet", 1, 1))
+	assert.ErrorContains(t, err, `unknown operation "DeletePet"`)
+	_, err = NewClient(server.URL, WithRateLimit(0, 1))
+	assert.Error(t, err)
+}
+
+func TestWithMaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithMaxInFlight(2))
+	require.NoError(t, err)
+
+	errs := make(chan error)
+	for range 6 {
+		go func() {
+			resp, err := client.ListPets(context.Background())
+			if err == nil {
+				err = resp.Body.Close()
+			}
+			errs <- err
+		}()
+	}
+	for range 6 {
+		require.NoError(t, <-errs)
+	}
+	assert.Equal(t, int32(2), maxInFlight.Load())
+
+	// The slot of a response is held until its body is closed.
+	resp, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	resp2, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.ListPets(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_ = resp.Body.Close()
+	_ = resp2.Body.Close()
+}
+
+func TestWithRateLimitBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRateLimitBackoff(time.Minute))
+	require.NoError(t, err)
+
+	resp, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	start := time.Now()
+	resp, err = client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	_, err = NewClient(server.URL, WithRateLimitBackoff(0))
+	assert.Error(t, err)
+}
+
+func TestWithRateLimitBackoffMax(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// A server saying to wait for a day is only waited for up to
+			// maxWait.
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRateLimitBackoff(100*time.Millisecond))
+	require.NoError(t, err)
+
+	resp, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	start := time.Now()
+	resp, err = client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, tc := range map[string]struct {
+		status int
+		header http.Header
+		want   time.Time
+	}{
+		"retry after seconds": {
+			status: http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": {"30"}},
+			want:   now.Add(30 * time.Second),
+		},
+		"retry after date": {
+			status: http.StatusServiceUnavailable,
+			header: http.Header{"Retry-After": {now.Add(time.Minute).UTC().Format(http.TimeFormat)}},
+			want:   now.Add(time.Minute),
+		},
+		"ratelimit": {
+			status: http.StatusOK,
+			header: http.Header{"Ratelimit": {`"default";r=0;t=5`}},
+			want:   now.Add(5 * time.Second),
+		},
+		"ratelimit remaining": {
+			status: http.StatusOK,
+			header: http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"10"}},
+			want:   now.Add(10 * time.Second),
+		},
+		"x-ratelimit unix time": {
+			status: http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Unix()+20, 10)}},
+			want:   now.Add(20 * time.Second),
+		},
+		"remaining requests": {
+			status: http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {"10"}},
+		},
+		"retry after of a success": {
+			status: http.StatusOK,
+			header: http.Header{"Retry-After": {"30"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reset, ok := rateLimitReset(&http.Response{StatusCode: tc.status, Header: tc.header}, now)
+			assert.Equal(t, !tc.want.IsZero(), ok)
+			assert.True(t, tc.want.Equal(reset), "%v != %v", tc.want, reset)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package security verifies that the client's WithSecurity option attaches
+// the credentials of the spec's securitySchemes only to the operations whose
+// security requirement includes them: the top-level requirement, an override
+// with alternative (OR) and combined (AND) schemes, and `security: []`.
+package security
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
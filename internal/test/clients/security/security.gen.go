@@ -0,0 +1,588 @@
+// Package security provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package security
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// securityCredentials are the credentials of WithSecurity, by scheme.
+	securityCredentials map[string]SecurityCredentials
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// Admin performs a GET /admin (the `Admin` operationId) request.
+	Admin(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Health performs a GET /health (the `Health` operationId) request.
+	Health(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets performs a GET /pets (the `ListPets` operationId) request.
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// Admin performs a GET /admin (the `Admin` operationId) request.
+func (c *Client) Admin(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applySecurity(ctx, req, "Admin"); err != nil {
+		return nil, err
+	}
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// Health performs a GET /health (the `Health` operationId) request.
+func (c *Client) Health(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListPets performs a GET /pets (the `ListPets` operationId) request.
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applySecurity(ctx, req, "ListPets"); err != nil {
+		return nil, err
+	}
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewAdminRequest constructs an http.Request for the Admin method
+func NewAdminRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/admin"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHealthRequest constructs an http.Request for the Health method
+func NewHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/health"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest constructs an http.Request for the ListPets method
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/pets"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SecurityCredentials attach the credentials of a security scheme of the spec
+// to requests. WithSecurity attaches them to the requests of the operations
+// requiring the scheme.
+type SecurityCredentials interface {
+	// SecurityScheme is the name of the security scheme.
+	SecurityScheme() string
+	// Intercept attaches the credentials to req.
+	Intercept(ctx context.Context, req *http.Request) error
+}
+
+// ApiKeyCredentials attaches the credentials of the apiKey security scheme, an apiKey scheme, in the header X-API-Key,
+// to requests, such as with the Intercept of a securityprovider.SecurityProviderApiKey.
+type ApiKeyCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (ApiKeyCredentials) SecurityScheme() string {
+	return "apiKey"
+}
+
+// Intercept implements SecurityCredentials.
+func (c ApiKeyCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// AppIdCredentials attaches the credentials of the appId security scheme, an apiKey scheme, in the query app_id,
+// to requests, such as with the Intercept of a securityprovider.SecurityProviderApiKey.
+type AppIdCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (AppIdCredentials) SecurityScheme() string {
+	return "appId"
+}
+
+// Intercept implements SecurityCredentials.
+func (c AppIdCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// BearerAuthCredentials attaches the credentials of the bearerAuth security scheme, an http bearer scheme,
+// to requests, such as with the Intercept of a securityprovider.SecurityProviderBearerToken.
+type BearerAuthCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (BearerAuthCredentials) SecurityScheme() string {
+	return "bearerAuth"
+}
+
+// Intercept implements SecurityCredentials.
+func (c BearerAuthCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// OauthCredentials attaches the credentials of the oauth security scheme, an oauth2 scheme,
+// to requests, such as with the Intercept of a securityprovider.SecurityProviderOAuth2.
+type OauthCredentials RequestEditorFn
+
+// SecurityScheme implements SecurityCredentials.
+func (OauthCredentials) SecurityScheme() string {
+	return "oauth"
+}
+
+// Intercept implements SecurityCredentials.
+func (c OauthCredentials) Intercept(ctx context.Context, req *http.Request) error {
+	return c(ctx, req)
+}
+
+// WithSecurity attaches the credentials to the requests of the operations
+// whose security requirement includes their scheme. Of the alternatives of an
+// operation's requirement, the first whose schemes all have credentials is
+// satisfied with them. No credentials are attached to the requests of
+// operations without security, such as with `security: []`, or whose
+// requirement no credentials satisfy.
+func WithSecurity(credentials ...SecurityCredentials) ClientOption {
+	return func(c *Client) error {
+		if c.securityCredentials == nil {
+			c.securityCredentials = make(map[string]SecurityCredentials)
+		}
+		for _, cred := range credentials {
+			c.securityCredentials[cred.SecurityScheme()] = cred
+		}
+		return nil
+	}
+}
+
+// operationSecurity are the security requirements of the operations, by
+// operation ID: the alternatives, each the schemes it requires together.
+var operationSecurity = map[string][][]string{
+	"Admin":    {{"apiKey", "appId"}, {}, {"oauth"}},
+	"ListPets": {{"bearerAuth"}},
+}
+
+// applySecurity attaches the credentials satisfying the security requirement
+// of the operation to req.
+func (c *Client) applySecurity(ctx context.Context, req *http.Request, operationID string) error {
+	for _, schemes := range operationSecurity[operationID] {
+		// An anonymous alternative is satisfied without credentials.
+		if len(schemes) == 0 || !c.hasSecurityCredentials(schemes) {
+			continue
+		}
+		for _, scheme := range schemes {
+			if err := c.securityCredentials[scheme].Intercept(ctx, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// hasSecurityCredentials returns whether there are credentials for each of
+// the schemes.
+func (c *Client) hasSecurityCredentials(schemes []string) bool {
+	for _, scheme := range schemes {
+		if _, ok := c.securityCredentials[scheme]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// AdminWithResponse performs a GET /admin (the `Admin` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	AdminWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AdminResponse, error)
+
+	// HealthWithResponse performs a GET /health (the `Health` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthResponse, error)
+
+	// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+}
+
+type AdminResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r AdminResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r AdminResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type HealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r HealthResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r HealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r HealthResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r ListPetsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListPetsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// AdminWithResponse performs a GET /admin (the `Admin` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) AdminWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AdminResponse, error) {
+	rsp, err := c.Admin(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminResponse(rsp)
+}
+
+// HealthWithResponse performs a GET /health (the `Health` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthResponse, error) {
+	rsp, err := c.Health(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealthResponse(rsp)
+}
+
+// ListPetsWithResponse performs a GET /pets (the `ListPets` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// ParseAdminResponse parses an HTTP response from a AdminWithResponse call
+func ParseAdminResponse(rsp *http.Response) (*AdminResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseHealthResponse parses an HTTP response from a HealthWithResponse call
+func ParseHealthResponse(rsp *http.Response) (*HealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,89 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// received is the credentials of a request.
+type received struct {
+	Authorization string
+	APIKey        string
+	AppID         string
+}
+
+// newServer returns a server recording the credentials of its requests.
+func newServer(t *testing.T) (*httptest.Server, map[string]received) {
+	requests := map[string]received{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path] = received{
+			Authorization: r.Header.Get("Authorization"),
+			APIKey:        r.Header.Get("X-API-Key"),
+			AppID:         r.URL.Query().Get("app_id"),
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func call(t *testing.T, client *Client) {
+	for _, do := range []func(context.Context, ...RequestEditorFn) (*http.Response, error){client.ListPets, client.Health, client.Admin} {
+		resp, err := do(context.Background())
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+}
+
+func TestWithSecurity(t *testing.T) {
+	bearer, err := securityprovider.NewSecurityProviderBearerToken("token")
+	require.NoError(t, err)
+	apiKey, err := securityprovider.NewSecurityProviderApiKey("header", "X-API-Key", "key")
+	require.NoError(t, err)
+	appID, err := securityprovider.NewSecurityProviderApiKey("query", "app_id", "app")
+	require.NoError(t, err)
+
+	server, requests := newServer(t)
+	client, err := NewClient(server.URL, WithSecurity(
+		BearerAuthCredentials(bearer.Intercept),
+		ApiKeyCredentials(apiKey.Intercept),
+		AppIdCredentials(appID.Intercept),
+	))
+	require.NoError(t, err)
+	call(t, client)
+
+	assert.Equal(t, received{Authorization: "Bearer token"}, requests["/pets"], "the top-level requirement")
+	assert.Equal(t, received{}, requests["/health"], "security: []")
+	assert.Equal(t, received{APIKey: "key", AppID: "app"}, requests["/admin"], "the first alternative, with both its schemes")
+}
+
+func TestWithSecurityAlternatives(t *testing.T) {
+	oauth, err := securityprovider.NewSecurityProviderBearerToken("oauth-token")
+	require.NoError(t, err)
+	apiKey, err := securityprovider.NewSecurityProviderApiKey("header", "X-API-Key", "key")
+	require.NoError(t, err)
+
+	// Without appId, the first alternative isn't satisfied, and the
+	// anonymous one is skipped for the oauth one.
+	server, requests := newServer(t)
+	client, err := NewClient(server.URL, WithSecurity(
+		ApiKeyCredentials(apiKey.Intercept),
+		OauthCredentials(oauth.Intercept),
+	))
+	require.NoError(t, err)
+	call(t, client)
+
+	assert.Equal(t, received{Authorization: "Bearer oauth-token"}, requests["/admin"])
+	assert.Equal(t, received{}, requests["/pets"], "no credentials for bearerAuth")
+
+	// Without credentials, the anonymous alternative is satisfied.
+	client, err = NewClient(server.URL)
+	require.NoError(t, err)
+	call(t, client)
+	assert.Equal(t, received{}, requests["/admin"])
+}
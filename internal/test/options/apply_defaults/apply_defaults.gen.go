@@ -0,0 +1,399 @@
+//go:build go1.22
+
+// Package optionsapplydefaults provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package optionsapplydefaults
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for Order.
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Valid indicates whether the value is a known member of the Order enum.
+func (e Order) Valid() bool {
+	switch e {
+	case Asc:
+		return true
+	case Desc:
+		return true
+	default:
+		return false
+	}
+}
+
+// Order defines model for Order.
+type Order string
+
+// Part defines model for Part.
+type Part struct {
+	Count *int `json:"count,omitempty"`
+}
+
+// Plain defines model for Plain.
+type Plain struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Size defines model for Size.
+type Size struct {
+	Unit  *Order `json:"unit,omitempty"`
+	Width *int   `json:"width,omitempty"`
+}
+
+// Widget defines model for Widget.
+type Widget struct {
+	Color   *string          `json:"color,omitempty"`
+	Created *time.Time       `json:"created,omitempty"`
+	Labels  *map[string]Part `json:"labels,omitempty"`
+	Name    string           `json:"name"`
+	Note    *string          `json:"note"`
+	Parts   *[]Part          `json:"parts,omitempty"`
+	Size    *Size            `json:"size,omitempty"`
+	Weight  *float64         `json:"weight,omitempty"`
+}
+
+// ListWidgetsParams defines parameters for ListWidgets.
+type ListWidgetsParams struct {
+	Limit    *int32    `form:"limit,omitempty" json:"limit,omitempty"`
+	Order    *Order    `form:"order,omitempty" json:"order,omitempty"`
+	Tags     *[]string `form:"tags,omitempty" json:"tags,omitempty"`
+	Cursor   *string   `form:"cursor,omitempty" json:"cursor,omitempty"`
+	XVerbose *bool     `json:"X-Verbose,omitempty"`
+	Session  *string   `form:"session,omitempty" json:"session,omitempty"`
+}
+
+// ApplyDefaults sets the unset optional fields of the Part which have a
+// default to that default, and applies the defaults of the values it holds.
+func (t *Part) ApplyDefaults() {
+	if t.Count == nil {
+		var v int = 1
+		t.Count = &v
+	}
+}
+
+// ApplyDefaults sets the unset optional fields of the Size which have a
+// default to that default, and applies the defaults of the values it holds.
+func (t *Size) ApplyDefaults() {
+	if t.Unit == nil {
+		var v Order = "asc"
+		t.Unit = &v
+	}
+	if t.Width == nil {
+		var v int = 10
+		t.Width = &v
+	}
+}
+
+// ApplyDefaults sets the unset optional fields of the Widget which have a
+// default to that default, and applies the defaults of the values it holds.
+func (t *Widget) ApplyDefaults() {
+	if t.Color == nil {
+		var v string = "blue"
+		t.Color = &v
+	}
+	if t.Labels != nil {
+		for k, v := range *t.Labels {
+			v.ApplyDefaults()
+			(*t.Labels)[k] = v
+		}
+	}
+	if t.Parts != nil {
+		for i := range *t.Parts {
+			(*t.Parts)[i].ApplyDefaults()
+		}
+	}
+	if t.Size != nil {
+		t.Size.ApplyDefaults()
+	}
+	if t.Weight == nil {
+		var v float64 = 1.5
+		t.Weight = &v
+	}
+}
+
+// ApplyDefaults sets the unset optional fields of the ListWidgetsParams which have a
+// default to that default, and applies the defaults of the values it holds.
+func (t *ListWidgetsParams) ApplyDefaults() {
+	if t.Limit == nil {
+		var v int32 = 20
+		t.Limit = &v
+	}
+	if t.Order == nil {
+		var v Order = "asc"
+		t.Order = &v
+	}
+	if t.Tags == nil {
+		v := []string{"new", "sale"}
+		t.Tags = &v
+	}
+	if t.XVerbose == nil {
+		var v bool = false
+		t.XVerbose = &v
+	}
+	if t.Session == nil {
+		var v string = "anonymous"
+		t.Session = &v
+	}
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /widgets)
+	ListWidgets(w http.ResponseWriter, r *http.Request, params ListWidgetsParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListWidgets operation middleware
+func (siw *ServerInterfaceWrapper) ListWidgets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWidgetsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: "int32"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "order", r.URL.Query(), &params.Order, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "order"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tags", r.URL.Query(), &params.Tags, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "tags"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tags", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Verbose" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Verbose")]; found {
+		var XVerbose bool
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Verbose", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Verbose", valueList[0], &XVerbose, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "boolean", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Verbose", Err: err})
+			return
+		}
+
+		params.XVerbose = &XVerbose
+
+	}
+
+	{
+		var cookie *http.Cookie
+
+		if cookie, err = r.Cookie("session"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "session", cookie.Value, &value, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationCookie, Explode: true, Required: false, Type: "string", Format: ""})
+			if err != nil {
+				siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session", Err: err})
+				return
+			}
+			params.Session = &value
+
+		}
+	}
+
+	params.ApplyDefaults()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWidgets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of [http.ServeMux].
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	http.Handler
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/widgets", wrapper.ListWidgets)
+
+	return m
+}
@@ -0,0 +1,86 @@
+package optionsapplydefaults
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestApplyDefaultsSetsUnsetFields(t *testing.T) {
+	w := Widget{Name: "w"}
+	w.ApplyDefaults()
+	assert.Equal(t, Widget{Name: "w", Color: ptr("blue"), Weight: ptr(1.5)}, w)
+}
+
+func TestApplyDefaultsKeepsSetFields(t *testing.T) {
+	w := Widget{Name: "w", Color: ptr("red"), Weight: ptr(0.0)}
+	w.ApplyDefaults()
+	assert.Equal(t, "red", *w.Color)
+	assert.Equal(t, 0.0, *w.Weight)
+}
+
+func TestApplyDefaultsSkipsUnsupportedDefaults(t *testing.T) {
+	// The nullable note and the date-time created have defaults, but nil may
+	// be an explicit null and time.Time has no constant form.
+	w := Widget{Name: "w"}
+	w.ApplyDefaults()
+	assert.Nil(t, w.Note)
+	assert.Nil(t, w.Created)
+}
+
+func TestApplyDefaultsRecursesIntoNestedValues(t *testing.T) {
+	labels := map[string]Part{"a": {}, "b": {Count: ptr(5)}}
+	w := Widget{
+		Name:   "w",
+		Size:   &Size{Unit: ptr(Desc)},
+		Parts:  &[]Part{{}, {Count: ptr(3)}},
+		Labels: &labels,
+	}
+	w.ApplyDefaults()
+	assert.Equal(t, &Size{Unit: ptr(Desc), Width: ptr(10)}, w.Size)
+	assert.Equal(t, []Part{{Count: ptr(1)}, {Count: ptr(3)}}, *w.Parts)
+	assert.Equal(t, map[string]Part{"a": {Count: ptr(1)}, "b": {Count: ptr(5)}}, *w.Labels)
+}
+
+type server struct {
+	params ListWidgetsParams
+}
+
+func (s *server) ListWidgets(w http.ResponseWriter, r *http.Request, params ListWidgetsParams) {
+	s.params = params
+}
+
+func TestServerAppliesParamDefaults(t *testing.T) {
+	s := &server{}
+	h := Handler(s)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/widgets", nil))
+	assert.Equal(t, ListWidgetsParams{
+		Limit:    ptr(int32(20)),
+		Order:    ptr(Asc),
+		Tags:     &[]string{"new", "sale"},
+		XVerbose: ptr(false),
+		Session:  ptr("anonymous"),
+	}, s.params)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets?limit=5&order=desc&tags=a&cursor=c", nil)
+	req.Header.Set("X-Verbose", "true")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotNil(t, s.params.Cursor)
+	assert.Equal(t, ListWidgetsParams{
+		Limit:    ptr(int32(5)),
+		Order:    ptr(Desc),
+		Tags:     &[]string{"a"},
+		XVerbose: ptr(true),
+		Session:  ptr("s1"),
+		Cursor:   ptr("c"),
+	}, s.params)
+}
@@ -0,0 +1,7 @@
+// Package optionsapplydefaults exercises the apply-defaults output option:
+// ApplyDefaults methods setting unset optional fields of models and parameter
+// objects to their schema default, and the std-http server wrapper applying
+// parameter defaults before calling the handler.
+package optionsapplydefaults
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,6 @@
+// Package optionsdoccommentorigins exercises output-options.doc-comment-origins,
+// which adds the spec file, line and column of each type and operation to
+// its doc comment.
+package optionsdoccommentorigins
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
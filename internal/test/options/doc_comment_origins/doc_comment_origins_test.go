@@ -0,0 +1,33 @@
+package optionsdoccommentorigins
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSourceComments checks that each Source line of the generated doc
+// comments points at the key of the spec element the code is generated from.
+func TestSourceComments(t *testing.T) {
+	generated, err := os.ReadFile("doc_comment_origins.gen.go")
+	require.NoError(t, err)
+	spec, err := os.ReadFile("spec.yaml")
+	require.NoError(t, err)
+	lines := strings.Split(string(spec), "\n")
+
+	sources := regexp.MustCompile(`// Source: spec\.yaml:(\d+):(\d+)\n`).FindAllStringSubmatch(string(generated), -1)
+	keys := map[string]bool{}
+	for _, source := range sources {
+		line, _ := strconv.Atoi(source[1])
+		column, _ := strconv.Atoi(source[2])
+		require.LessOrEqual(t, line, len(lines))
+		key, _, _ := strings.Cut(lines[line-1][column-1:], ":")
+		keys[key] = true
+	}
+	assert.Equal(t, map[string]bool{"get": true, "post": true, "Pet": true, "Pets": true}, keys)
+}
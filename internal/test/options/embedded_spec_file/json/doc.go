@@ -0,0 +1,8 @@
+// Package optionsembeddedspecfilejson checks that embedded-spec-file:
+// openapi.json writes the spec as indented JSON next to the code, which
+// embeds it with go:embed, and that GetSpec and GetSpecJSON return it.
+//
+// outputoptions/embedded-spec-file/json
+package optionsembeddedspecfilejson
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,27 @@
+package optionsembeddedspecfilejson
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedSpecFile verifies that the spec is embedded from openapi.json,
+// as it's written next to the code, and is loaded by GetSpec.
+//
+// Sources: outputoptions/embedded-spec-file/json
+func TestEmbeddedSpecFile(t *testing.T) {
+	file, err := os.ReadFile("openapi.json")
+	require.NoError(t, err)
+	specJSON, err := GetSpecJSON()
+	require.NoError(t, err)
+	assert.Equal(t, file, specJSON)
+
+	spec, err := GetSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Pets", spec.Info.Title)
+	require.NotNil(t, spec.Paths.Find("/pets/{id}"))
+	assert.Contains(t, spec.Components.Schemas["Pet"].Value.Properties, "age")
+}
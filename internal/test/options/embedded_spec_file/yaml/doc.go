@@ -0,0 +1,8 @@
+// Package optionsembeddedspecfileyaml checks that embedded-spec-file:
+// openapi.yaml writes the spec as YAML next to the code, which embeds it with
+// go:embed, and that GetSpecJSON converts it to JSON.
+//
+// outputoptions/embedded-spec-file/yaml
+package optionsembeddedspecfileyaml
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,26 @@
+package optionsembeddedspecfileyaml
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedSpecFile verifies that the spec embedded from openapi.yaml is
+// converted to JSON by GetSpecJSON, and is loaded by GetSpec.
+//
+// Sources: outputoptions/embedded-spec-file/yaml
+func TestEmbeddedSpecFile(t *testing.T) {
+	specJSON, err := GetSpecJSON()
+	require.NoError(t, err)
+	assert.True(t, json.Valid(specJSON))
+	assert.Contains(t, string(specJSON), `"operationId":"GetPet"`)
+
+	spec, err := GetSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Pets", spec.Info.Title)
+	require.NotNil(t, spec.Paths.Find("/pets/{id}"))
+	assert.Equal(t, []string{"name"}, spec.Components.Schemas["Pet"].Value.Required)
+}
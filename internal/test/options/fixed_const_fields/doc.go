@@ -0,0 +1,6 @@
+// Package optionsfixedconstfields exercises the fixed-const-fields output
+// option: OpenAPI 3.1 `const` schemas generated as zero-size types which
+// write their value when marshaled and reject any other when unmarshaled.
+package optionsfixedconstfields
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
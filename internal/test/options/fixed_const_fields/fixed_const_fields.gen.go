@@ -0,0 +1,871 @@
+//go:build go1.22
+
+// Package optionsfixedconstfields provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package optionsfixedconstfields
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for MaybeVersion.
+const (
+	V1 MaybeVersion = "v1"
+)
+
+// Valid indicates whether the value is a known member of the MaybeVersion enum.
+func (e MaybeVersion) Valid() bool {
+	switch e {
+	case V1:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApiVersion defines model for ApiVersion.
+type ApiVersion struct{}
+
+// Created defines model for Created.
+type Created struct {
+	Id      string      `json:"id"`
+	Kind    CreatedKind `json:"kind"`
+	Version ApiVersion  `json:"version"`
+}
+
+// CreatedKind defines model for Created.Kind.
+type CreatedKind struct{}
+
+// Deleted defines model for Deleted.
+type Deleted struct {
+	Final  DeletedFinal  `json:"final,omitempty"`
+	Id     string        `json:"id"`
+	Kind   DeletedKind   `json:"kind"`
+	Ratio  DeletedRatio  `json:"ratio,omitempty"`
+	Schema DeletedSchema `json:"schema,omitempty"`
+}
+
+// DeletedFinal defines model for Deleted.Final.
+type DeletedFinal struct{}
+
+// DeletedKind defines model for Deleted.Kind.
+type DeletedKind struct{}
+
+// DeletedRatio defines model for Deleted.Ratio.
+type DeletedRatio struct{}
+
+// DeletedSchema defines model for Deleted.Schema.
+type DeletedSchema struct{}
+
+// Event defines model for Event.
+type Event struct {
+	union json.RawMessage
+}
+
+// MaybeVersion defines model for MaybeVersion.
+type MaybeVersion string
+
+// ListEventsParams defines parameters for ListEvents.
+type ListEventsParams struct {
+	Version ApiVersion `form:"version" json:"version"`
+}
+
+// AsCreated returns the union data inside the Event as a Created
+func (t Event) AsCreated() (Created, error) {
+	var body Created
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromCreated overwrites any union data inside the Event as the provided Created
+func (t *Event) FromCreated(v Created) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err = runtime.JSONMerge(b, []byte(`{"kind":"created"}`))
+	t.union = b
+	return err
+}
+
+// MergeCreated performs a merge with any union data inside the Event, using the provided Created
+func (t *Event) MergeCreated(v Created) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err = runtime.JSONMerge(b, []byte(`{"kind":"created"}`))
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsDeleted returns the union data inside the Event as a Deleted
+func (t Event) AsDeleted() (Deleted, error) {
+	var body Deleted
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromDeleted overwrites any union data inside the Event as the provided Deleted
+func (t *Event) FromDeleted(v Deleted) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err = runtime.JSONMerge(b, []byte(`{"kind":"deleted"}`))
+	t.union = b
+	return err
+}
+
+// MergeDeleted performs a merge with any union data inside the Event, using the provided Deleted
+func (t *Event) MergeDeleted(v Deleted) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err = runtime.JSONMerge(b, []byte(`{"kind":"deleted"}`))
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Event) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"kind"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t Event) ValueByDiscriminator() (any, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "created":
+		return t.AsCreated()
+	case "deleted":
+		return t.AsDeleted()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t Event) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Event) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// Value returns the fixed value of a ApiVersion, "v2".
+func (ApiVersion) Value() string {
+	return "v2"
+}
+
+// MarshalJSON encodes a ApiVersion as its fixed value.
+func (ApiVersion) MarshalJSON() ([]byte, error) {
+	return []byte("\"v2\""), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a ApiVersion.
+func (*ApiVersion) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != "v2" {
+		return fmt.Errorf("invalid value for ApiVersion: %v, must be %v", v, "v2")
+	}
+	return nil
+}
+
+// MarshalText encodes a ApiVersion as its fixed value, for parameters.
+func (ApiVersion) MarshalText() ([]byte, error) {
+	return []byte("v2"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a ApiVersion,
+// for parameters.
+func (*ApiVersion) UnmarshalText(b []byte) error {
+	if string(b) != "v2" {
+		return fmt.Errorf("invalid value for ApiVersion: %s, must be %s", b, "v2")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *ApiVersion) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// Value returns the fixed value of a CreatedKind, "created".
+func (CreatedKind) Value() string {
+	return "created"
+}
+
+// MarshalJSON encodes a CreatedKind as its fixed value.
+func (CreatedKind) MarshalJSON() ([]byte, error) {
+	return []byte("\"created\""), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a CreatedKind.
+func (*CreatedKind) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != "created" {
+		return fmt.Errorf("invalid value for CreatedKind: %v, must be %v", v, "created")
+	}
+	return nil
+}
+
+// MarshalText encodes a CreatedKind as its fixed value, for parameters.
+func (CreatedKind) MarshalText() ([]byte, error) {
+	return []byte("created"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a CreatedKind,
+// for parameters.
+func (*CreatedKind) UnmarshalText(b []byte) error {
+	if string(b) != "created" {
+		return fmt.Errorf("invalid value for CreatedKind: %s, must be %s", b, "created")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *CreatedKind) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// Value returns the fixed value of a DeletedFinal, true.
+func (DeletedFinal) Value() bool {
+	return true
+}
+
+// MarshalJSON encodes a DeletedFinal as its fixed value.
+func (DeletedFinal) MarshalJSON() ([]byte, error) {
+	return []byte("true"), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a DeletedFinal.
+func (*DeletedFinal) UnmarshalJSON(b []byte) error {
+	var v bool
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != true {
+		return fmt.Errorf("invalid value for DeletedFinal: %v, must be %v", v, true)
+	}
+	return nil
+}
+
+// MarshalText encodes a DeletedFinal as its fixed value, for parameters.
+func (DeletedFinal) MarshalText() ([]byte, error) {
+	return []byte("true"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a DeletedFinal,
+// for parameters.
+func (*DeletedFinal) UnmarshalText(b []byte) error {
+	if string(b) != "true" {
+		return fmt.Errorf("invalid value for DeletedFinal: %s, must be %s", b, "true")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *DeletedFinal) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// Value returns the fixed value of a DeletedKind, "deleted".
+func (DeletedKind) Value() string {
+	return "deleted"
+}
+
+// MarshalJSON encodes a DeletedKind as its fixed value.
+func (DeletedKind) MarshalJSON() ([]byte, error) {
+	return []byte("\"deleted\""), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a DeletedKind.
+func (*DeletedKind) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != "deleted" {
+		return fmt.Errorf("invalid value for DeletedKind: %v, must be %v", v, "deleted")
+	}
+	return nil
+}
+
+// MarshalText encodes a DeletedKind as its fixed value, for parameters.
+func (DeletedKind) MarshalText() ([]byte, error) {
+	return []byte("deleted"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a DeletedKind,
+// for parameters.
+func (*DeletedKind) UnmarshalText(b []byte) error {
+	if string(b) != "deleted" {
+		return fmt.Errorf("invalid value for DeletedKind: %s, must be %s", b, "deleted")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *DeletedKind) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// Value returns the fixed value of a DeletedRatio, 0.5.
+func (DeletedRatio) Value() float32 {
+	return 0.5
+}
+
+// MarshalJSON encodes a DeletedRatio as its fixed value.
+func (DeletedRatio) MarshalJSON() ([]byte, error) {
+	return []byte("0.5"), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a DeletedRatio.
+func (*DeletedRatio) UnmarshalJSON(b []byte) error {
+	var v float32
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != 0.5 {
+		return fmt.Errorf("invalid value for DeletedRatio: %v, must be %v", v, 0.5)
+	}
+	return nil
+}
+
+// MarshalText encodes a DeletedRatio as its fixed value, for parameters.
+func (DeletedRatio) MarshalText() ([]byte, error) {
+	return []byte("0.5"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a DeletedRatio,
+// for parameters.
+func (*DeletedRatio) UnmarshalText(b []byte) error {
+	if string(b) != "0.5" {
+		return fmt.Errorf("invalid value for DeletedRatio: %s, must be %s", b, "0.5")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *DeletedRatio) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// Value returns the fixed value of a DeletedSchema, 3.
+func (DeletedSchema) Value() int {
+	return 3
+}
+
+// MarshalJSON encodes a DeletedSchema as its fixed value.
+func (DeletedSchema) MarshalJSON() ([]byte, error) {
+	return []byte("3"), nil
+}
+
+// UnmarshalJSON rejects any value other than the fixed value of a DeletedSchema.
+func (*DeletedSchema) UnmarshalJSON(b []byte) error {
+	var v int
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v != 3 {
+		return fmt.Errorf("invalid value for DeletedSchema: %v, must be %v", v, 3)
+	}
+	return nil
+}
+
+// MarshalText encodes a DeletedSchema as its fixed value, for parameters.
+func (DeletedSchema) MarshalText() ([]byte, error) {
+	return []byte("3"), nil
+}
+
+// UnmarshalText rejects any value other than the fixed value of a DeletedSchema,
+// for parameters.
+func (*DeletedSchema) UnmarshalText(b []byte) error {
+	if string(b) != "3" {
+		return fmt.Errorf("invalid value for DeletedSchema: %s, must be %s", b, "3")
+	}
+	return nil
+}
+
+// Bind implements runtime.Binder, for parameters; see UnmarshalText.
+func (v *DeletedSchema) Bind(src string) error {
+	return v.UnmarshalText([]byte(src))
+}
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// ListEvents performs a GET /events (the `ListEvents` operationId) request.
+	ListEvents(ctx context.Context, params *ListEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// ListEvents performs a GET /events (the `ListEvents` operationId) request.
+func (c *Client) ListEvents(ctx context.Context, params *ListEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListEventsRequest constructs an http.Request for the ListEvents method
+func NewListEventsRequest(server string, params *ListEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "/events"
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		// queryValues collects non-styled parameters (passthrough, JSON)
+		// that are safe to round-trip through url.Values.Encode().
+		queryValues := queryURL.Query()
+		// rawQueryFragments collects pre-encoded query fragments from
+		// styled parameters, preserving literal commas as delimiters
+		// per the OpenAPI spec (e.g. "color=blue,black,brown").
+		var rawQueryFragments []string
+
+		if queryFrag, err := runtime.StyleParamWithOptions("form", true, "version", params.Version, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+			return nil, err
+		} else {
+			for _, qp := range strings.Split(queryFrag, "&") {
+				rawQueryFragments = append(rawQueryFragments, qp)
+			}
+		}
+
+		if encoded := queryValues.Encode(); encoded != "" {
+			rawQueryFragments = append(rawQueryFragments, encoded)
+		}
+		queryURL.RawQuery = strings.Join(rawQueryFragments, "&")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// ListEventsWithResponse performs a GET /events (the `ListEvents` operationId) request.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	ListEventsWithResponse(ctx context.Context, params *ListEventsParams, reqEditors ...RequestEditorFn) (*ListEventsResponse, error)
+}
+
+type ListEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *[]Event
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListEventsResponse) GetJSON200() *[]Event {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListEventsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListEventsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// ListEventsWithResponse performs a GET /events (the `ListEvents` operationId) request.
+//
+// Returns a wrapper object for the known response body format(s).
+func (c *ClientWithResponses) ListEventsWithResponse(ctx context.Context, params *ListEventsParams, reqEditors ...RequestEditorFn) (*ListEventsResponse, error) {
+	rsp, err := c.ListEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListEventsResponse(rsp)
+}
+
+// ParseListEventsResponse parses an HTTP response from a ListEventsWithResponse call
+func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Event
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /events)
+	ListEvents(w http.ResponseWriter, r *http.Request, params ListEventsParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListEventsParams
+
+	// ------------- Required query parameter "version" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "version", r.URL.Query(), &params.Version, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "version"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of [http.ServeMux].
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	http.Handler
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/events", wrapper.ListEvents)
+
+	return m
+}
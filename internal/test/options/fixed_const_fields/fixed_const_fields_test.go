@@ -0,0 +1,89 @@
+package optionsfixedconstfields
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalWritesFixedValues(t *testing.T) {
+	b, err := json.Marshal(Created{Id: "1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","kind":"created","version":"v2"}`, string(b))
+
+	// Optional fixed values are written too.
+	b, err = json.Marshal(Deleted{Id: "1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","kind":"deleted","schema":3,"final":true,"ratio":0.5}`, string(b))
+}
+
+func TestUnmarshalVerifiesFixedValues(t *testing.T) {
+	var c Created
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1","kind":"created","version":"v2"}`), &c))
+	assert.Equal(t, Created{Id: "1"}, c)
+
+	err := json.Unmarshal([]byte(`{"id":"1","kind":"created","version":"v1"}`), &c)
+	assert.EqualError(t, err, "invalid value for ApiVersion: v1, must be v2")
+
+	var d Deleted
+	err = json.Unmarshal([]byte(`{"id":"1","kind":"deleted","schema":4}`), &d)
+	assert.EqualError(t, err, "invalid value for DeletedSchema: 4, must be 3")
+
+	err = json.Unmarshal([]byte(`{"id":"1","kind":"deleted","ratio":"0.5"}`), &d)
+	assert.Error(t, err)
+}
+
+func TestValueReturnsFixedValue(t *testing.T) {
+	assert.Equal(t, "v2", ApiVersion{}.Value())
+	assert.Equal(t, 3, DeletedSchema{}.Value())
+	assert.Equal(t, true, DeletedFinal{}.Value())
+	assert.Equal(t, float32(0.5), DeletedRatio{}.Value())
+}
+
+func TestDiscriminatedUnionOfFixedValues(t *testing.T) {
+	var e Event
+	require.NoError(t, e.FromDeleted(Deleted{Id: "1"}))
+	discriminator, err := e.Discriminator()
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", discriminator)
+
+	v, err := e.ValueByDiscriminator()
+	require.NoError(t, err)
+	assert.Equal(t, Deleted{Id: "1"}, v)
+
+	// A member can't be decoded from the data of another.
+	_, err = e.AsCreated()
+	assert.EqualError(t, err, "invalid value for CreatedKind: deleted, must be created")
+}
+
+func TestNullableConstKeepsEnum(t *testing.T) {
+	assert.True(t, MaybeVersion("v1").Valid())
+}
+
+type server struct{}
+
+func (server) ListEvents(w http.ResponseWriter, r *http.Request, params ListEventsParams) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestParameterFixedValue(t *testing.T) {
+	srv := httptest.NewServer(Handler(server{}))
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	rsp, err := client.ListEvents(context.Background(), &ListEventsParams{})
+	require.NoError(t, err)
+	defer func() { _ = rsp.Body.Close() }()
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	assert.Equal(t, "version=v2", rsp.Request.URL.RawQuery)
+
+	rec := httptest.NewRecorder()
+	Handler(server{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events?version=v1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,9 @@
+// Package optionspublicspec checks that public-spec embeds a public variant
+// of the spec, without the operations it excludes, the components only they
+// use, and the extensions of oapi-codegen, which GetPublicSpec returns and the
+// spec routes serve, while GetSpec returns the whole spec.
+//
+// outputoptions/public-spec
+package optionspublicspec
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,65 @@
+package optionspublicspec
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetPublicSpec verifies that the public spec is without the excluded
+// operations, and the components and tags only they use, and the extensions
+// of oapi-codegen, while the spec has them all.
+//
+// Sources: outputoptions/public-spec
+func TestGetPublicSpec(t *testing.T) {
+	public, err := GetPublicSpec()
+	require.NoError(t, err)
+
+	pets := public.Paths.Find("/pets/{id}")
+	require.NotNil(t, pets)
+	assert.NotNil(t, pets.Get)
+	assert.Nil(t, pets.Delete, "the x-internal operation")
+	assert.Nil(t, public.Paths.Find("/admin/audit"), "the operation tagged admin")
+	assert.NotContains(t, public.Components.Schemas, "AuditLog")
+	require.Len(t, public.Tags, 1)
+	assert.Equal(t, "pets", public.Tags[0].Name)
+
+	assert.NotContains(t, pets.Get.Parameters[0].Value.Extensions, "x-go-name")
+	pet := public.Components.Schemas["Pet"].Value
+	assert.NotContains(t, pet.Properties["name"].Value.Extensions, "x-oapi-codegen-extra-tags")
+	assert.NotContains(t, pet.Properties["age"].Value.Extensions, "x-go-type")
+
+	spec, err := GetSpec()
+	require.NoError(t, err)
+	assert.NotNil(t, spec.Paths.Find("/pets/{id}").Delete)
+	assert.NotNil(t, spec.Paths.Find("/admin/audit"))
+	assert.Contains(t, spec.Components.Schemas, "AuditLog")
+	assert.Contains(t, spec.Components.Schemas["Pet"].Value.Properties["age"].Value.Extensions, "x-go-type")
+}
+
+// TestSpecRoutesServePublicSpec verifies that the spec routes serve the
+// public spec.
+//
+// Sources: outputoptions/public-spec
+func TestSpecRoutesServePublicSpec(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterSpecRoutes(mux, SpecRoutesOptions{})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	publicJSON, err := GetPublicSpecJSON()
+	require.NoError(t, err)
+	assert.Equal(t, publicJSON, rec.Body.Bytes())
+
+	var served struct {
+		Paths map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.NotContains(t, served.Paths, "/admin/audit")
+}
@@ -0,0 +1,7 @@
+// Package optionstypemappingpresets exercises the `decimal` and
+// `int64-as-string` presets of output-options.type-mapping: arbitrary
+// precision and string-encoded numbers in bodies and parameters, alongside
+// the types of the default mappings, such as time.Time.
+package optionstypemappingpresets
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,106 @@
+package optionstypemappingpresets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/numeric"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceJSON(t *testing.T) {
+	var b Balance
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"account": "9007199254740993",
+		"amount": "12345678901234567890.10",
+		"exact": 0.1000000000000000055511151231257827,
+		"converted": {"EUR": "1.20"}
+	}`), &b))
+	assert.Equal(t, numeric.Int64String(9007199254740993), b.Account)
+	assert.Equal(t, "12345678901234567890.10", b.Amount.String())
+	assert.Equal(t, "0.1000000000000000055511151231257827", b.Exact.String())
+	assert.Equal(t, "1.20", (*b.Converted)["EUR"].String())
+
+	out, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"account": "9007199254740993",
+		"amount": "12345678901234567890.10",
+		"exact": 0.1000000000000000055511151231257827,
+		"converted": {"EUR": "1.20"}
+	}`, string(out))
+	assert.Contains(t, string(out), `"exact":0.1000000000000000055511151231257827`)
+}
+
+func TestTransactionJSON(t *testing.T) {
+	var tx Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"amount": "0.30",
+		"postedAt": "2024-02-29T12:00:00Z",
+		"raw": {"memo": "coffee"},
+		"metadata": {"tags": ["food"]}
+	}`), &tx))
+	assert.Equal(t, "0.30", tx.Amount.String())
+	assert.True(t, tx.PostedAt.Equal(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)))
+	assert.JSONEq(t, `{"memo": "coffee"}`, string(tx.Raw))
+	assert.Equal(t, []any{"food"}, (*tx.Metadata)["tags"])
+}
+
+type server struct{}
+
+func (server) GetBalance(w http.ResponseWriter, r *http.Request, id numeric.Int64String, threshold numeric.Number, params GetBalanceParams) {
+	b := Balance{Account: id, Amount: threshold.Decimal, Exact: threshold, Rate: params.Rate}
+	if params.Rates != nil {
+		converted := map[string]numeric.Decimal{}
+		for _, rate := range *params.Rates {
+			converted[rate.String()] = rate
+		}
+		b.Converted = &converted
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(b)
+}
+
+func TestParameterBinding(t *testing.T) {
+	srv := httptest.NewServer(Handler(server{}))
+	defer srv.Close()
+
+	client, err := NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+
+	rate := numeric.MustParse("0.000000000000000000001")
+	rates := []numeric.Decimal{numeric.MustParse("1.10"), numeric.MustParse("-2")}
+	rsp, err := client.GetBalanceWithResponse(context.Background(), 9007199254740993,
+		numeric.Number{Decimal: numeric.MustParse("99999999999999999999.99")},
+		&GetBalanceParams{Rate: &rate, Rates: &rates})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode(), string(rsp.Body))
+
+	b := rsp.JSON200
+	require.NotNil(t, b)
+	assert.Equal(t, numeric.Int64String(9007199254740993), b.Account)
+	assert.Equal(t, "99999999999999999999.99", b.Amount.String())
+	assert.Equal(t, "99999999999999999999.99", b.Exact.String())
+	assert.Equal(t, "0.000000000000000000001", b.Rate.String())
+	assert.Equal(t, map[string]numeric.Decimal{"1.10": rates[0], "-2": rates[1]}, *b.Converted)
+}
+
+func TestInvalidParameter(t *testing.T) {
+	srv := httptest.NewServer(Handler(server{}))
+	defer srv.Close()
+
+	rsp, err := http.Get(srv.URL + "/accounts/1/balance/1.5?rate=1,5")
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+
+	rsp2, err := http.Get(srv.URL + "/accounts/1.5/balance/1")
+	require.NoError(t, err)
+	defer rsp2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, rsp2.StatusCode)
+}
@@ -0,0 +1,7 @@
+// Package serversspecroutes is the multi-framework harness of spec-routes:
+// one shared spec (spec.yaml), a generated server under each framework
+// subdirectory, and one table-driven test (spec_routes_test.go) of the routes
+// RegisterSpecRoutes registers on each framework's router.
+//
+// generate/spec-routes
+package serversspecroutes
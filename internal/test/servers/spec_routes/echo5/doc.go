@@ -0,0 +1,4 @@
+// Package api is the echo5 server of the spec routes harness.
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
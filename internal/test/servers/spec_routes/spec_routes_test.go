@@ -0,0 +1,170 @@
+package serversspecroutes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofiber/fiber/v2"
+	fiberadaptor "github.com/gofiber/fiber/v2/middleware/adaptor"
+	fiberv3 "github.com/gofiber/fiber/v3"
+	fiberv3adaptor "github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/gorilla/mux"
+	"github.com/kataras/iris/v12"
+	"github.com/labstack/echo/v4"
+	echov5 "github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chiAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/chi"
+	echoAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/echo"
+	echo5API "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/echo5"
+	fiberAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/fiber"
+	fiberv3API "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/fiberv3"
+	ginAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/gin"
+	gorillaAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/gorilla"
+	irisAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/iris"
+	stdhttpAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/spec_routes/stdhttp"
+)
+
+// TestSpecRoutes verifies that RegisterSpecRoutes serves the embedded spec,
+// and its docs, from each of the frameworks, alike.
+//
+// Sources: generate/spec-routes
+func TestSpecRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for name, newHandler := range map[string]func(bool) http.Handler{
+		"stdhttp": func(rewrite bool) http.Handler {
+			m := http.NewServeMux()
+			stdhttpAPI.RegisterSpecRoutes(m, stdhttpAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return m
+		},
+		"chi": func(rewrite bool) http.Handler {
+			r := chi.NewRouter()
+			chiAPI.RegisterSpecRoutes(r, chiAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return r
+		},
+		"gorilla": func(rewrite bool) http.Handler {
+			r := mux.NewRouter()
+			gorillaAPI.RegisterSpecRoutes(r, gorillaAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return r
+		},
+		"echo": func(rewrite bool) http.Handler {
+			e := echo.New()
+			echoAPI.RegisterSpecRoutes(e, echoAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return e
+		},
+		"echo5": func(rewrite bool) http.Handler {
+			e := echov5.New()
+			echo5API.RegisterSpecRoutes(e, echo5API.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return e
+		},
+		"gin": func(rewrite bool) http.Handler {
+			r := gin.New()
+			ginAPI.RegisterSpecRoutes(r, ginAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return r
+		},
+		"fiber": func(rewrite bool) http.Handler {
+			app := fiber.New()
+			fiberAPI.RegisterSpecRoutes(app, fiberAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return fiberadaptor.FiberApp(app)
+		},
+		"fiberv3": func(rewrite bool) http.Handler {
+			app := fiberv3.New()
+			fiberv3API.RegisterSpecRoutes(app, fiberv3API.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			return fiberv3adaptor.FiberApp(app)
+		},
+		"iris": func(rewrite bool) http.Handler {
+			app := iris.New()
+			irisAPI.RegisterSpecRoutes(app, irisAPI.SpecRoutesOptions{BaseURL: "/api", RewriteServers: rewrite, TrustForwardedHeaders: rewrite})
+			require.NoError(t, app.Build())
+			return app
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testSpecRoutes(t, newHandler)
+		})
+	}
+}
+
+func testSpecRoutes(t *testing.T, newHandler func(rewrite bool) http.Handler) {
+	get := func(h http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://pets.local:8080"+path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	servers := func(body []byte) []string {
+		var spec struct {
+			Servers []struct {
+				URL string `json:"url"`
+			} `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(body, &spec))
+		var urls []string
+		for _, s := range spec.Servers {
+			urls = append(urls, s.URL)
+		}
+		return urls
+	}
+
+	h := newHandler(false)
+	res := get(h, "/api/openapi.json", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"https://api.example.com/v1", "/relative"}, servers(res.Body.Bytes()))
+	etag := res.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	specJSON := res.Body.String()
+
+	res = get(h, "/api/openapi.json", http.Header{"If-None-Match": {`"other", ` + etag}})
+	assert.Equal(t, http.StatusNotModified, res.Code)
+	assert.Empty(t, res.Body.String())
+
+	res = get(h, "/api/openapi.yaml", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/yaml", res.Header().Get("Content-Type"))
+	assert.Contains(t, res.Body.String(), "title: Spec routes\n")
+	// Numbers are kept as they're written.
+	assert.Contains(t, res.Body.String(), "example: 7\n")
+	assert.NotEqual(t, etag, res.Header().Get("ETag"))
+	specYAML := res.Body.String()
+
+	res = get(h, "/api/docs", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "text/html; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Contains(t, res.Body.String(), `fetch("openapi.json")`)
+	assert.NotContains(t, res.Body.String(), "<script src")
+	assert.Contains(t, res.Header().Get("Content-Security-Policy"), "default-src 'none'")
+	assert.Empty(t, res.Header().Values("Vary"))
+
+	// The absolute server URLs are rewritten to where the spec is served
+	// from.
+	h = newHandler(true)
+	res = get(h, "/api/openapi.json", http.Header{"X-Forwarded-Proto": {"https"}})
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, []string{"Host, X-Forwarded-Proto"}, res.Header().Values("Vary"))
+	assert.Equal(t, []string{"https://pets.local:8080/v1", "/relative"}, servers(res.Body.Bytes()))
+	assert.Contains(t, res.Body.String(), `"example":7`)
+	assert.NotEqual(t, etag, res.Header().Get("ETag"))
+	// The rest of the spec, and the order of its keys, are kept as they are.
+	assert.Equal(t, strings.Replace(specJSON, "https://api.example.com", "https://pets.local:8080", 1), res.Body.String())
+
+	res = get(h, "/api/openapi.yaml", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "  - url: http://pets.local:8080/v1\n")
+	assert.Equal(t, strings.Replace(specYAML, "https://api.example.com", "http://pets.local:8080", 1), res.Body.String())
+
+	// A forwarded scheme other than http or https is ignored.
+	res = get(h, "/api/openapi.json", http.Header{"X-Forwarded-Proto": {"javascript"}})
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, []string{"http://pets.local:8080/v1", "/relative"}, servers(res.Body.Bytes()))
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteSpecServers(t *testing.T) {
+	// The spec isn't in the order of its keys, nor compact, as an embedded
+	// spec file may be.
+	spec := `{
+  "openapi": "3.0.0",
+  "servers": [
+    {"url": "https:\/\/api.example.com\/v1", "description": "url: \"https://other.example.com\""},
+    {"url": "/relative"},
+    {"url": "https://{region}.example.com", "variables": {"region": {"default": "eu"}}}
+  ],
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "x-servers": {"url": "https://api.example.com"},
+  "paths": {}
+}`
+	r := httptest.NewRequest("GET", "http://pets.local:8080/openapi.json", nil)
+	rewritten, err := rewriteSpecServers([]byte(spec), r, SpecRoutesOptions{RewriteServers: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "openapi": "3.0.0",
+  "servers": [
+    {"url": "http://pets.local:8080/v1", "description": "url: \"https://other.example.com\""},
+    {"url": "/relative"},
+    {"url": "https://{region}.example.com", "variables": {"region": {"default": "eu"}}}
+  ],
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "x-servers": {"url": "https://api.example.com"},
+  "paths": {}
+}`, string(rewritten))
+}
+
+func TestRewriteSpecServersForwardedHeaders(t *testing.T) {
+	spec := `{"servers":[{"url":"https://api.example.com/v1"}]}`
+	rewrite := func(host, proto string, trust bool) string {
+		t.Helper()
+		r := httptest.NewRequest("GET", "http://pets.local/openapi.json", nil)
+		r.Host = host
+		r.Header.Set("X-Forwarded-Proto", proto)
+		rewritten, err := rewriteSpecServers([]byte(spec), r, SpecRoutesOptions{RewriteServers: true, TrustForwardedHeaders: trust})
+		require.NoError(t, err)
+		return string(rewritten)
+	}
+
+	assert.Equal(t, `{"servers":[{"url":"https://pets.local/v1"}]}`, rewrite("pets.local", "HTTPS, http", true))
+	// The header is only trusted when the options say so.
+	assert.Equal(t, `{"servers":[{"url":"http://pets.local/v1"}]}`, rewrite("pets.local", "https", false))
+	// A forged scheme is ignored.
+	assert.Equal(t, `{"servers":[{"url":"http://pets.local/v1"}]}`, rewrite("pets.local", "javascript", true))
+	assert.Equal(t, `{"servers":[{"url":"http://pets.local/v1"}]}`, rewrite("pets.local", "https://evil.example.com/#", true))
+	// So is a Host which isn't a host, leaving the servers alone.
+	for _, host := range []string{"", "evil.example.com/x", "user@evil.example.com", `evil"example.com`, "evil.example.com#"} {
+		assert.Equal(t, spec, rewrite(host, "", true), host)
+	}
+	assert.Equal(t, `{"servers":[{"url":"http://[::1]:8080/v1"}]}`, rewrite("[::1]:8080", "", true))
+}
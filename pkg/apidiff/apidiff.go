@@ -0,0 +1,262 @@
+// Package apidiff compares the exported Go API of two versions of a generated
+// file, to find the changes which break the code using it, such as a removed
+// type, a field changed from a pointer to a value, or a method added to an
+// interface which the code implements.
+package apidiff
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Change is a change of one exported symbol.
+type Change struct {
+	// Symbol is the name of the changed declaration, e.g. "Pet", or of the
+	// changed field or method, e.g. "Pet.Name" or "ClientInterface.GetPet".
+	Symbol string
+	// Message describes the change, e.g. "removed type".
+	Message string
+	// Breaking is set when code using the old API may not compile with the
+	// new one.
+	Breaking bool
+}
+
+// String returns the change as a line of a report.
+func (c Change) String() string {
+	kind := "compatible"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s: %s: %s", kind, c.Symbol, c.Message)
+}
+
+// Compare returns the changes of the exported API of the Go source file
+// newSrc from oldSrc, sorted by symbol.
+func Compare(oldSrc, newSrc string) ([]Change, error) {
+	oldAPI, err := parseAPI("old.go", oldSrc)
+	if err != nil {
+		return nil, err
+	}
+	newAPI, err := parseAPI("new.go", newSrc)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, name := range slices.Sorted(maps.Keys(oldAPI)) {
+		o := oldAPI[name]
+		n, ok := newAPI[name]
+		if !ok {
+			changes = append(changes, Change{Symbol: name, Message: "removed " + o.kind, Breaking: true})
+			continue
+		}
+		changes = append(changes, compareDecls(name, o, n)...)
+	}
+	for _, name := range slices.Sorted(maps.Keys(newAPI)) {
+		if _, ok := oldAPI[name]; !ok {
+			changes = append(changes, Change{Symbol: name, Message: "added " + newAPI[name].kind})
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+	return changes, nil
+}
+
+// HasBreaking reports whether any of changes is breaking.
+func HasBreaking(changes []Change) bool {
+	return slices.ContainsFunc(changes, func(c Change) bool { return c.Breaking })
+}
+
+// decl is an exported declaration.
+type decl struct {
+	// kind is "type", "func", "method", "const" or "var".
+	kind string
+	// def is the definition: the type of a type, the signature of a func or
+	// method, or the type and value of a const or var.
+	def string
+	// fields are the types of the exported fields of a struct type, and
+	// methods the signatures of the methods of an interface type.
+	fields  map[string]string
+	methods map[string]string
+}
+
+func parseAPI(filename, src string) (map[string]decl, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+
+	api := map[string]decl{}
+	for _, d := range file.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			if d.Recv == nil {
+				api[d.Name.Name] = decl{kind: "func", def: types.ExprString(d.Type)}
+				continue
+			}
+			recv := d.Recv.List[0].Type
+			base := recv
+			if star, ok := base.(*ast.StarExpr); ok {
+				base = star.X
+			}
+			if ident, ok := base.(*ast.Ident); ok && ident.IsExported() {
+				api[ident.Name+"."+d.Name.Name] = decl{
+					kind: "method",
+					def:  "(" + types.ExprString(recv) + ") " + types.ExprString(d.Type),
+				}
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						api[spec.Name.Name] = typeDecl(spec)
+					}
+				case *ast.ValueSpec:
+					kind := "var"
+					if d.Tok == token.CONST {
+						kind = "const"
+					}
+					for i, name := range spec.Names {
+						if !name.IsExported() {
+							continue
+						}
+						var def []string
+						if spec.Type != nil {
+							def = append(def, types.ExprString(spec.Type))
+						}
+						if kind == "const" && i < len(spec.Values) {
+							def = append(def, "= "+types.ExprString(spec.Values[i]))
+						}
+						api[name.Name] = decl{kind: kind, def: strings.Join(def, " ")}
+					}
+				}
+			}
+		}
+	}
+	return api, nil
+}
+
+func typeDecl(spec *ast.TypeSpec) decl {
+	d := decl{kind: "type"}
+	if spec.Assign.IsValid() {
+		d.def = "= " + types.ExprString(spec.Type)
+		return d
+	}
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		d.def = "struct"
+		d.fields = map[string]string{}
+		for _, f := range t.Fields.List {
+			typ := types.ExprString(f.Type)
+			if len(f.Names) == 0 {
+				// An embedded field is named after its type.
+				name := strings.TrimPrefix(typ, "*")
+				if i := strings.LastIndex(name, "."); i >= 0 {
+					name = name[i+1:]
+				}
+				if ast.IsExported(name) {
+					d.fields[name] = typ
+				}
+			}
+			for _, name := range f.Names {
+				if name.IsExported() {
+					d.fields[name.Name] = typ
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		d.def = "interface"
+		d.methods = map[string]string{}
+		for _, m := range t.Methods.List {
+			if len(m.Names) == 0 {
+				// An embedded interface.
+				d.methods[types.ExprString(m.Type)] = "embedded"
+			}
+			for _, name := range m.Names {
+				d.methods[name.Name] = types.ExprString(m.Type)
+			}
+		}
+	default:
+		d.def = types.ExprString(spec.Type)
+	}
+	return d
+}
+
+func compareDecls(name string, o, n decl) []Change {
+	switch {
+	case o.kind != n.kind:
+		return []Change{{Symbol: name, Message: fmt.Sprintf("changed from %s to %s", o.kind, n.kind), Breaking: true}}
+	case o.def != n.def:
+		if o.kind == "const" && strings.SplitN(o.def, "= ", 2)[0] == strings.SplitN(n.def, "= ", 2)[0] {
+			// The same type with another value still compiles.
+			return []Change{{Symbol: name, Message: fmt.Sprintf("value changed from %s to %s", constValue(o.def), constValue(n.def))}}
+		}
+		return []Change{{Symbol: name, Message: fmt.Sprintf("%s changed from %s to %s", defWord(o.kind), describe(o), describe(n)), Breaking: true}}
+	case o.fields != nil:
+		return compareMembers(name, o.fields, n.fields, "field", false)
+	case o.methods != nil:
+		// Any change of an interface breaks its implementations, as a
+		// ServerInterface is implemented by the users of generated code.
+		return compareMembers(name, o.methods, n.methods, "method", true)
+	}
+	return nil
+}
+
+func compareMembers(name string, old, new map[string]string, kind string, addBreaks bool) []Change {
+	var changes []Change
+	for _, member := range slices.Sorted(maps.Keys(old)) {
+		symbol := name + "." + member
+		typ, ok := new[member]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Symbol: symbol, Message: "removed " + kind, Breaking: true})
+		case typ != old[member]:
+			changes = append(changes, Change{
+				Symbol:   symbol,
+				Message:  fmt.Sprintf("%s type changed from %s to %s", kind, old[member], typ),
+				Breaking: true,
+			})
+		}
+	}
+	for _, member := range slices.Sorted(maps.Keys(new)) {
+		if _, ok := old[member]; !ok {
+			changes = append(changes, Change{Symbol: name + "." + member, Message: "added " + kind, Breaking: addBreaks})
+		}
+	}
+	return changes
+}
+
+func defWord(kind string) string {
+	switch kind {
+	case "func", "method":
+		return "signature"
+	case "const", "var":
+		return "definition"
+	}
+	return "type"
+}
+
+func describe(d decl) string {
+	if d.def == "" {
+		return "untyped"
+	}
+	return d.def
+}
+
+func constValue(def string) string {
+	if _, value, ok := strings.Cut(def, "= "); ok {
+		return value
+	}
+	return def
+}
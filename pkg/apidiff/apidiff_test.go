@@ -0,0 +1,114 @@
+package apidiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldSrc = `package api
+
+type PetKind string
+
+const (
+	Cat PetKind = "cat"
+	Dog PetKind = "dog"
+)
+
+type Pet struct {
+	Name *string
+	Kind PetKind
+	Tag  string
+	internal int
+}
+
+type Pets = []Pet
+
+type Owner struct {
+	Id int
+}
+
+type ServerInterface interface {
+	ListPets(limit *int) ([]Pet, error)
+	GetPet(id string) (Pet, error)
+}
+
+func NewListPetsRequest(server string) error { return nil }
+
+func (p Pet) Validate() error { return nil }
+
+func helper() {}
+`
+
+const newSrc = `package api
+
+type PetKind string
+
+const (
+	Cat PetKind = "Cat"
+	Bird PetKind = "bird"
+)
+
+type Pet struct {
+	Name string
+	Kind PetKind
+	Tag  string
+	Age  *int
+}
+
+type Pets []Pet
+
+type Owner = string
+
+type ServerInterface interface {
+	ListPets(limit *int) ([]Pet, error)
+	GetPet(id int) (Pet, error)
+	DeletePet(id int) error
+}
+
+func NewListPetsRequest(server string, limit *int) error { return nil }
+
+func NewDeletePetRequest(server string) error { return nil }
+
+func (p *Pet) Validate() error { return nil }
+
+func helper2() {}
+`
+
+func TestCompare(t *testing.T) {
+	changes, err := Compare(oldSrc, newSrc)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	assert.Equal(t, []string{
+		"compatible: Bird: added const",
+		`compatible: Cat: value changed from "cat" to "Cat"`,
+		"breaking: Dog: removed const",
+		"compatible: NewDeletePetRequest: added func",
+		"breaking: NewListPetsRequest: signature changed from func(server string) error to func(server string, limit *int) error",
+		"breaking: Owner: type changed from struct to = string",
+		"compatible: Pet.Age: added field",
+		"breaking: Pet.Name: field type changed from *string to string",
+		"breaking: Pet.Validate: signature changed from (Pet) func() error to (*Pet) func() error",
+		"breaking: Pets: type changed from = []Pet to []Pet",
+		"breaking: ServerInterface.DeletePet: added method",
+		"breaking: ServerInterface.GetPet: method type changed from func(id string) (Pet, error) to func(id int) (Pet, error)",
+	}, lines)
+	assert.True(t, HasBreaking(changes))
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	changes, err := Compare(oldSrc, oldSrc)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.False(t, HasBreaking(changes))
+}
+
+func TestCompareInvalidSource(t *testing.T) {
+	_, err := Compare(oldSrc, "package api\n\nfunc {")
+	assert.ErrorContains(t, err, "error parsing generated code")
+}
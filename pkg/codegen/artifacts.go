@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Artifacts are what GenerateArtifacts generates: the code, and what it's
+// generated from, for tools which embed the generator, such as language
+// servers or build rules, to use without parsing the code.
+type Artifacts struct {
+	// Files are the generated files: the code first, then those it embeds,
+	// such as the embedded-spec-file.
+	Files []GeneratedFile
+	// Types are the definitions of the generated types, with models.
+	Types []TypeDefinition
+	// Operations are the definitions of the operations of the paths,
+	// webhooks and callbacks.
+	Operations []OperationDefinition
+	// Imports are the import paths of the packages the code uses, sorted.
+	Imports []string
+	// Diagnostics are the warnings of the configuration, and the errors of
+	// the generation.
+	Diagnostics []Diagnostic
+	// Report is the Report of GenerateWithReport.
+	Report *Report
+}
+
+// GeneratedFile is a generated file: the Go code, and the files it embeds.
+type GeneratedFile struct {
+	// Name is the name of the file: for the code, the package name with a
+	// .gen.go suffix, to be written wherever the caller wants, and for the
+	// others, the name the code embeds them by, to be written next to it.
+	Name     string
+	Contents []byte
+}
+
+// DiagnosticSeverity is how severe a Diagnostic is.
+type DiagnosticSeverity string
+
+const (
+	SeverityError   DiagnosticSeverity = "error"
+	SeverityWarning DiagnosticSeverity = "warning"
+)
+
+// Diagnostic is a problem found generating the code.
+type Diagnostic struct {
+	Severity DiagnosticSeverity
+	// Code is the code of a warning, as in ReportWarning.
+	Code    string
+	Message string
+	// Position is where the problem is in the spec, as
+	// SourceError.Position returns it, when it's known.
+	Position string
+}
+
+// GenerateArtifacts is Generate, which returns the generated code as
+// Artifacts. As with GenerateWithReport, the artifacts are returned even when
+// generation fails part way, with its errors as diagnostics, and the code if
+// it failed to be formatted.
+//
+// Together with util.LoadSwaggerFromFS, the code can be generated from a
+// spec in memory, without touching the disk, as long as the configuration
+// sets the package name, and is Hermetic, or has no user templates to read,
+// nor a std-http-server, whose go.mod is searched for.
+func GenerateArtifacts(spec *openapi3.T, opts Configuration) (*Artifacts, error) {
+	rb := newReportBuilder(spec)
+	rb.addWarnings(opts.Warnings())
+	rb.addWarnings(opts.GenerateWarnings())
+	a := &Artifacts{}
+
+	goCode, err := func() (string, error) {
+		generateMu.Lock()
+		defer generateMu.Unlock()
+		globalState.artifacts = a
+		defer func() { globalState.artifacts = nil }()
+		return generateLocked(spec, opts, rb)
+	}()
+	if err == nil {
+		goCode, err = formatCode(goCode, opts)
+	}
+
+	a.Report = rb.finish()
+	for _, w := range a.Report.Warnings {
+		a.Diagnostics = append(a.Diagnostics, Diagnostic{Severity: SeverityWarning, Code: w.Code, Message: w.Message})
+	}
+	if err != nil {
+		a.Diagnostics = append(a.Diagnostics, errorDiagnostics(err)...)
+	}
+	if goCode != "" {
+		// The code comes first, before the files it refers to.
+		a.Files = append([]GeneratedFile{{Name: opts.PackageName + ".gen.go", Contents: []byte(goCode)}}, a.Files...)
+		a.Imports = codeImports(goCode)
+	} else {
+		a.Files = nil
+	}
+	return a, err
+}
+
+// errorDiagnostics returns a diagnostic for each of the errors joined in err.
+func errorDiagnostics(err error) []Diagnostic {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var diagnostics []Diagnostic
+		for _, e := range joined.Unwrap() {
+			diagnostics = append(diagnostics, errorDiagnostics(e)...)
+		}
+		return diagnostics
+	}
+	d := Diagnostic{Severity: SeverityError, Message: err.Error()}
+	var located *SourceError
+	if errors.As(err, &located) {
+		d.Position = located.Position()
+	}
+	return []Diagnostic{d}
+}
+
+// codeImports returns the import paths of goCode, or nil when it doesn't
+// parse.
+func codeImports(goCode string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", goCode, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, spec := range f.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
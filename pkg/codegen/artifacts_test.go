@@ -0,0 +1,182 @@
+package codegen
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestGenerateArtifacts(t *testing.T) {
+	// The spec and the file it refers to are in memory.
+	fsys := fstest.MapFS{
+		"pets.yaml": {Data: []byte(`paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    born:
+                      type: string
+                      format: date-time
+`)},
+	}
+	spec, err := util.LoadSwaggerFromFS(fsys, "api.yaml", []byte(originMainSpec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true},
+	}
+	a, err := GenerateArtifacts(spec, opts)
+	require.NoError(t, err)
+
+	var typeNames []string
+	for _, td := range a.Types {
+		typeNames = append(typeNames, td.TypeName)
+	}
+	assert.Equal(t, []string{"ListPetsParams"}, typeNames)
+	require.Len(t, a.Operations, 1)
+	assert.Equal(t, "ListPets", a.Operations[0].OperationId)
+	assert.Contains(t, a.Imports, "net/http")
+	assert.Contains(t, a.Imports, "time")
+	assert.Empty(t, a.Diagnostics)
+	assert.Len(t, a.Report.Operations, 1)
+
+	require.Len(t, a.Files, 1)
+	assert.Equal(t, "api.gen.go", a.Files[0].Name)
+	spec, err = util.LoadSwaggerFromFS(fsys, "api.yaml", []byte(originMainSpec))
+	require.NoError(t, err)
+	code, err := Generate(spec, opts)
+	require.NoError(t, err)
+	assert.Equal(t, code, string(a.Files[0].Contents))
+}
+
+func TestGenerateArtifactsDiagnostics(t *testing.T) {
+	spec, err := util.LoadSwaggerFromFS(fstest.MapFS{}, "api.yaml", []byte(`openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          x-go-name: "not a name"
+        tag:
+          type: string
+          x-go-name: "nor this"
+`))
+	require.NoError(t, err)
+
+	a, err := GenerateArtifacts(spec, Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{SkipPrune: true},
+	})
+	require.Error(t, err)
+	assert.Empty(t, a.Files)
+
+	require.Len(t, a.Diagnostics, 2)
+	for _, d := range a.Diagnostics {
+		assert.Equal(t, SeverityError, d.Severity)
+		assert.Contains(t, d.Message, "x-go-name")
+	}
+	assert.Equal(t, "api.yaml:9:9", a.Diagnostics[0].Position)
+	assert.Equal(t, "api.yaml:12:9", a.Diagnostics[1].Position)
+}
+
+func TestGenerateArtifactsEmbeddedSpecFile(t *testing.T) {
+	load := func() *openapi3.T {
+		spec, err := util.LoadSwaggerFromFS(fstest.MapFS{}, "api.yaml", []byte(`openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: string
+`))
+		require.NoError(t, err)
+		return spec
+	}
+	opts := Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true, EmbeddedSpec: true},
+		OutputOptions: OutputOptions{SkipPrune: true, EmbeddedSpecFile: "openapi.yaml"},
+	}
+
+	a, err := GenerateArtifacts(load(), opts)
+	require.NoError(t, err)
+	require.Len(t, a.Files, 2)
+	assert.Equal(t, "api.gen.go", a.Files[0].Name)
+	assert.Contains(t, string(a.Files[0].Contents), "//go:embed openapi.yaml\n")
+	assert.NotContains(t, string(a.Files[0].Contents), "swaggerSpec")
+	assert.Contains(t, a.Imports, "embed")
+	assert.Equal(t, "openapi.yaml", a.Files[1].Name)
+	assert.Equal(t, `components:
+  schemas:
+    Pet:
+      type: string
+info:
+  title: Pets
+  version: 1.0.0
+openapi: 3.0.0
+paths: {}
+`, string(a.Files[1].Contents))
+
+	opts.OutputOptions.EmbeddedSpecFile = "openapi.json"
+	a, err = GenerateArtifacts(load(), opts)
+	require.NoError(t, err)
+	require.Len(t, a.Files, 2)
+	assert.Contains(t, string(a.Files[1].Contents), "{\n  \"components\": {\n")
+
+	// Without the file, Generate's code doesn't compile.
+	_, err = Generate(load(), opts)
+	assert.ErrorContains(t, err, "only GenerateArtifacts returns")
+}
+
+func TestGenerateArtifactsPublicSpecFile(t *testing.T) {
+	spec, err := util.LoadSwaggerFromFS(fstest.MapFS{}, "api.yaml", []byte(`openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /internal:
+    get:
+      x-internal: true
+      responses:
+        "204": {description: Done}
+`))
+	require.NoError(t, err)
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, EmbeddedSpec: true},
+		OutputOptions: OutputOptions{
+			EmbeddedSpecFile: "openapi.json",
+			PublicSpec:       &PublicSpecOptions{},
+		},
+	}
+
+	a, err := GenerateArtifacts(spec, opts)
+	require.NoError(t, err)
+	require.Len(t, a.Files, 3)
+	assert.Contains(t, string(a.Files[0].Contents), "//go:embed openapi.public.json\n")
+	assert.Contains(t, string(a.Files[0].Contents), "func GetPublicSpec() (*openapi3.T, error) {")
+	assert.Equal(t, "openapi.json", a.Files[1].Name)
+	assert.Contains(t, string(a.Files[1].Contents), `"/internal"`)
+	assert.Equal(t, "openapi.public.json", a.Files[2].Name)
+	assert.NotContains(t, string(a.Files[2].Contents), `"/internal"`)
+}
@@ -21,7 +21,9 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"go/parser"
 	"go/scanner"
+	"go/token"
 	"io"
 	"io/fs"
 	"maps"
@@ -30,7 +32,9 @@ import (
 	"regexp"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -86,6 +90,12 @@ var globalState struct {
 	// on strict RequestObject structs; identical to the schema generator
 	// except the legacy yaml-tags flag does not apply.
 	paramFieldTagGenerator *structTagGenerator
+	// report collects the Report of GenerateWithReport, and is nil
+	// otherwise.
+	report *reportBuilder
+	// artifacts collects the definitions of GenerateArtifacts, and is nil
+	// otherwise.
+	artifacts *Artifacts
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -163,11 +173,69 @@ func constructImportMapping(importMapping map[string]string) importMap {
 	return result
 }
 
+// generateMu serializes generation, which runs on the package-level
+// globalState and template state.
+var generateMu sync.Mutex
+
 // Generate uses the Go templating engine to generate all of our server wrappers from
 // the descriptions we've built up above from the schema objects.
 // opts defines
+//
+// Generate may be called concurrently, e.g. for several specs at once. The
+// code is generated for one spec at a time, while the formatting of the
+// output, which takes most of the time, runs in parallel.
 func Generate(spec *openapi3.T, opts Configuration) (string, error) {
-	// This is global state
+	return generateFormatted(spec, opts, nil)
+}
+
+// GenerateWithReport is Generate, which also returns a Report of what was
+// generated. The report is returned even when generation fails part way,
+// with what was found until then.
+func GenerateWithReport(spec *openapi3.T, opts Configuration) (string, *Report, error) {
+	rb := newReportBuilder(spec)
+	rb.addWarnings(opts.Warnings())
+	rb.addWarnings(opts.GenerateWarnings())
+	goCode, err := generateFormatted(spec, opts, rb)
+	return goCode, rb.finish(), err
+}
+
+// generateFormatted returns the formatted code for spec, collecting its
+// report in rb, if it's not nil.
+func generateFormatted(spec *openapi3.T, opts Configuration, rb *reportBuilder) (string, error) {
+	goCode, err := generate(spec, opts, rb)
+	if err != nil {
+		return goCode, err
+	}
+	return formatCode(goCode, opts)
+}
+
+// formatCode formats the generated code, unless opts skip it. On failure,
+// it returns the code unformatted, to be inspected.
+func formatCode(goCode string, opts Configuration) (string, error) {
+	// The generation code produces unindented horrors. Use the Go Imports
+	// to make it all pretty.
+	if opts.OutputOptions.SkipFmt {
+		return goCode, nil
+	}
+
+	outBytes, err := imports.Process(opts.PackageName+".go", []byte(goCode), nil)
+	if err != nil {
+		errLine := -1
+		var scanErr scanner.ErrorList
+		if errors.As(err, &scanErr) && scanErr.Len() > 0 {
+			errLine = scanErr[0].Pos.Line
+		}
+		if errLine > 0 {
+			return goCode, fmt.Errorf("error formatting Go code at line %d: %w", errLine, err)
+		}
+		return goCode, fmt.Errorf("error formatting Go code: %w", err)
+	}
+	return string(outBytes), nil
+}
+
+// initGlobalState sets up globalState, and the other package-level state of
+// generation, for spec and opts. The caller must hold generateMu.
+func initGlobalState(spec *openapi3.T, opts Configuration) error {
 	globalState.options = opts
 	globalState.spec = spec
 	globalState.is31 = spec.IsOpenAPI31OrLater()
@@ -184,36 +252,19 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	schemaTagGen, err := newStructTagGenerator(
 		defaultStructTagsConfig(opts.OutputOptions.EnableYamlTags).Merge(opts.OutputOptions.StructTags))
 	if err != nil {
-		return "", fmt.Errorf("error in output-options.struct-tags: %w", err)
+		return fmt.Errorf("error in output-options.struct-tags: %w", err)
 	}
 	globalState.schemaFieldTagGenerator = schemaTagGen
 	paramTagGen, err := newStructTagGenerator(
 		defaultStructTagsConfig(false).Merge(opts.OutputOptions.StructTags))
 	if err != nil {
-		return "", fmt.Errorf("error in output-options.struct-tags: %w", err)
+		return fmt.Errorf("error in output-options.struct-tags: %w", err)
 	}
 	globalState.paramFieldTagGenerator = paramTagGen
 
-	filterOperationsByTag(spec, opts)
-	filterOperationsByOperationID(spec, opts)
-	if !opts.OutputOptions.SkipPrune {
-		pruneUnusedComponents(spec)
-	}
-
-	// Reject spec values that cannot be represented in the generated Go source
-	// (names, media types, enum values, extension hints containing quotes,
-	// backticks, or control characters). Run after filtering/pruning so only
-	// values that will actually be emitted are considered.
-	if err := ValidateSpec(spec); err != nil {
-		return "", err
-	}
-	if opts.Generate.StdHTTPServer {
-		if err := ValidateStdHTTPPaths(spec); err != nil {
-			return "", err
-		}
-	}
-
-	// if we are provided an override for the response type suffix update it
+	// if we are provided an override for the response type suffix update it,
+	// else reset it, in case an earlier Generate set another
+	responseTypeSuffix = defaultResponseTypeSuffix
 	if opts.OutputOptions.ResponseTypeSuffix != "" {
 		responseTypeSuffix = opts.OutputOptions.ResponseTypeSuffix
 	}
@@ -225,12 +276,12 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	nameNormalizerFunction := NameNormalizerFunction(opts.OutputOptions.NameNormalizer)
 	nameNormalizer = NameNormalizers[nameNormalizerFunction]
 	if nameNormalizer == nil {
-		return "", fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
+		return fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
 			opts.OutputOptions.NameNormalizer, NameNormalizers.Options())
 	}
 
 	if nameNormalizerFunction != NameNormalizerFunctionToCamelCaseWithInitialisms && len(opts.OutputOptions.AdditionalInitialisms) > 0 {
-		return "", fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
+		return fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
 	}
 
 	globalState.initialismsMap = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
@@ -239,7 +290,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// Validate() already caught syntax errors, but surface any regression here too.
 	streamingRegexes, err := compileStreamingContentTypes(opts.OutputOptions.StreamingContentTypes)
 	if err != nil {
-		return "", err
+		return err
 	}
 	globalState.streamingContentTypeRegexes = streamingRegexes
 
@@ -247,15 +298,55 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// syntax errors, but surface any regression here too.
 	contentTypeTags, err := compileContentTypeNameTags(opts.OutputOptions.ContentTypes)
 	if err != nil {
-		return "", err
+		return err
 	}
 	globalState.contentTypeNameTags = contentTypeTags
 
+	return nil
+}
+
+// generate returns the unformatted code for spec; see Generate.
+func generate(spec *openapi3.T, opts Configuration, rb *reportBuilder) (string, error) {
+	generateMu.Lock()
+	defer generateMu.Unlock()
+	return generateLocked(spec, opts, rb)
+}
+
+// generateLocked is generate, for a caller holding generateMu.
+func generateLocked(spec *openapi3.T, opts Configuration, rb *reportBuilder) (string, error) {
+	// This is global state
+	globalState.report = rb
+	defer func() { globalState.report = nil }()
+	if err := initGlobalState(spec, opts); err != nil {
+		return "", err
+	}
+
+	filterOperationsByTag(spec, opts)
+	filterOperationsByOperationID(spec, opts)
+	if !opts.OutputOptions.SkipPrune {
+		globalState.report.addPrunedComponents(pruneUnusedComponents(spec))
+	}
+
+	// Reject spec values that cannot be represented in the generated Go source
+	// (names, media types, enum values, extension hints containing quotes,
+	// backticks, or control characters). Run after filtering/pruning so only
+	// values that will actually be emitted are considered.
+	if err := ValidateSpec(spec); err != nil {
+		return "", err
+	}
+	if opts.Generate.StdHTTPServer {
+		if err := ValidateStdHTTPPaths(spec); err != nil {
+			return "", err
+		}
+	}
+
 	// Multi-pass name resolution: gather all schemas, then resolve names globally.
 	// Only enabled when resolve-type-name-collisions is set.
 	if opts.OutputOptions.ResolveTypeNameCollisions {
 		gathered := GatherSchemas(spec, opts)
-		globalState.resolvedNames = ResolveNames(gathered)
+		resolved := resolveNames(gathered)
+		globalState.resolvedNames = resolvedNameMap(resolved)
+		globalState.report.addNameResolutions(resolved)
 		// Build a separate operationID -> wrapper name lookup for genResponseTypeName.
 		// Keys must use the normalized operationID (via nameNormalizer) because
 		// OperationDefinition.OperationId is normalized before templates run.
@@ -275,19 +366,24 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 
 	// This creates the golang templates text package
 	TemplateFunctions["opts"] = func() Configuration { return globalState.options }
-	t := template.New("oapi-codegen").Funcs(TemplateFunctions)
-	// This parses all of our own template files into the template object
-	// above
-	err = LoadTemplates(templates, t)
+	// This is a copy of all of our own template files, parsed once
+	t, err := cloneBuiltinTemplates()
 	if err != nil {
 		return "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
 	}
 
 	// load user-provided templates. Will Override built-in versions.
+	var deniedTemplates []string
 	for name, template := range opts.OutputOptions.UserTemplates {
 		utpl := t.New(name)
 
-		txt, err := GetUserTemplateText(template)
+		txt, err := userTemplateText(opts, template)
+		var sandboxErr *util.SandboxError
+		if errors.As(err, &sandboxErr) {
+			// All that's outside of the sandbox is reported at once.
+			deniedTemplates = append(deniedTemplates, sandboxErr.Accesses...)
+			continue
+		}
 		if err != nil {
 			return "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
 		}
@@ -297,6 +393,10 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 			return "", fmt.Errorf("error parsing user-provided template %q: %w", name, err)
 		}
 	}
+	if len(deniedTemplates) > 0 {
+		slices.Sort(deniedTemplates)
+		return "", fmt.Errorf("error loading user-provided templates: %w", &util.SandboxError{Accesses: deniedTemplates})
+	}
 
 	// Build per-framework clones of the base tree, layering each framework's
 	// hook overrides over the shared server-*.tmpl skeletons. Must happen after
@@ -334,6 +434,12 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		return "", fmt.Errorf("error creating callback operation definitions: %w", err)
 	}
 	allOps := append(append(append([]OperationDefinition{}, ops...), webhookOps...), callbackOps...)
+	for _, op := range allOps {
+		globalState.report.addOperation(op, opts.Generate.Models)
+	}
+	if a := globalState.artifacts; a != nil {
+		a.Operations = allOps
+	}
 
 	xGoTypeImports, err := OperationImports(allOps)
 	if err != nil {
@@ -347,6 +453,9 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// compatibility (changing it would alter the symbols emitted by
 	// `models: true`-alone configs and break downstream code in the wild).
 	var typeDefinitions, constantDefinitions string
+	// typeNames are the names of the types generated, which other
+	// declarations mustn't take.
+	typeNames := map[string]bool{}
 	if opts.Generate.Models {
 		componentTypes, err := collectComponentTypes(t, spec, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
@@ -363,6 +472,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("error collecting operation types: %w", err)
 		}
+		globalState.report.addTypes(opTypes)
 		opDecls, err := GenerateTypesForOperations(t, allOps)
 		if err != nil {
 			return "", fmt.Errorf("error generating Go types for operations: %w", err)
@@ -383,13 +493,20 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		// marshalers) scans the union of all declared types so methods are
 		// emitted for inline types living inside operations too.
 		allEmitted := slices.Concat(componentTypes, opTypes)
-		enumsOut, allOfOut, unionOut, unionAndAdditionalOut, err := renderBoilerplate(t, allEmitted)
+		for _, td := range allEmitted {
+			typeNames[td.TypeName] = true
+		}
+		if a := globalState.artifacts; a != nil {
+			a.Types = append(a.Types, allEmitted...)
+		}
+		enumsOut, allOfOut, unionOut, unionAndAdditionalOut, sealedOut, defaultsOut, fixedOut, err := renderBoilerplate(t, allEmitted)
 		if err != nil {
 			return "", err
 		}
 		// Preserve historical concatenation order:
-		// enums, component decls, op decls, allOf, union, union+additional.
-		typeDefinitions = strings.Join([]string{enumsOut, componentDecls, opDecls, allOfOut, unionOut, unionAndAdditionalOut}, "")
+		// enums, component decls, op decls, allOf, union, union+additional,
+		// followed by sealed unions, defaults and fixed values.
+		typeDefinitions = strings.Join([]string{enumsOut, componentDecls, opDecls, allOfOut, unionOut, unionAndAdditionalOut, sealedOut, defaultsOut, fixedOut}, "")
 	}
 
 	var serverURLsDefinitions string
@@ -404,6 +521,12 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("error generating Go types for server URL variables: %w", err)
 		}
+		if a := globalState.artifacts; a != nil {
+			a.Types = append(a.Types, serverURLEnumTypes...)
+		}
+		for _, td := range serverURLEnumTypes {
+			typeNames[td.TypeName] = true
+		}
 		serverURLEnumTypeDecls, err := GenerateTypes(t, serverURLEnumTypes)
 		if err != nil {
 			return "", fmt.Errorf("error generating type declarations for server URL variables: %w", err)
@@ -493,6 +616,14 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	}
 
+	var specRoutesOut string
+	if opts.Generate.SpecRoutes {
+		specRoutesOut, err = GenerateSpecRoutes(t, serverTemplates, opts)
+		if err != nil {
+			return "", fmt.Errorf("error generating spec routes: %w", err)
+		}
+	}
+
 	var strictServerOut string
 	if opts.Generate.Strict {
 		var responses []ResponseDefinition
@@ -519,6 +650,21 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("error generating client: %w", err)
 		}
+		// Operations only require security schemes which are defined.
+		if spec.Components != nil {
+			var securityOut string
+			securityOut, err = GenerateClientSecurity(t, spec.Components.SecuritySchemes, ops, typeNames)
+			if err != nil {
+				return "", fmt.Errorf("error generating client security: %w", err)
+			}
+			clientOut += securityOut
+		}
+		var rateLimitOut string
+		rateLimitOut, err = GenerateClientRateLimit(t, ops)
+		if err != nil {
+			return "", fmt.Errorf("error generating client rate limit: %w", err)
+		}
+		clientOut += rateLimitOut
 	}
 
 	var clientWithResponsesOut string
@@ -722,9 +868,18 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 
 	var inlinedSpec string
 	if opts.Generate.EmbeddedSpec {
-		inlinedSpec, err = GenerateInlinedSpec(t, globalState.importMapping, spec)
+		// Only GenerateArtifacts can return the spec file, without which
+		// the code doesn't compile.
+		if opts.OutputOptions.EmbeddedSpecFile != "" && globalState.artifacts == nil {
+			return "", errors.New("`output-options.embedded-spec-file` writes the spec as a file, which only GenerateArtifacts returns")
+		}
+		var files []GeneratedFile
+		inlinedSpec, files, err = generateEmbeddedSpec(t, globalState.importMapping, spec, opts.OutputOptions)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return "", fmt.Errorf("error generating embedded spec: %w", err)
+		}
+		if globalState.artifacts != nil {
+			globalState.artifacts.Files = append(globalState.artifacts.Files, files...)
 		}
 	}
 
@@ -970,33 +1125,20 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	}
 
+	if opts.Generate.SpecRoutes {
+		_, err = w.WriteString(specRoutesOut)
+		if err != nil {
+			return "", fmt.Errorf("error writing spec routes: %w", err)
+		}
+	}
+
 	err = w.Flush()
 	if err != nil {
 		return "", fmt.Errorf("error flushing output buffer: %w", err)
 	}
 
 	// remove any byte-order-marks which break Go-Code
-	goCode := SanitizeCode(buf.String())
-
-	// The generation code produces unindented horrors. Use the Go Imports
-	// to make it all pretty.
-	if opts.OutputOptions.SkipFmt {
-		return goCode, nil
-	}
-
-	outBytes, err := imports.Process(opts.PackageName+".go", []byte(goCode), nil)
-	if err != nil {
-		errLine := -1
-		var scanErr scanner.ErrorList
-		if errors.As(err, &scanErr) && scanErr.Len() > 0 {
-			errLine = scanErr[0].Pos.Line
-		}
-		if errLine > 0 {
-			return goCode, fmt.Errorf("error formatting Go code at line %d: %w", errLine, err)
-		}
-		return goCode, fmt.Errorf("error formatting Go code: %w", err)
-	}
-	return string(outBytes), nil
+	return SanitizeCode(buf.String()), nil
 }
 
 // collectComponentTypes returns the TypeDefinitions collected from
@@ -1062,28 +1204,40 @@ func collectOperationTypes(ops []OperationDefinition) ([]TypeDefinition, error)
 	return out, nil
 }
 
-// renderBoilerplate runs the enum, additionalProperties, union, and
-// union+additionalProperties passes over the union of all emitted types.
-// These passes are "inner" — they emit methods/constants subordinate to
-// whichever outer types were declared.
-func renderBoilerplate(t *template.Template, allEmitted []TypeDefinition) (enumsOut, allOfOut, unionOut, unionAndAdditionalOut string, err error) {
+// renderBoilerplate runs the enum, additionalProperties, union,
+// union+additionalProperties, sealed union, defaults and fixed value passes
+// over the union of all emitted types. These passes are "inner" — they emit
+// methods/constants subordinate to whichever outer types were declared.
+func renderBoilerplate(t *template.Template, allEmitted []TypeDefinition) (enumsOut, allOfOut, unionOut, unionAndAdditionalOut, sealedOut, defaultsOut, fixedOut string, err error) {
 	enumsOut, err = GenerateEnums(t, allEmitted)
 	if err != nil {
-		return "", "", "", "", fmt.Errorf("error generating code for type enums: %w", err)
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating code for type enums: %w", err)
 	}
 	allOfOut, err = GenerateAdditionalPropertyBoilerplate(t, allEmitted)
 	if err != nil {
-		return "", "", "", "", fmt.Errorf("error generating allOf boilerplate: %w", err)
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating allOf boilerplate: %w", err)
 	}
 	unionOut, err = GenerateUnionBoilerplate(t, allEmitted)
 	if err != nil {
-		return "", "", "", "", fmt.Errorf("error generating union boilerplate: %w", err)
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating union boilerplate: %w", err)
 	}
 	unionAndAdditionalOut, err = GenerateUnionAndAdditionalProopertiesBoilerplate(t, allEmitted)
 	if err != nil {
-		return "", "", "", "", fmt.Errorf("error generating boilerplate for union types with additionalProperties: %w", err)
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating boilerplate for union types with additionalProperties: %w", err)
+	}
+	sealedOut, err = GenerateSealedUnionBoilerplate(t, allEmitted)
+	if err != nil {
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating sealed union boilerplate: %w", err)
+	}
+	defaultsOut, err = GenerateDefaultsBoilerplate(t, allEmitted)
+	if err != nil {
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating defaults boilerplate: %w", err)
 	}
-	return enumsOut, allOfOut, unionOut, unionAndAdditionalOut, nil
+	fixedOut, err = GenerateFixedValueBoilerplate(t, allEmitted)
+	if err != nil {
+		return "", "", "", "", "", "", "", fmt.Errorf("error generating fixed value boilerplate: %w", err)
+	}
+	return enumsOut, allOfOut, unionOut, unionAndAdditionalOut, sealedOut, defaultsOut, fixedOut, nil
 }
 
 // GenerateConstants generates operation ids, context keys, paths, etc. to be exported as constants
@@ -1183,8 +1337,10 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 			TypeName: goTypeName,
 			Schema:   goSchema,
 		})
+		globalState.report.addType(goTypeName, joinPointer("#", "components", "schemas", schemaName))
 
 		types = append(types, goSchema.AdditionalTypes...)
+		globalState.report.addTypes(goSchema.AdditionalTypes)
 	}
 	return types, nil
 }
@@ -1198,7 +1354,7 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 
 		goType, err := paramToGoType(paramOrRef.Value, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err)
+			return nil, withOrigin(paramOrRef.Value.Origin, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err))
 		}
 
 		goTypeName, err := renameParameter(paramName, paramOrRef)
@@ -1226,6 +1382,7 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 		}
 
 		types = append(types, typeDef)
+		globalState.report.addType(typeDef.TypeName, joinPointer("#", "components", "parameters", paramName))
 	}
 	return types, nil
 }
@@ -1248,6 +1405,7 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 		for _, mediaType := range SortedMapKeys {
 			response := response.Content[mediaType]
 			if !util.IsMediaTypeJson(mediaType) {
+				globalState.report.addSkippedMediaType(joinPointer("#", "components", "responses", responseName, "content", mediaType), mediaType)
 				continue
 			}
 
@@ -1301,6 +1459,8 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 
 			types = append(types, typeDef)
 			types = append(types, goType.AdditionalTypes...)
+			globalState.report.addType(typeDef.TypeName, joinPointer("#", "components", "responses", responseName, "content", mediaType, "schema"))
+			globalState.report.addTypes(goType.AdditionalTypes)
 		}
 	}
 	return types, nil
@@ -1320,6 +1480,7 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 		for _, mediaType := range SortedMapKeys(response.Content) {
 			body := response.Content[mediaType]
 			if !util.IsMediaTypeJson(mediaType) {
+				globalState.report.addSkippedMediaType(joinPointer("#", "components", "requestBodies", requestBodyName, "content", mediaType), mediaType)
 				continue
 			}
 
@@ -1353,6 +1514,8 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 			}
 			types = append(types, typeDef)
 			types = append(types, goType.AdditionalTypes...)
+			globalState.report.addType(typeDef.TypeName, joinPointer("#", "components", "requestBodies", requestBodyName, "content", mediaType, "schema"))
+			globalState.report.addTypes(goType.AdditionalTypes)
 		}
 	}
 	return types, nil
@@ -1383,6 +1546,7 @@ func GenerateTypesForSecuritySchemes(t *template.Template, schemes map[string]*o
 			TypeName: goTypeName,
 			Schema:   goType,
 		})
+		globalState.report.addType(goTypeName, joinPointer("#", "components", "securitySchemes", schemeName))
 	}
 
 	return types, nil
@@ -1653,6 +1817,32 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		RouterImports:     globalState.options.Generate.RouterImports(),
 	}
 
+	// The template imports packages of its own, which an external import,
+	// such as the one of a type mapping, mustn't import again.
+	context.ExternalImports = nil
+	out, err := GenerateTemplates([]string{"imports.tmpl"}, t, context)
+	if err != nil || len(externalImports) == 0 {
+		return out, err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "", out, parser.ImportsOnly)
+	if err != nil {
+		return "", fmt.Errorf("error parsing imports: %w", err)
+	}
+	imported := make(map[string]bool, len(file.Imports))
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return "", fmt.Errorf("error parsing import %s: %w", spec.Path.Value, err)
+		}
+		imprt := goImport{Path: path}
+		if spec.Name != nil {
+			imprt.Name = spec.Name.Name
+		}
+		imported[imprt.String()] = true
+	}
+	context.ExternalImports = slices.DeleteFunc(slices.Clone(externalImports), func(imprt string) bool {
+		return imported[imprt]
+	})
 	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
 }
 
@@ -1735,6 +1925,152 @@ func GenerateUnionAndAdditionalProopertiesBoilerplate(t *template.Template, type
 	return GenerateTemplates([]string{"union-and-additional-properties.tmpl"}, t, context)
 }
 
+// GenerateSealedUnionBoilerplate generates the marker methods and JSON
+// functions of sealed unions, forwarding functions for aliases of them, and
+// JSON methods for the structs, slices and maps which hold them.
+func GenerateSealedUnionBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var unions, aliases, holders []TypeDefinition
+	seen := map[string]bool{}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		switch {
+		case td.Schema.SealedUnion != nil && !td.Schema.IsRef():
+			unions = append(unions, td)
+		case td.IsAlias():
+			if td.Schema.SealedUnionRef {
+				aliases = append(aliases, td)
+			}
+		case isSealedUnionHolder(td.Schema):
+			holders = append(holders, td)
+		}
+	}
+
+	if len(unions) == 0 && len(aliases) == 0 && len(holders) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Unions  []TypeDefinition
+		Aliases []TypeDefinition
+		Holders []TypeDefinition
+	}{
+		Unions:  unions,
+		Aliases: aliases,
+		Holders: holders,
+	}
+
+	return GenerateTemplates([]string{"sealed-union.tmpl"}, t, context)
+}
+
+// isSealedUnionHolder reports whether a defined type needs JSON methods to
+// decode and encode the sealed unions it holds: a slice or map of one, or a
+// plain struct with such fields. Structs with additionalProperties or union
+// members already have JSON methods, which handle their sealed union fields.
+func isSealedUnionHolder(s Schema) bool {
+	if s.IsRef() {
+		return false
+	}
+	if codec := s.SealedUnionCodec(); codec != nil {
+		return codec.Suffix != ""
+	}
+	return strings.HasPrefix(s.GoType, "struct") &&
+		!s.HasAdditionalProperties && len(s.UnionElements) == 0 &&
+		len(s.SealedUnionFields()) != 0
+}
+
+// GenerateDefaultsBoilerplate generates ApplyDefaults methods for the struct
+// types with properties that have a default, or that hold values of such
+// types, when the output-options.apply-defaults option is set.
+func GenerateDefaultsBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	if !globalState.options.OutputOptions.ApplyDefaults {
+		return "", nil
+	}
+
+	var structs []TypeDefinition
+	seen := map[string]bool{}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		if !td.IsAlias() && strings.HasPrefix(td.Schema.GoType, "struct") {
+			structs = append(structs, td)
+		}
+	}
+
+	// A struct has ApplyDefaults when one of its properties has a default,
+	// or holds a struct which has ApplyDefaults, so keep going until no more
+	// are found.
+	hasDefaults := map[string]bool{}
+	for found := true; found; {
+		found = false
+		for _, td := range structs {
+			if !hasDefaults[td.TypeName] && len(td.Schema.DefaultedFields(func(typeName string) bool { return hasDefaults[typeName] })) != 0 {
+				hasDefaults[td.TypeName] = true
+				found = true
+			}
+		}
+	}
+
+	type defaultsType struct {
+		TypeName string
+		Fields   []DefaultedField
+	}
+	var types []defaultsType
+	for _, td := range structs {
+		if hasDefaults[td.TypeName] {
+			types = append(types, defaultsType{
+				TypeName: td.TypeName,
+				Fields:   td.Schema.DefaultedFields(func(typeName string) bool { return hasDefaults[typeName] }),
+			})
+		}
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []defaultsType
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"defaults.tmpl"}, t, context)
+}
+
+// GenerateFixedValueBoilerplate generates the Value and JSON methods of the
+// zero-size types declared for 3.1 `const` schemas when the
+// output-options.fixed-const-fields option is set.
+func GenerateFixedValueBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+	seen := map[string]bool{}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		if td.Schema.FixedValue != nil && !td.IsAlias() {
+			filteredTypes = append(filteredTypes, td)
+		}
+	}
+
+	if len(filteredTypes) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+
+	return GenerateTemplates([]string{"fixed-value.tmpl"}, t, context)
+}
+
 // SanitizeCode runs sanitizers across the generated Go code to ensure the
 // generated code will be able to compile.
 func SanitizeCode(goCode string) string {
@@ -1743,6 +2079,17 @@ func SanitizeCode(goCode string) string {
 	return strings.ReplaceAll(goCode, "\uFEFF", "")
 }
 
+// userTemplateText returns the text of a user template, as
+// GetUserTemplateText does, but in hermetic mode, reads a template given by
+// path from the hermetic files.
+func userTemplateText(opts Configuration, template string) (string, error) {
+	if opts.Hermetic == nil || strings.Contains(template, "\n") {
+		return GetUserTemplateText(template)
+	}
+	data, err := opts.Hermetic.Sandbox().ReadFile(template)
+	return string(data), err
+}
+
 // GetUserTemplateText attempts to retrieve the template text from a passed in URL or file
 // path when inputData is more than one line.
 // This function will attempt to load a file first, and if it fails, will try to get the
@@ -1795,6 +2142,31 @@ func GetUserTemplateText(inputData string) (template string, err error) {
 	return string(data), nil
 }
 
+// builtinTemplates holds our own template files, parsed on first use.
+var builtinTemplates struct {
+	once sync.Once
+	t    *template.Template
+	err  error
+}
+
+// cloneBuiltinTemplates returns a copy of our own template files, which are
+// only parsed once for all the calls to Generate.
+func cloneBuiltinTemplates() (*template.Template, error) {
+	builtinTemplates.once.Do(func() {
+		t := template.New("oapi-codegen").Funcs(TemplateFunctions)
+		builtinTemplates.t, builtinTemplates.err = t, LoadTemplates(templates, t)
+	})
+	if builtinTemplates.err != nil {
+		return nil, builtinTemplates.err
+	}
+	t, err := builtinTemplates.t.Clone()
+	if err != nil {
+		return nil, err
+	}
+	// Pick up any functions added to TemplateFunctions since the parse.
+	return t.Funcs(TemplateFunctions), nil
+}
+
 // LoadTemplates loads all of our template files into a text/template. The
 // path of template is relative to the templates directory.
 func LoadTemplates(src embed.FS, t *template.Template) error {
@@ -1978,6 +2350,17 @@ func GoSchemaImports(schemas ...*openapi3.SchemaRef) (map[string]goImport, error
 		}
 		schemaVal := sref.Value
 
+		// Types from the type mapping may need an import too, such as
+		// those of the numeric presets, including as map values.
+		for _, v := range []*openapi3.SchemaRef{sref, schemaVal.AdditionalProperties.Schema} {
+			if v == nil || v.Value == nil {
+				continue
+			}
+			if gi := globalState.typeMapping.importFor(v.Value); gi != nil {
+				res[gi.String()] = *gi
+			}
+		}
+
 		t := schemaPrimaryType(schemaVal.Type)
 		if t.Slice() == nil || t.Is("object") {
 			for _, v := range schemaVal.Properties {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -21,6 +22,74 @@ const (
 	remoteRefImport = `github.com/oapi-codegen/oapi-codegen/v2/examples/petstore-expanded`
 )
 
+// TestGenerateConcurrently verifies that concurrent calls of Generate, with
+// different options, produce the same code as sequential ones.
+func TestGenerateConcurrently(t *testing.T) {
+	configs := []Configuration{
+		{PackageName: "a", Generate: GenerateOptions{Models: true, Client: true}},
+		{PackageName: "b", Generate: GenerateOptions{Models: true, Client: true},
+			OutputOptions: OutputOptions{ResponseTypeSuffix: "Result"}},
+		{PackageName: "c", Generate: GenerateOptions{Models: true, Client: true}},
+	}
+	const spec = `
+openapi: "3.0.0"
+info: {title: Things, version: 1.0.0}
+paths:
+  /things/{name}:
+    get:
+      operationId: getThing
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+      responses:
+        "200":
+          description: The thing
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Thing"
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        name: {type: string}
+`
+	generate := func(opts Configuration) (string, error) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		if err != nil {
+			return "", err
+		}
+		return Generate(swagger, opts)
+	}
+
+	want := make([]string, len(configs))
+	for i, opts := range configs {
+		code, err := generate(opts)
+		require.NoError(t, err)
+		want[i] = code
+	}
+	// The response type suffix of b doesn't leak into c.
+	assert.Contains(t, want[0], "type GetThingResponse struct {")
+	assert.Contains(t, want[1], "type GetThingResult struct {")
+	assert.Contains(t, want[2], "type GetThingResponse struct {")
+
+	got := make([]string, len(configs))
+	errs := make([]error, len(configs))
+	var wg sync.WaitGroup
+	for i, opts := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i], errs[i] = generate(opts)
+		}()
+	}
+	wg.Wait()
+	for i := range configs {
+		require.NoError(t, errs[i])
+		assert.Equal(t, want[i], got[i], configs[i].PackageName)
+	}
+}
+
 func TestExampleOpenAPICodeGeneration(t *testing.T) {
 
 	// Input vars for code generation:
@@ -519,6 +588,41 @@ func TestBodylessResponseWithDefaultCatchAll(t *testing.T) {
 	assert.Greater(t, strictDefaultIdx, widgetRangeIdx, "bodyless 2XX guard must appear before strict default catch-all")
 }
 
+// TestClientSecurityOption verifies that the client's WithSecurity option
+// is only generated with the client-security output option, and that its
+// types aren't named as generated schema types are.
+func TestClientSecurityOption(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/client-security-collision.yaml")
+	require.NoError(t, err)
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "WithSecurity")
+	assert.Contains(t, code, "type BearerCredentials struct")
+
+	opts.OutputOptions.ClientSecurity = true
+	_, err = Generate(swagger, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `security scheme "bearer" are named BearerCredentials, as a generated type is`)
+
+	// Generated into their own package, the models don't collide with it.
+	opts.Generate.Models = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func WithSecurity(credentials ...SecurityCredentials) ClientOption")
+	assert.Contains(t, code, "type BearerCredentials RequestEditorFn")
+}
+
 func indexOf(s, substr string) int {
 	for i := 0; i <= len(s)-len(substr); i++ {
 		if s[i:i+len(substr)] == substr {
@@ -3,10 +3,13 @@ package codegen
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 )
 
 // defaultStreamingContentTypes are the regex patterns matched against
@@ -40,6 +43,24 @@ type Configuration struct {
 	// NoVCSVersionOverride allows overriding the version of the application for cases where no Version Control System (VCS) is available when building, for instance when using a Nix derivation.
 	// See documentation for how to use it in examples/no-vcs-version-override/README.md
 	NoVCSVersionOverride *string `yaml:"-"`
+	// Hermetic, if set, generates the code as a hermetic build, such as
+	// Bazel's, needs: only the files it lists are read, nothing is fetched
+	// from the network, and no go.mod is searched for.
+	Hermetic *HermeticOptions `yaml:"hermetic,omitempty"`
+}
+
+// HermeticOptions are the options of hermetic generation.
+type HermeticOptions struct {
+	// Files maps the files the spec refers to, by their paths relative to
+	// the directory of the spec, and the user templates given by path, to
+	// the paths they're read from. The spec, and the overlay, are read from
+	// where they're given.
+	Files map[string]string `yaml:"files,omitempty"`
+}
+
+// Sandbox returns the sandbox the spec is loaded in.
+func (h *HermeticOptions) Sandbox() *util.Sandbox {
+	return &util.Sandbox{Files: h.Files}
 }
 
 // Validate checks whether Configuration represent a valid configuration
@@ -80,6 +101,9 @@ func (o Configuration) Validate() error {
 	if nServers > 1 {
 		return errors.New("only one server type is supported at a time")
 	}
+	if o.Generate.SpecRoutes && (nServers == 0 || !o.Generate.EmbeddedSpec) {
+		return errors.New("`generate.spec-routes` requires `embedded-spec` and a server")
+	}
 
 	var errs []error
 	if problems := o.Generate.Validate(); problems != nil {
@@ -100,6 +124,13 @@ func (o Configuration) Validate() error {
 		}
 	}
 
+	if o.OutputOptions.EmbeddedSpecFile != "" && !o.Generate.EmbeddedSpec {
+		errs = append(errs, errors.New("`output-options` configuration for embedded-spec-file was incorrect: it requires `generate.embedded-spec`"))
+	}
+	if o.OutputOptions.PublicSpec != nil && !o.Generate.EmbeddedSpec {
+		errs = append(errs, errors.New("`output-options` configuration for public-spec was incorrect: it requires `generate.embedded-spec`"))
+	}
+
 	// import-mapping keys are the paths of $ref'd documents (a relative
 	// file path or URL). A JSON pointer key can never match anything —
 	// references within the same document always resolve to the package
@@ -188,6 +219,10 @@ type GenerateOptions struct {
 	EmbeddedSpec bool `yaml:"embedded-spec,omitempty"`
 	// ServerURLs generates types for the `Server` definitions' URLs, instead of needing to provide your own values
 	ServerURLs bool `yaml:"server-urls,omitempty"`
+	// SpecRoutes generates RegisterSpecRoutes for the server, serving the
+	// embedded spec as JSON and YAML, and an HTML page documenting it. It
+	// requires EmbeddedSpec, and a server.
+	SpecRoutes bool `yaml:"spec-routes,omitempty"`
 }
 
 // RouterImports returns the framework-specific and strict middleware imports
@@ -208,8 +243,14 @@ func (g GenerateOptions) RouterImports() []AdditionalImport {
 		imports = append(imports, AdditionalImport{Package: "github.com/gorilla/mux"})
 	case g.FiberServer:
 		imports = append(imports, AdditionalImport{Package: "github.com/gofiber/fiber/v2"})
+		if g.SpecRoutes {
+			imports = append(imports, AdditionalImport{Package: "github.com/gofiber/fiber/v2/middleware/adaptor"})
+		}
 	case g.FiberV3Server:
 		imports = append(imports, AdditionalImport{Package: "github.com/gofiber/fiber/v3"})
+		if g.SpecRoutes {
+			imports = append(imports, AdditionalImport{Package: "github.com/gofiber/fiber/v3/middleware/adaptor"})
+		}
 	case g.IrisServer:
 		imports = append(imports, AdditionalImport{Package: "github.com/kataras/iris/v12"})
 		imports = append(imports, AdditionalImport{Package: "github.com/kataras/iris/v12/core/router"})
@@ -234,9 +275,19 @@ func (oo GenerateOptions) Validate() map[string]string {
 }
 
 func (oo GenerateOptions) Warnings() map[string]string {
+	return oo.warnings(true)
+}
+
+// GenerateWarnings returns the warnings of o.Generate, but for those found
+// searching for the go.mod of the output in hermetic mode.
+func (o Configuration) GenerateWarnings() map[string]string {
+	return o.Generate.warnings(o.Hermetic == nil)
+}
+
+func (oo GenerateOptions) warnings(findGoMod bool) map[string]string {
 	warnings := make(map[string]string)
 
-	if oo.StdHTTPServer {
+	if oo.StdHTTPServer && findGoMod {
 		if warning := oo.warningForStdHTTP(); warning != "" {
 			warnings["std-http-server"] = warning
 		}
@@ -483,6 +534,15 @@ type OutputOptions struct {
 	// SkipClientResponseContentType disables the generation of a `ContentType()` method on response objects for `ClientWithResponses`, which is otherwise generated by default.
 	SkipClientResponseContentType bool `yaml:"skip-client-response-content-type,omitempty"`
 
+	// ClientSecurity enables the generation of the client's `WithSecurity` option, and a `<Scheme>Credentials` type for each security scheme, which attach credentials to the requests of the operations requiring their scheme
+	ClientSecurity bool `yaml:"client-security,omitempty"`
+
+	// ClientRateLimit enables the generation of the client's rate limiting options: `WithRateLimit`, `WithOperationRateLimit`, `WithMaxInFlight` and `WithRateLimitBackoff`
+	ClientRateLimit bool `yaml:"client-rate-limit,omitempty"`
+
+	// DocCommentOrigins adds the file, line and column of the spec element each type and operation is generated from to its doc comment, e.g. `// Source: api/pets.yaml:123:7`, with the file relative to the working directory
+	DocCommentOrigins bool `yaml:"doc-comment-origins,omitempty"`
+
 	// PreferSkipOptionalPointer allows defining at a global level whether to omit the pointer for a type to indicate that the field/type is optional.
 	// This is the same as adding `x-go-type-skip-optional-pointer` to each field (manually, or using an OpenAPI Overlay)
 	PreferSkipOptionalPointer bool `yaml:"prefer-skip-optional-pointer,omitempty"`
@@ -532,8 +592,56 @@ type OutputOptions struct {
 	// See https://github.com/oapi-codegen/oapi-codegen/issues/1139
 	GenerateTypesForAnonymousSchemas bool `yaml:"generate-types-for-anonymous-schemas,omitempty"`
 
+	// SealedDiscriminatedUnions, when true, generates a discriminated `oneOf`
+	// as a sealed Go interface (e.g. `type Pet interface{ isPet() }`) which
+	// each member type implements, rather than as a struct wrapping the raw
+	// JSON. `Unmarshal<Union>` / `Marshal<Union>` functions (and their
+	// `Slice` / `Map` variants) dispatch on the discriminator, and structs,
+	// request bodies and responses that hold the union decode and encode
+	// through them.
+	//
+	// Only unions whose members are all `$ref`s to local object schemas, and
+	// which declare no properties of their own, are generated this way; any
+	// other union keeps the default representation. Ignored when
+	// `compatibility.old-aliasing` is set.
+	SealedDiscriminatedUnions bool `yaml:"sealed-discriminated-unions,omitempty"`
+
+	// UnionVariantDetection, when true, generates `Detect` and `Variant`
+	// methods on `oneOf` / `anyOf` unions without a discriminator. They
+	// match the union data against each member's structural signature (JSON
+	// type, enum values, required and declared properties, and
+	// `additionalProperties: false`), and return the matching member, or a
+	// `<Union>VariantError` when a `oneOf` doesn't match exactly one member
+	// or an `anyOf` matches none.
+	UnionVariantDetection bool `yaml:"union-variant-detection,omitempty"`
+
+	// ApplyDefaults, when true, generates an `ApplyDefaults` method on each
+	// struct type with properties that have a `default`, or that hold values
+	// of such types, which sets unset optional fields to their default and
+	// recurses into nested values. Generated servers call it on the
+	// `<Op>Params` object, so that defaulted optional query, header and
+	// cookie parameters are populated before the handler is called.
+	//
+	// Only defaults of string, number and boolean types (and arrays of them)
+	// mapped to the matching Go builtin types are applied; a nullable field
+	// is left alone, since nil may mean an explicit null.
+	ApplyDefaults bool `yaml:"apply-defaults,omitempty"`
+
+	// FixedConstFields, when true, generates an OpenAPI 3.1 `const` schema
+	// (e.g. a property with `const: "v2"`) as a zero-size Go type rather than
+	// a single-value enum. Its JSON and text methods always write the value,
+	// and reject any other value on decoding, so fields of the type are filled
+	// in automatically and verified without being set by hand. A `Value`
+	// method returns the value. Fields of the type are never pointers.
+	//
+	// Only string, number and boolean constants mapped to Go builtin types
+	// are generated this way; others keep the enum representation.
+	FixedConstFields bool `yaml:"fixed-const-fields,omitempty"`
+
 	// TypeMapping allows customizing OpenAPI type/format to Go type mappings.
-	// User-specified mappings are merged on top of the defaults.
+	// User-specified mappings are merged on top of the defaults. Its
+	// `presets` select built-in sets of mappings, such as `decimal` for
+	// arbitrary-precision numbers; see TypeMappingPresets.
 	TypeMapping *TypeMapping `yaml:"type-mapping,omitempty"`
 
 	// ContentTypes maps a short name to a list of regex patterns matched
@@ -570,6 +678,32 @@ type OutputOptions struct {
 	// NOTE that mapping two media types that appear on the same request or
 	// response to the same short name produces colliding type names.
 	ContentTypes map[string][]string `yaml:"content-types,omitempty"`
+
+	// EmbeddedSpecFile, with `generate.embedded-spec`, writes the spec as
+	// this file, e.g. `openapi.json` or `openapi.yaml`, next to the
+	// generated code, which embeds it with `//go:embed`, rather than as a
+	// compressed blob in the code, so that changes to the spec can be
+	// reviewed in its diffs. It's a base name, and its extension picks JSON
+	// or YAML. Only GenerateArtifacts, and the CLI, return the file.
+	EmbeddedSpecFile string `yaml:"embedded-spec-file,omitempty"`
+
+	// PublicSpec, with `generate.embedded-spec`, embeds a public variant of
+	// the spec too, returned by GetPublicSpec, and served by the spec routes,
+	// without what isn't for the clients of the API.
+	PublicSpec *PublicSpecOptions `yaml:"public-spec,omitempty"`
+}
+
+// PublicSpecOptions are the options of the public variant of the embedded
+// spec, which leaves out the operations they exclude, the components only
+// they use, and the `x-go-*` and `x-oapi-codegen-*` extensions.
+type PublicSpecOptions struct {
+	// ExcludeTags excludes the operations with any of these tags, and the
+	// tags.
+	ExcludeTags []string `yaml:"exclude-tags,omitempty"`
+	// ExcludeExtensions excludes the operations with any of these
+	// extensions, set to other than false. The components with them must
+	// only be used by excluded operations. Defaults to `x-internal`.
+	ExcludeExtensions []string `yaml:"exclude-extensions,omitempty"`
 }
 
 func (oo OutputOptions) Validate() map[string]string {
@@ -597,9 +731,49 @@ func (oo OutputOptions) Validate() map[string]string {
 		}
 	}
 
+	if oo.EmbeddedSpecFile != "" {
+		if err := validateEmbeddedSpecFile(oo.EmbeddedSpecFile); err != nil {
+			return map[string]string{
+				"embedded-spec-file": err.Error(),
+			}
+		}
+	}
+
+	if oo.TypeMapping != nil {
+		if unknown := unknownPresets(oo.TypeMapping.Presets); len(unknown) > 0 {
+			return map[string]string{
+				"type-mapping": fmt.Sprintf("unknown type mapping presets: %s", strings.Join(unknown, ", ")),
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateEmbeddedSpecFile checks that name is the base name of a JSON or
+// YAML file, as go:embed can embed it from next to the generated code.
+func validateEmbeddedSpecFile(name string) error {
+	if name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%q must be a file name, without a directory", name)
+	}
+	if embeddedSpecFormat(name) == "" {
+		return fmt.Errorf("%q must have a .json, .yaml or .yml extension", name)
+	}
 	return nil
 }
 
+// embeddedSpecFormat returns "json" or "yaml", the format of the embedded
+// spec file name, or "" when its extension is neither.
+func embeddedSpecFormat(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return ""
+}
+
 // compileStreamingContentTypes returns the merged default + user-provided
 // patterns compiled into regexes. The first compile error is returned
 // including the offending pattern.
@@ -1,10 +1,15 @@
 package codegen
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 )
 
 // TestConfigurationValidateImportMappingKeys verifies that import-mapping
@@ -32,3 +37,90 @@ func TestConfigurationValidateImportMappingKeys(t *testing.T) {
 	}
 	require.NoError(t, cfg.Validate())
 }
+
+func TestConfigurationHermetic(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{StdHTTPServer: true},
+		Hermetic:    &HermeticOptions{},
+	}
+	// No go.mod is searched for.
+	assert.Empty(t, cfg.GenerateWarnings())
+
+	// The user templates given by path are read from the hermetic files,
+	// and none from URLs.
+	dir := writeSpecFiles(t, map[string]string{"client.tmpl": "{{ end }}"})
+	spec, err := util.LoadSwaggerFromFS(fstest.MapFS{}, "api.yaml", []byte(`openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+`))
+	require.NoError(t, err)
+	cfg.OutputOptions.UserTemplates = map[string]string{
+		"client.tmpl":                "templates/client.tmpl",
+		"client-with-responses.tmpl": "https://example.com/client-with-responses.tmpl",
+	}
+	_, err = Generate(spec, cfg)
+	var sandboxErr *util.SandboxError
+	require.True(t, errors.As(err, &sandboxErr), err)
+	assert.Equal(t, []string{
+		"https://example.com/client-with-responses.tmpl: a URL",
+		"templates/client.tmpl: not in the hermetic files",
+	}, sandboxErr.Accesses)
+
+	cfg.OutputOptions.UserTemplates = map[string]string{"client.tmpl": "templates/client.tmpl"}
+	cfg.Hermetic.Files = map[string]string{"templates/client.tmpl": filepath.Join(dir, "client.tmpl")}
+	_, err = Generate(spec, cfg)
+	assert.ErrorContains(t, err, `error parsing user-provided template "client.tmpl"`)
+}
+
+func TestConfigurationValidateEmbeddedSpecFile(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{EmbeddedSpec: true},
+	}
+	for name, msg := range map[string]string{
+		"openapi.json":      "",
+		"openapi.YML":       "",
+		"spec/openapi.json": "without a directory",
+		"openapi.txt":       "must have a .json, .yaml or .yml extension",
+	} {
+		cfg.OutputOptions.EmbeddedSpecFile = name
+		err := cfg.Validate()
+		if msg == "" {
+			assert.NoError(t, err, name)
+		} else {
+			assert.ErrorContains(t, err, msg, name)
+		}
+	}
+
+	cfg.Generate.EmbeddedSpec = false
+	cfg.OutputOptions.EmbeddedSpecFile = "openapi.json"
+	assert.ErrorContains(t, cfg.Validate(), "it requires `generate.embedded-spec`")
+}
+
+func TestConfigurationValidateSpecRoutes(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{SpecRoutes: true, EmbeddedSpec: true, ChiServer: true},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Generate.ChiServer = false
+	assert.ErrorContains(t, cfg.Validate(), "`generate.spec-routes` requires `embedded-spec` and a server")
+
+	cfg.Generate.ChiServer = true
+	cfg.Generate.EmbeddedSpec = false
+	assert.ErrorContains(t, cfg.Validate(), "`generate.spec-routes` requires `embedded-spec` and a server")
+}
+
+func TestConfigurationValidatePublicSpec(t *testing.T) {
+	cfg := Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{EmbeddedSpec: true},
+		OutputOptions: OutputOptions{PublicSpec: &PublicSpecOptions{}},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Generate.EmbeddedSpec = false
+	assert.ErrorContains(t, cfg.Validate(), "`output-options` configuration for public-spec was incorrect: it requires `generate.embedded-spec`")
+}
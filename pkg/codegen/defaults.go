@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultedField describes how a generated ApplyDefaults method fills in one
+// field of a struct: by assigning the property's default when the field is
+// unset, by recursing into a nested value whose type has ApplyDefaults, or
+// both.
+type DefaultedField struct {
+	GoFieldName string
+	// TypeDecl is the Go type of the field's value, without the optional
+	// pointer.
+	TypeDecl string
+	// Value is the Go expression of the property's default, or "" when the
+	// property has none the generator can express.
+	Value string
+	// Composite is set when Value is a composite literal of TypeDecl, rather
+	// than an untyped constant.
+	Composite bool
+	// Pointer is set when the field is an optional pointer, so that Value is
+	// assigned through a variable, and a nested value reached through it.
+	Pointer bool
+	// Nested is how the field's value is recursed into: "struct", "slice" or
+	// "map" when the value, or its items, have ApplyDefaults, else "".
+	Nested string
+}
+
+// newDefaultedField returns how ApplyDefaults fills in the field of the given
+// property, or nil when it leaves the field alone. hasDefaults reports whether
+// a named type has an ApplyDefaults method.
+func newDefaultedField(p Property, hasDefaults func(typeName string) bool) *DefaultedField {
+	p = p.withFieldExtensions()
+	goType := p.GoTypeDef()
+	f := DefaultedField{
+		GoFieldName: p.GoFieldName(),
+		TypeDecl:    p.Schema.TypeDecl(),
+		Pointer:     strings.HasPrefix(goType, "*"),
+	}
+
+	// A default only applies to an absent property, which the Go zero value
+	// can tell apart only for a nil pointer, slice or map. A nullable
+	// pointer is also nil for an explicit null, so it's left alone.
+	if !p.Required && !p.Nullable && (f.Pointer || p.ZeroValueIsNil()) {
+		f.Value = defaultValueLiteral(f.TypeDecl, p.Schema.OAPISchema)
+		f.Composite = strings.HasPrefix(f.Value, f.TypeDecl+"{")
+	}
+
+	switch base := strings.TrimPrefix(goType, "*"); {
+	case hasDefaults(base):
+		f.Nested = "struct"
+	case strings.HasPrefix(base, "[]") && hasDefaults(base[len("[]"):]):
+		f.Nested = "slice"
+	case strings.HasPrefix(base, "map[string]") && hasDefaults(base[len("map[string]"):]):
+		f.Nested = "map"
+	}
+
+	if f.Value == "" && f.Nested == "" {
+		return nil
+	}
+	return &f
+}
+
+// DefaultedFields returns how ApplyDefaults fills in the fields of a struct
+// type; see newDefaultedField.
+func (s Schema) DefaultedFields(hasDefaults func(typeName string) bool) []DefaultedField {
+	var fields []DefaultedField
+	for _, p := range s.Properties {
+		if f := newDefaultedField(p, hasDefaults); f != nil {
+			fields = append(fields, *f)
+		}
+	}
+	return fields
+}
+
+// defaultValueLiteral returns the Go expression of a schema's default value
+// for a variable of the given Go type, or "" when the schema has no default,
+// or one that can't be written as a constant of the type: a string, number or
+// boolean mapped to a Go type with the same kind, or an array of those.
+func defaultValueLiteral(goType string, schema *openapi3.Schema) string {
+	if schema == nil || schema.Default == nil {
+		return ""
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return ""
+	}
+	if t := schemaPrimaryType(schema.Type); t != nil && t.Is("array") {
+		values, ok := schema.Default.([]any)
+		if !ok || schema.Items == nil {
+			return ""
+		}
+		items := make([]string, len(values))
+		for i, value := range values {
+			if items[i] = scalarLiteral(schema.Items.Value, value); items[i] == "" {
+				return ""
+			}
+		}
+		return goType + "{" + strings.Join(items, ", ") + "}"
+	}
+	return scalarLiteral(schema, schema.Default)
+}
+
+// scalarLiteral returns value as an untyped Go constant, assignable to the
+// Go type of schema, or "" when the type isn't a plain string, integer,
+// float or boolean type or the value doesn't fit it.
+func scalarLiteral(schema *openapi3.Schema, value any) string {
+	if schema == nil {
+		return ""
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return ""
+	}
+	t := schemaPrimaryType(schema.Type)
+	if t == nil {
+		return ""
+	}
+	switch {
+	case t.Is("string"):
+		v, ok := value.(string)
+		if !ok || globalState.typeMapping.String.Resolve(schema.Format).Type != "string" {
+			return ""
+		}
+		return StringToGoString(v)
+	case t.Is("boolean"):
+		v, ok := value.(bool)
+		if !ok || globalState.typeMapping.Boolean.Resolve(schema.Format).Type != "bool" {
+			return ""
+		}
+		return strconv.FormatBool(v)
+	case t.Is("integer"):
+		v, ok := value.(float64)
+		goType := globalState.typeMapping.Integer.Resolve(schema.Format).Type
+		if !ok || v != math.Trunc(v) || !isGoIntegerType(goType) ||
+			(v < 0 && strings.HasPrefix(goType, "uint")) {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case t.Is("number"):
+		v, ok := value.(float64)
+		goType := globalState.typeMapping.Number.Resolve(schema.Format).Type
+		if !ok || (goType != "float32" && goType != "float64") {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return ""
+}
+
+// isGoIntegerType reports whether goType is one of Go's builtin integer types.
+func isGoIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// HasDefaultedParams reports whether the operation has optional query, header
+// or cookie parameters with a default, which the generated server wrappers
+// fill in by calling ApplyDefaults on the parameter object before calling the
+// handler.
+func (o *OperationDefinition) HasDefaultedParams() bool {
+	if !globalState.options.OutputOptions.ApplyDefaults {
+		return false
+	}
+	for _, td := range o.TypeDefinitions {
+		if td.TypeName != o.OperationId+"Params" {
+			continue
+		}
+		for _, f := range td.Schema.DefaultedFields(func(string) bool { return false }) {
+			if f.Value != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultValueLiteral(t *testing.T) {
+	old := globalState.typeMapping
+	globalState.typeMapping = DefaultTypeMapping
+	defer func() { globalState.typeMapping = old }()
+
+	schema := func(typ, format string, value any) *openapi3.Schema {
+		return &openapi3.Schema{Type: &openapi3.Types{typ}, Format: format, Default: value}
+	}
+
+	tests := []struct {
+		name   string
+		goType string
+		schema *openapi3.Schema
+		want   string
+	}{
+		{"no default", "string", schema("string", "", nil), ""},
+		{"string", "string", schema("string", "", `a "b"`), `"a \"b\""`},
+		{"enum type", "Order", schema("string", "", "asc"), `"asc"`},
+		{"date-time", "time.Time", schema("string", "date-time", "2020-01-01T00:00:00Z"), ""},
+		{"string for integer", "int", schema("integer", "", "1"), ""},
+		{"integer", "int64", schema("integer", "int64", float64(-3)), "-3"},
+		{"fractional integer", "int", schema("integer", "", 1.5), ""},
+		{"negative unsigned", "uint", schema("integer", "uint", float64(-1)), ""},
+		{"number", "float32", schema("number", "", 0.25), "0.25"},
+		{"boolean", "bool", schema("boolean", "", true), "true"},
+		{
+			"array",
+			"[]int",
+			&openapi3.Schema{
+				Type:    &openapi3.Types{"array"},
+				Items:   openapi3.NewSchemaRef("", schema("integer", "", nil)),
+				Default: []any{float64(1), float64(2)},
+			},
+			"[]int{1, 2}",
+		},
+		{
+			"array of unsupported",
+			"[]openapi_types.UUID",
+			&openapi3.Schema{
+				Type:    &openapi3.Types{"array"},
+				Items:   openapi3.NewSchemaRef("", schema("string", "uuid", nil)),
+				Default: []any{"0f0e7f3c-1b2a-4c5d-8e9f-0a1b2c3d4e5f"},
+			},
+			"",
+		},
+		{
+			"x-go-type",
+			"MyString",
+			&openapi3.Schema{
+				Type:       &openapi3.Types{"string"},
+				Default:    "a",
+				Extensions: map[string]any{extPropGoType: "MyString"},
+			},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultValueLiteral(tt.goType, tt.schema))
+		})
+	}
+}
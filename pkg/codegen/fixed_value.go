@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"encoding/json"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FixedValue describes an OpenAPI 3.1 `const` schema generated as a zero-size
+// Go type, when the output-options.fixed-const-fields option is set. Its
+// generated JSON and text methods always write the value, and reject any
+// other value on decoding, so a field of the type never needs setting.
+type FixedValue struct {
+	// GoType is the Go type of the value, e.g. "string".
+	GoType string
+	// Literal is the value as a Go constant, e.g. `"v2"`.
+	Literal string
+	// JSON is the JSON encoding of the value.
+	JSON string
+	// Text is the text encoding of the value, used for parameters.
+	Text string
+}
+
+// newFixedValue returns the fixed value representation of schema, or nil when
+// the schema doesn't qualify: the option is off, the schema isn't a 3.1
+// `const` (without an `enum`), it's nullable, or its value isn't a string,
+// number or boolean mapped to a Go builtin type. A qualifying schema is
+// decided the same way at its declaration and at every $ref to it, so both
+// agree on the representation.
+func newFixedValue(schema *openapi3.Schema) *FixedValue {
+	if !globalState.options.OutputOptions.FixedConstFields || !globalState.is31 {
+		return nil
+	}
+	if schema == nil || schema.Const == nil || len(schema.Enum) != 0 || schemaIsNullable(schema) {
+		return nil
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return nil
+	}
+	literal := scalarLiteral(schema, schema.Const)
+	if literal == "" {
+		return nil
+	}
+	var valueSchema Schema
+	if err := oapiSchemaToGoType(schema, nil, &valueSchema); err != nil {
+		return nil
+	}
+	b, err := json.Marshal(schema.Const)
+	if err != nil {
+		return nil
+	}
+	text := literal
+	if s, ok := schema.Const.(string); ok {
+		text = s
+	}
+	return &FixedValue{GoType: valueSchema.GoType, Literal: literal, JSON: string(b), Text: text}
+}
+
+// IsFixedValue reports whether the schema is, or refers to, a zero-size fixed
+// value type, which needs no assigning.
+func (s Schema) IsFixedValue() bool {
+	return s.FixedValue != nil || newFixedValue(s.OAPISchema) != nil
+}
@@ -18,36 +18,94 @@ import (
 	"compress/flate"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"text/template"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
 )
 
 // GenerateInlinedSpec generates a gzipped, base64 encoded JSON representation of the
 // swagger definition, which we embed inside the generated code.
 func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T) (string, error) {
+	code, _, err := generateEmbeddedSpec(t, importMapping, swagger, OutputOptions{})
+	return code, err
+}
+
+// generateEmbeddedSpec generates the code embedding the swagger definition,
+// and, with opts.PublicSpec, its public variant, as the opts say: in the code,
+// or from the embedded-spec-file next to it, which are the returned files.
+func generateEmbeddedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T, opts OutputOptions) (string, []GeneratedFile, error) {
 	// ensure that any external file references are embedded into the embedded spec
 	swagger.InternalizeRefs(context.Background(), nil)
 	// Marshal to json
 	encoded, err := swagger.MarshalJSON()
 	if err != nil {
-		return "", fmt.Errorf("error marshaling swagger: %w", err)
+		return "", nil, fmt.Errorf("error marshaling swagger: %w", err)
+	}
+
+	data := inlineSpec{ImportMapping: importMapping}
+	var files []GeneratedFile
+	if opts.EmbeddedSpecFile != "" {
+		data.EmbeddedFile = opts.EmbeddedSpecFile
+		data.EmbeddedYAML = embeddedSpecFormat(opts.EmbeddedSpecFile) == "yaml"
+		contents, err := embeddedSpecFileContents(encoded, data.EmbeddedYAML)
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, GeneratedFile{Name: data.EmbeddedFile, Contents: contents})
+	} else {
+		data.SpecParts, err = compressSpec(encoded)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if opts.PublicSpec != nil {
+		public, err := publicSpec(encoded, *opts.PublicSpec)
+		if err != nil {
+			return "", nil, fmt.Errorf("error generating the public spec: %w", err)
+		}
+		data.Public = true
+		if opts.EmbeddedSpecFile != "" {
+			data.PublicEmbeddedFile = publicSpecFileName(opts.EmbeddedSpecFile)
+			contents, err := embeddedSpecFileContents(public, data.EmbeddedYAML)
+			if err != nil {
+				return "", nil, err
+			}
+			files = append(files, GeneratedFile{Name: data.PublicEmbeddedFile, Contents: contents})
+		} else {
+			data.PublicSpecParts, err = compressSpec(public)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	code, err := GenerateTemplates([]string{"inline.tmpl"}, t, data)
+	if err != nil {
+		return "", nil, err
 	}
+	return code, files, nil
+}
 
+// compressSpec returns the JSON spec encoded compressed with flate, base64
+// encoded, and chopped into fixed-width chunks.
+func compressSpec(encoded []byte) ([]string, error) {
 	// flate
 	var buf bytes.Buffer
 	zw, err := flate.NewWriter(&buf, flate.BestCompression)
 	if err != nil {
-		return "", fmt.Errorf("new flate writer: %w", err)
+		return nil, fmt.Errorf("new flate writer: %w", err)
 	}
 
 	if _, err := zw.Write(encoded); err != nil {
-		return "", fmt.Errorf("write flate: %w", err)
+		return nil, fmt.Errorf("write flate: %w", err)
 	}
 
 	if err := zw.Close(); err != nil {
-		return "", fmt.Errorf("close flate writer: %w", err)
+		return nil, fmt.Errorf("close flate writer: %w", err)
 	}
 
 	str := base64.StdEncoding.EncodeToString(buf.Bytes())
@@ -64,15 +122,69 @@ func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger
 	if len(str) > 0 {
 		parts = append(parts, str)
 	}
+	return parts, nil
+}
+
+// inlineSpec is the data of the inline.tmpl template.
+type inlineSpec struct {
+	// SpecParts are the chunks of the compressed spec, when it's in the code.
+	SpecParts []string
+	// EmbeddedFile is the name of the file the spec is embedded from, when
+	// it's not.
+	EmbeddedFile string
+	// EmbeddedYAML is whether the embedded files are YAML, rather than JSON.
+	EmbeddedYAML bool
+	// Public is whether the public variant of the spec is embedded too, in
+	// PublicSpecParts or from PublicEmbeddedFile, as the spec is.
+	Public             bool
+	PublicSpecParts    []string
+	PublicEmbeddedFile string
+	ImportMapping      importMap
+}
+
+// embeddedSpecFileContents returns the contents of an embedded spec file of
+// the JSON spec encoded: indented JSON, or YAML.
+func embeddedSpecFileContents(encoded []byte, isYAML bool) ([]byte, error) {
+	if isYAML {
+		contents, err := jsonToYAML(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling swagger as YAML: %w", err)
+		}
+		return contents, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, encoded, "", "  "); err != nil {
+		return nil, fmt.Errorf("error indenting swagger: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// jsonToYAML converts the JSON document data to block style YAML, keeping
+// the order of its keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	// JSON is YAML, in flow style.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	var blockStyle func(n *yaml.Node)
+	blockStyle = func(n *yaml.Node) {
+		n.Style = 0
+		for _, c := range n.Content {
+			blockStyle(c)
+		}
+	}
+	blockStyle(&node)
 
-	return GenerateTemplates(
-		[]string{"inline.tmpl"},
-		t,
-		struct {
-			SpecParts     []string
-			ImportMapping importMap
-		}{
-			SpecParts:     parts,
-			ImportMapping: importMapping,
-		})
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,496 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// LintRule is a named check of a spec, for something that will make its
+// generation fail or its generated code awkward.
+type LintRule struct {
+	Name        string
+	Description string
+	check       func(l *linter) error
+}
+
+// LintRules are the rules of Lint, in the order their findings are reported.
+// They're all enabled unless LintOptions says otherwise.
+var LintRules = []LintRule{
+	{
+		Name:        "inline-schema-name",
+		Description: "Inline schemas, which are generated as types with names made up from where they're declared.",
+		check:       (*linter).checkInlineSchemaNames,
+	},
+	{
+		Name:        "missing-operation-id",
+		Description: "Operations without an operationId, whose handlers are named after their method and path.",
+		check:       (*linter).checkMissingOperationIDs,
+	},
+	{
+		Name:        "enum-value-collision",
+		Description: "Enum values whose constants have the same name, so all but the first are numbered.",
+		check:       (*linter).checkEnumValueCollisions,
+	},
+	{
+		Name:        "allof-property-conflict",
+		Description: "Properties declared with different types by the members of an allOf, where the last one wins.",
+		check:       (*linter).checkAllOfPropertyConflicts,
+	},
+	{
+		Name:        "parameter-hoisting",
+		Description: "Path item parameters whose inline schemas are generated as helper types, which are named after the parameter, or a hash of the path when several path items share the name.",
+		check:       (*linter).checkParameterHoisting,
+	},
+	{
+		Name:        "std-http-path",
+		Description: "Paths which net/http ServeMux can't route, when generating a std-http-server, or no configuration is given.",
+		check:       (*linter).checkStdHTTPPaths,
+	},
+}
+
+// LintOptions selects the rules of Lint, by name.
+type LintOptions struct {
+	// Enable, when not empty, are the only rules which are run.
+	Enable []string
+	// Disable are rules which aren't run.
+	Disable []string
+}
+
+// LintFinding is something a rule of Lint found in the spec.
+type LintFinding struct {
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+	// Source is the JSON pointer of the element of the spec, e.g.
+	// "#/components/schemas/Pet/properties/owner".
+	Source string `json:"source"`
+	// Position is the "file:line:column" of the element, when it's known.
+	Position string `json:"position,omitempty"`
+	Message  string `json:"message"`
+	// Fix is an overlay action fixing the finding, e.g. naming an inline
+	// schema with x-go-type-name, when there is one.
+	Fix *OverlayAction `json:"fix,omitempty"`
+	// Advice tells how to fix the finding otherwise.
+	Advice string `json:"advice,omitempty"`
+}
+
+func (f LintFinding) String() string {
+	where := f.Position
+	if where == "" {
+		where = f.Source
+	}
+	return fmt.Sprintf("%s: %s (%s)", where, f.Message, f.Rule)
+}
+
+// Lint checks spec, as it would be generated with opts, for the hazards the
+// rules of LintRules look for. It lints the whole spec, before any operations
+// are filtered out or unused components pruned, and doesn't change it.
+func Lint(spec *openapi3.T, opts Configuration, lintOpts LintOptions) ([]LintFinding, error) {
+	rules, err := selectLintRules(lintOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	generateMu.Lock()
+	defer generateMu.Unlock()
+
+	if err := initGlobalState(spec, opts); err != nil {
+		return nil, err
+	}
+	globalState.resolvedNames = nil
+	globalState.resolvedClientWrapperNames = nil
+
+	l := &linter{
+		spec:    spec,
+		opts:    opts,
+		sources: newReportBuilder(spec),
+	}
+	for _, rule := range rules {
+		l.rule = rule.Name
+		if err := rule.check(l); err != nil {
+			return l.findings, fmt.Errorf("error running lint rule %s: %w", rule.Name, err)
+		}
+	}
+	return l.findings, nil
+}
+
+func selectLintRules(lintOpts LintOptions) ([]LintRule, error) {
+	for _, name := range slices.Concat(lintOpts.Enable, lintOpts.Disable) {
+		if !slices.ContainsFunc(LintRules, func(r LintRule) bool { return r.Name == name }) {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+	}
+	var rules []LintRule
+	for _, rule := range LintRules {
+		if len(lintOpts.Enable) > 0 && !slices.Contains(lintOpts.Enable, rule.Name) {
+			continue
+		}
+		if slices.Contains(lintOpts.Disable, rule.Name) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// linter holds the state of a run of Lint.
+type linter struct {
+	spec *openapi3.T
+	opts Configuration
+	// sources finds the JSON pointers of the elements of the spec.
+	sources       *reportBuilder
+	sourceSchemas map[string]*openapi3.Schema
+	rule          string
+	findings      []LintFinding
+}
+
+// add records a finding of the current rule at the element whose JSON
+// pointer and origin are given, and returns it to have a fix added.
+func (l *linter) add(source string, origin *openapi3.Origin, format string, args ...any) *LintFinding {
+	l.findings = append(l.findings, LintFinding{
+		Rule:     l.rule,
+		Source:   source,
+		Position: originPosition(origin),
+		Message:  fmt.Sprintf(format, args...),
+	})
+	return &l.findings[len(l.findings)-1]
+}
+
+// namedOperation is an operation of the paths of the spec, with the name
+// generation gives it.
+type namedOperation struct {
+	path   string
+	method string
+	item   *openapi3.PathItem
+	op     *openapi3.Operation
+	id     string
+}
+
+// namedOperations returns the operations of the paths of spec, named as
+// OperationDefinitions names them, without writing the names to the spec.
+func namedOperations(spec *openapi3.T) ([]namedOperation, error) {
+	if spec.Paths == nil {
+		return nil, nil
+	}
+	var ops []namedOperation
+	for _, path := range SortedMapKeys(spec.Paths.Map()) {
+		item := spec.Paths.Value(path)
+		pathOps := item.Operations()
+		for _, method := range SortedMapKeys(pathOps) {
+			op := pathOps[method]
+			id := op.OperationID
+			if id == "" {
+				var err error
+				if id, err = generateDefaultOperationID(method, path); err != nil {
+					return nil, withOrigin(op.Origin, err)
+				}
+			} else {
+				id = nameNormalizer(id)
+			}
+			ops = append(ops, namedOperation{
+				path:   path,
+				method: method,
+				item:   item,
+				op:     op,
+				id:     typeNamePrefix(id) + id,
+			})
+		}
+	}
+	return ops, nil
+}
+
+func (l *linter) checkInlineSchemaNames() error {
+	types, err := collectComponentTypes(nil, l.spec, l.opts.OutputOptions.ExcludeSchemas)
+	if err != nil {
+		return err
+	}
+	ops, err := namedOperations(l.spec)
+	if err != nil {
+		return err
+	}
+	for _, o := range ops {
+		params, err := DescribeParameters(o.op.Parameters, []string{o.id + "Params"})
+		if err != nil {
+			return err
+		}
+		for _, pd := range params {
+			types = append(types, pd.Schema.AdditionalTypes...)
+		}
+		bodies, _, err := GenerateBodyDefinitions(o.id, o.op.RequestBody, o.item.Ref)
+		if err != nil {
+			return withOrigin(o.op.Origin, err)
+		}
+		for _, bd := range bodies {
+			types = append(types, bd.Schema.AdditionalTypes...)
+		}
+		opDef := OperationDefinition{OperationId: o.id, Spec: o.op, PathItemRef: o.item.Ref}
+		responses, err := opDef.GetResponseTypeDefinitions()
+		if err != nil {
+			return withOrigin(o.op.Origin, err)
+		}
+		for _, rd := range responses {
+			types = append(types, rd.AdditionalTypeDefinitions...)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, td := range types {
+		schema := td.Schema.OAPISchema
+		source := l.sources.schemaPointer(schema)
+		if source == "" || seen[source] || isNamedSchemaPointer(source) {
+			continue
+		}
+		seen[source] = true
+		if _, ok := schema.Extensions[extGoTypeName]; ok {
+			continue
+		}
+		f := l.add(source, schema.Origin, "inline schema is generated as type %s, named after where it's declared", td.TypeName)
+		f.Fix = overlayUpdate(source, extGoTypeName, td.TypeName)
+		f.Advice = "Name the type with x-go-type-name, or move the schema to components/schemas."
+	}
+	return nil
+}
+
+// isNamedSchemaPointer reports whether the schema at the JSON pointer is one
+// whose type is named after a component: a schema, or the schema of a
+// parameter, response or request body.
+func isNamedSchemaPointer(pointer string) bool {
+	tokens := strings.Split(pointer, "/")
+	if len(tokens) < 4 || tokens[1] != "components" {
+		return false
+	}
+	switch tokens[2] {
+	case "schemas":
+		return len(tokens) == 4
+	case "parameters":
+		return len(tokens) == 5 && tokens[4] == "schema"
+	case "responses", "requestBodies":
+		return len(tokens) == 7 && tokens[4] == "content" && tokens[6] == "schema"
+	}
+	return false
+}
+
+func (l *linter) checkMissingOperationIDs() error {
+	ops, err := namedOperations(l.spec)
+	if err != nil {
+		return err
+	}
+	for _, o := range ops {
+		if o.op.OperationID != "" {
+			continue
+		}
+		source := l.sources.operationPointer(o.op)
+		f := l.add(source, o.op.Origin, "%s %s has no operationId, so its handler is named %s", strings.ToUpper(o.method), o.path, o.id)
+		f.Fix = overlayUpdate(source, "operationId", o.id)
+		f.Advice = "Give the operation an operationId."
+	}
+	return nil
+}
+
+func (l *linter) checkEnumValueCollisions() error {
+	for _, source := range l.schemaSources() {
+		schema := l.sourceSchemas[source]
+		if len(schema.Enum) == 0 {
+			continue
+		}
+		varNames, collisions := enumVarNames(schema)
+		if len(collisions) == 0 {
+			continue
+		}
+		f := l.add(source, schema.Origin, "enum values' names collide: %s", strings.Join(collisions, "; "))
+		if len(varNames) == len(schema.Enum) {
+			f.Fix = overlayUpdate(source, extEnumVarNames, varNames)
+		}
+		f.Advice = "Name the enum values with x-enum-varnames."
+	}
+	return nil
+}
+
+// enumVarNames returns the names of the constants of the values of an enum
+// schema, as SanitizeEnumNames names them, in the order of the values, and a
+// description of each value whose name was taken. Values with the same name
+// as an earlier one are left out, as they share its constant.
+func enumVarNames(schema *openapi3.Schema) (varNames, collisions []string) {
+	values := make([]string, len(schema.Enum))
+	for i, v := range schema.Enum {
+		values[i] = fmt.Sprintf("%v", v)
+	}
+	names := values
+	for _, key := range []string{extEnumVarNames, extEnumNames} {
+		if extension, ok := schema.Extensions[key]; ok {
+			if extNames, err := extParseEnumVarNames(extension); err == nil {
+				names = extNames
+				break
+			}
+		}
+	}
+
+	var (
+		first  = map[string]string{}
+		counts = map[string]int{}
+		named  = map[string]bool{}
+	)
+	for i, v := range values {
+		n := v
+		if i < len(names) {
+			n = names[i]
+		}
+		if named[n] {
+			continue
+		}
+		named[n] = true
+		sanitized := SanitizeGoIdentity(SchemaNameToTypeName(n))
+		varName := sanitized
+		if counts[sanitized] > 0 {
+			varName += strconv.Itoa(counts[sanitized])
+			collisions = append(collisions, fmt.Sprintf("%q is named %s as %q is named %s", v, varName, first[sanitized], sanitized))
+		} else {
+			first[sanitized] = v
+		}
+		counts[sanitized]++
+		varNames = append(varNames, varName)
+	}
+	return varNames, collisions
+}
+
+// schemaSources returns the JSON pointers of the schemas of the spec, in
+// order, with sourceSchemas set to the schemas at them.
+func (l *linter) schemaSources() []string {
+	if l.sourceSchemas == nil {
+		l.sourceSchemas = l.sources.schemasBySource()
+	}
+	return SortedMapKeys(l.sourceSchemas)
+}
+
+// allOfProperty is a property declared by a member of an allOf.
+type allOfProperty struct {
+	member int
+	schema *openapi3.SchemaRef
+}
+
+func (l *linter) checkAllOfPropertyConflicts() error {
+	for _, source := range l.schemaSources() {
+		schema := l.sourceSchemas[source]
+		if len(schema.AllOf) < 2 {
+			continue
+		}
+		properties := map[string][]allOfProperty{}
+		for i, member := range schema.AllOf {
+			collectAllOfProperties(member, i, properties, map[*openapi3.Schema]bool{})
+		}
+		for _, name := range SortedMapKeys(properties) {
+			declared := properties[name]
+			for _, p := range declared[1:] {
+				if a, b := propertyTypeString(declared[0].schema), propertyTypeString(p.schema); a != b {
+					f := l.add(source, schema.Origin, "property %q is %s in allOf member %d, but %s in member %d; the generated field has the type of the last",
+						name, a, declared[0].member, b, p.member)
+					f.Advice = "Declare the property with the same type in each member, or in only one."
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// collectAllOfProperties adds the properties of the allOf member schema,
+// including those of the allOf members it has itself.
+func collectAllOfProperties(ref *openapi3.SchemaRef, member int, properties map[string][]allOfProperty, seen map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || seen[ref.Value] {
+		return
+	}
+	seen[ref.Value] = true
+	for _, name := range SortedMapKeys(ref.Value.Properties) {
+		properties[name] = append(properties[name], allOfProperty{member: member, schema: ref.Value.Properties[name]})
+	}
+	for _, sub := range ref.Value.AllOf {
+		collectAllOfProperties(sub, member, properties, seen)
+	}
+}
+
+// propertyTypeString describes the type of a property schema, to compare it
+// with another: its $ref, or its types and format.
+func propertyTypeString(ref *openapi3.SchemaRef) string {
+	if ref.Ref != "" {
+		return ref.Ref
+	}
+	if ref.Value == nil || len(ref.Value.Type.Slice()) == 0 {
+		return "untyped"
+	}
+	s := strings.Join(ref.Value.Type.Slice(), "|")
+	if ref.Value.Format != "" {
+		s += " (" + ref.Value.Format + ")"
+	}
+	return s
+}
+
+func (l *linter) checkParameterHoisting() error {
+	shared, err := resolveSharedParameters(l.spec)
+	if err != nil {
+		return err
+	}
+	scopes := enumerateSharedParamScopes(l.spec)
+	// Count the path items hoisting a parameter of each name, as
+	// resolveSharedParameters does to find which names collide.
+	counts := map[string]int{}
+	for _, scope := range scopes {
+		if len(scope.item.Operations()) == 0 {
+			continue
+		}
+		for _, pd := range shared[scope.item] {
+			if len(pd.Schema.AdditionalTypes) > 0 {
+				counts[pd.ParamName]++
+			}
+		}
+	}
+	for _, scope := range scopes {
+		item := scope.item
+		if len(item.Operations()) == 0 {
+			continue
+		}
+		for _, pd := range shared[item] {
+			if len(pd.Schema.AdditionalTypes) == 0 {
+				continue
+			}
+			i := slices.IndexFunc(item.Parameters, func(p *openapi3.ParameterRef) bool { return p.Value == pd.Spec })
+			source := joinPointer(l.sources.pathItemPointer(item), "parameters", strconv.Itoa(i))
+			names := make([]string, len(pd.Schema.AdditionalTypes))
+			for j, td := range pd.Schema.AdditionalTypes {
+				names[j] = td.TypeName
+			}
+			var f *LintFinding
+			if counts[pd.ParamName] > 1 {
+				f = l.add(source, pd.Spec.Origin, "parameter %q of %s is generated with helper types %s, prefixed with a hash of the path as other path items have a parameter of the same name",
+					pd.ParamName, scope.source, strings.Join(names, ", "))
+			} else {
+				f = l.add(source, pd.Spec.Origin, "parameter %q of %s is generated with helper types %s, named after the parameter",
+					pd.ParamName, scope.source, strings.Join(names, ", "))
+			}
+			f.Advice = "Move the parameter's schema to components/schemas and refer to it with a $ref."
+		}
+	}
+	return nil
+}
+
+func (l *linter) checkStdHTTPPaths() error {
+	if g := l.opts.Generate; g != (GenerateOptions{}) && !g.StdHTTPServer {
+		return nil
+	}
+	if l.spec.Paths == nil {
+		return nil
+	}
+	for _, path := range SortedMapKeys(l.spec.Paths.Map()) {
+		item := l.spec.Paths.Value(path)
+		if len(item.Operations()) == 0 {
+			continue
+		}
+		if err := ValidateStdHTTPPath(path); err != nil {
+			f := l.add(l.sources.pathItemPointer(item), item.Origin, "%s", err)
+			f.Advice = "Make each path parameter a whole segment of the path, e.g. /things/{id}/apply rather than /things/{id}:apply."
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+const lintSpec = `openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+  /pets/{id}:apply:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          anyOf:
+            - {type: string, format: uuid}
+            - {type: string}
+    post:
+      operationId: applyPet
+      responses:
+        "204":
+          description: Applied
+  /owners/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          anyOf:
+            - {type: string, format: uuid}
+            - {type: string}
+    get:
+      operationId: getOwner
+      responses:
+        "204":
+          description: Found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          type: object
+          properties:
+            name: {type: string}
+        size:
+          type: string
+          enum: [big-dog, big_dog, small]
+    Cat:
+      allOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          properties:
+            size: {type: integer}
+`
+
+func TestLint(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(lintSpec))
+	require.NoError(t, err)
+
+	findings, err := Lint(swagger, Configuration{PackageName: "api"}, LintOptions{})
+	require.NoError(t, err)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Rule+" "+f.Source)
+	}
+	assert.Equal(t, []string{
+		"inline-schema-name #/components/schemas/Pet/properties/size",
+		"missing-operation-id #/paths/~1pets/get",
+		"enum-value-collision #/components/schemas/Pet/properties/size",
+		"allof-property-conflict #/components/schemas/Cat",
+		"parameter-hoisting #/paths/~1owners~1{id}/parameters/0",
+		"parameter-hoisting #/paths/~1pets~1{id}:apply/parameters/0",
+		"std-http-path #/paths/~1pets~1{id}:apply",
+	}, got)
+
+	// Inline objects are anonymous structs, rather than named types.
+	assert.Equal(t, "#/components/schemas/Pet/properties/size: inline schema is generated as type PetSize, named after where it's declared (inline-schema-name)", findings[0].String())
+	assert.Equal(t, &OverlayAction{
+		Target: "$.components.schemas.Pet.properties.size",
+		Update: map[string]any{"x-go-type-name": "PetSize"},
+	}, findings[0].Fix)
+	assert.Equal(t, "GET /pets has no operationId, so its handler is named GetPets", findings[1].Message)
+	assert.Equal(t, `enum values' names collide: "big_dog" is named BigDog1 as "big-dog" is named BigDog`, findings[2].Message)
+	assert.Equal(t, []string{"BigDog", "BigDog1", "Small"}, findings[2].Fix.Update["x-enum-varnames"])
+	assert.Equal(t, `property "size" is string in allOf member 0, but integer in member 1; the generated field has the type of the last`, findings[3].Message)
+	assert.Contains(t, findings[4].Message, "prefixed with a hash of the path")
+	assert.Nil(t, findings[6].Fix)
+}
+
+func TestLintRules(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(lintSpec))
+	require.NoError(t, err)
+
+	findings, err := Lint(swagger, Configuration{PackageName: "api"}, LintOptions{Enable: []string{"std-http-path", "missing-operation-id"}, Disable: []string{"missing-operation-id"}})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "std-http-path", findings[0].Rule)
+
+	// The paths only matter to a std-http-server.
+	findings, err = Lint(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{ChiServer: true},
+	}, LintOptions{Enable: []string{"std-http-path"}})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = Lint(swagger, Configuration{PackageName: "api"}, LintOptions{Disable: []string{"no-such-rule"}})
+	assert.EqualError(t, err, `unknown lint rule "no-such-rule"`)
+}
+
+func TestLintFixes(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{"api.yaml": lintSpec})
+	specPath := filepath.Join(dir, "api.yaml")
+	opts := Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{SkipPrune: true},
+	}
+	swagger, err := util.LoadSwagger(specPath)
+	require.NoError(t, err)
+	findings, err := Lint(swagger, opts, LintOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "api.yaml:52:9", filepath.Base(findings[0].Position))
+
+	var actions []OverlayAction
+	for _, f := range findings {
+		if f.Fix != nil {
+			actions = append(actions, *f.Fix)
+		}
+	}
+	overlay, err := yaml.Marshal(NewOverlay("Lint fixes", actions))
+	require.NoError(t, err)
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, overlay, 0o644))
+
+	// The fixes pin the names the code has.
+	fixed, err := util.LoadSwaggerWithOverlay(specPath, util.LoadSwaggerWithOverlayOpts{Path: overlayPath, Strict: true})
+	require.NoError(t, err)
+	code, err := Generate(fixed, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type PetSize string")
+	assert.Contains(t, code, "BigDog1 PetSize = \"big_dog\"")
+
+	fixed, err = util.LoadSwaggerWithOverlay(specPath, util.LoadSwaggerWithOverlayOpts{Path: overlayPath, Strict: true})
+	require.NoError(t, err)
+	findings, err = Lint(fixed, opts, LintOptions{Enable: []string{"inline-schema-name", "missing-operation-id", "enum-value-collision"}})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
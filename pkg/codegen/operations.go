@@ -17,6 +17,7 @@ import (
 	"bufio"
 	"bytes"
 	"cmp"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"maps"
@@ -259,8 +260,8 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 
 		goType, err := paramToGoType(param, append(path, param.Name))
 		if err != nil {
-			return nil, fmt.Errorf("error generating type for param (%s): %s",
-				param.Name, err)
+			return nil, withOrigin(param.Origin, fmt.Errorf("error generating type for param (%s): %w",
+				param.Name, err))
 		}
 
 		pd := ParameterDefinition{
@@ -566,6 +567,25 @@ func DescribeSecurityDefinition(securityRequirements openapi3.SecurityRequiremen
 	return outDefs
 }
 
+// describeSecurityRequirements returns the alternatives of
+// securityRequirements, each the sorted names of the schemes it requires,
+// without those requiring a scheme which isn't defined, and can't be
+// satisfied.
+func describeSecurityRequirements(securityRequirements openapi3.SecurityRequirements, defined map[string]struct{}) [][]string {
+	var alternatives [][]string
+	for _, sr := range securityRequirements {
+		schemes := SortedMapKeys(sr)
+		if slices.ContainsFunc(schemes, func(name string) bool {
+			_, ok := defined[name]
+			return !ok
+		}) {
+			continue
+		}
+		alternatives = append(alternatives, schemes)
+	}
+	return alternatives
+}
+
 // filterOutUndefinedSecuritySchemes drops any SecurityDefinition whose ProviderName
 // is not present in defined. A `security` requirement that references an
 // unknown scheme would otherwise produce a constant declaration and middleware
@@ -594,12 +614,16 @@ type OperationDefinition struct {
 	CookieParams        []ParameterDefinition // Parameters in cookies
 	TypeDefinitions     []TypeDefinition      // These are all the types we need to define for this operation
 	SecurityDefinitions []SecurityDefinition  // These are the security providers
-	BodyRequired        bool
-	Bodies              []RequestBodyDefinition // The list of bodies for which to generate handlers.
-	Responses           []ResponseDefinition    // The list of responses that can be accepted by handlers.
-	Summary             string                  // Summary string from Swagger, used to generate a comment
-	Method              string                  // GET, POST, DELETE, etc.
-	Path                string                  // The Swagger path for the operation, like /resource/{id}
+	// SecurityRequirements are the alternatives of the operation's security
+	// requirement, each the names of the schemes it requires together; an
+	// empty alternative allows anonymous requests.
+	SecurityRequirements [][]string
+	BodyRequired         bool
+	Bodies               []RequestBodyDefinition // The list of bodies for which to generate handlers.
+	Responses            []ResponseDefinition    // The list of responses that can be accepted by handlers.
+	Summary              string                  // Summary string from Swagger, used to generate a comment
+	Method               string                  // GET, POST, DELETE, etc.
+	Path                 string                  // The Swagger path for the operation, like /resource/{id}
 	// SpecOrder is the source line on which this operation's path is
 	// declared in the spec, used to register routes in the order the paths
 	// appear in the spec rather than sorted (issue #1887). Zero when the
@@ -856,6 +880,10 @@ func (o OperationDefinition) GenerateFunctionComment(originalFunctionName string
 		}
 	}
 
+	if source := o.SourceComment(); source != "" {
+		parts = append(parts, "//", source)
+	}
+
 	// make sure that each line is sanitised
 	for i, part := range parts {
 		parts[i] = stripNewLines(part)
@@ -864,6 +892,15 @@ func (o OperationDefinition) GenerateFunctionComment(originalFunctionName string
 	return strings.Join(parts, "\n")
 }
 
+// SourceComment returns the "// Source: file:line:column" line of the
+// operation's doc comments with the doc-comment-origins output option, or "".
+func (o OperationDefinition) SourceComment() string {
+	if o.Spec == nil {
+		return ""
+	}
+	return sourceComment(o.Spec.Origin)
+}
+
 // DeprecationComment returns a Go-style deprecation comment if the operation is deprecated, otherwise returns an empty string.
 func (o *OperationDefinition) DeprecationComment() string {
 	if o.Spec == nil || !o.Spec.Deprecated {
@@ -995,7 +1032,7 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 					// equivalent block in GenerateResponseDefinitions for
 					// rationale.
 					if !IsGoTypeReference(responseRef.Ref) && responseSchema.RefType == "" &&
-						(len(responseSchema.UnionElements) != 0 || responseSchema.HasAdditionalProperties ||
+						(len(responseSchema.UnionElements) != 0 || responseSchema.SealedUnion != nil || isSealedUnionHolder(responseSchema) || responseSchema.HasAdditionalProperties ||
 							(globalState.options.OutputOptions.GenerateTypesForAnonymousSchemas && len(responseSchema.Properties) > 0)) {
 						if externalPkg := externalPackageFor(o.PathItemRef); externalPkg != "" {
 							responseSchema.RefType = fmt.Sprintf("%s.%s", externalPkg, responseBodyTypeName)
@@ -1152,6 +1189,10 @@ func (r RequestBodyDefinition) GenerateFunctionComment(originalFunctionName stri
 		}
 	}
 
+	if source := parent.SourceComment(); source != "" {
+		parts = append(parts, "//", source)
+	}
+
 	// make sure that each line is sanitised
 	for i, part := range parts {
 		parts[i] = stripNewLines(part)
@@ -1514,8 +1555,8 @@ func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
 			if operationId == "" {
 				operationId, err = generateDefaultOperationID(opName, requestPath)
 				if err != nil {
-					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
-						opName, requestPath, err)
+					return nil, withOrigin(op.Origin, fmt.Errorf("error generating default OperationID for %s/%s: %w",
+						opName, requestPath, err))
 				}
 			} else {
 				operationId = nameNormalizer(operationId)
@@ -1548,14 +1589,14 @@ func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
 			// we're iterating over.
 			localParams, err := DescribeParameters(op.Parameters, []string{operationId + "Params"})
 			if err != nil {
-				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
-					opName, requestPath, err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error describing global parameters for %s/%s: %w",
+					opName, requestPath, err))
 			}
 			// All the parameters required by a handler are the union of the
 			// global parameters and the local parameters.
 			allParams, err := CombineOperationParameters(globalParams, localParams)
 			if err != nil {
-				return nil, err
+				return nil, withOrigin(op.Origin, err)
 			}
 
 			ensureExternalRefsInParameterDefinitions(&allParams, pathItem.Ref)
@@ -1566,19 +1607,19 @@ func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
 			pathParams := FilterParameterDefinitionByType(allParams, "path")
 			pathParams, err = SortParamsByPath(requestPath, pathParams)
 			if err != nil {
-				return nil, err
+				return nil, withOrigin(op.Origin, err)
 			}
 
 			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody, pathItem.Ref)
 			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions: %w", err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error generating body definitions: %w", err))
 			}
 
 			ensureExternalRefsInRequestBodyDefinitions(&bodyDefinitions, pathItem.Ref)
 
 			responseDefinitions, err := GenerateResponseDefinitions(operationId, op.Responses.Map(), pathItem.Ref)
 			if err != nil {
-				return nil, fmt.Errorf("error generating response definitions: %w", err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error generating response definitions: %w", err))
 			}
 
 			ensureExternalRefsInResponseDefinitions(&responseDefinitions, pathItem.Ref)
@@ -1618,6 +1659,11 @@ func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
 
 			}
 			opDef.SecurityDefinitions = filterOutUndefinedSecuritySchemes(opDef.SecurityDefinitions, definedSecuritySchemes)
+			if op.Security != nil {
+				opDef.SecurityRequirements = describeSecurityRequirements(*op.Security, definedSecuritySchemes)
+			} else {
+				opDef.SecurityRequirements = describeSecurityRequirements(swagger.Security, definedSecuritySchemes)
+			}
 
 			if op.RequestBody != nil {
 				opDef.BodyRequired = op.RequestBody.Value.Required
@@ -1705,11 +1751,11 @@ func WebhookOperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, er
 
 			localParams, err := DescribeParameters(op.Parameters, []string{operationId + "Params"})
 			if err != nil {
-				return nil, fmt.Errorf("error describing webhook %q operation params: %w", webhookName, err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error describing webhook %q operation params: %w", webhookName, err))
 			}
 			allParams, err := CombineOperationParameters(globalParams, localParams)
 			if err != nil {
-				return nil, err
+				return nil, withOrigin(op.Origin, err)
 			}
 
 			// The initiator sends to an opaque caller-supplied target URL, so
@@ -1717,18 +1763,18 @@ func WebhookOperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, er
 			// Rejecting it here beats silently dropping it, which would send
 			// requests to a URL that still contains the placeholder.
 			if pathParams := FilterParameterDefinitionByType(allParams, "path"); len(pathParams) > 0 {
-				return nil, fmt.Errorf("webhook %q operation %s declares path parameter %q: path parameters are not supported for webhooks",
-					webhookName, opName, pathParams[0].ParamName)
+				return nil, withOrigin(op.Origin, fmt.Errorf("webhook %q operation %s declares path parameter %q: path parameters are not supported for webhooks",
+					webhookName, opName, pathParams[0].ParamName))
 			}
 
 			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody, pathItem.Ref)
 			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions for webhook %q: %w", webhookName, err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error generating body definitions for webhook %q: %w", webhookName, err))
 			}
 
 			responseDefinitions, err := GenerateResponseDefinitions(operationId, op.Responses.Map(), pathItem.Ref)
 			if err != nil {
-				return nil, fmt.Errorf("error generating response definitions for webhook %q: %w", webhookName, err)
+				return nil, withOrigin(op.Origin, fmt.Errorf("error generating response definitions for webhook %q: %w", webhookName, err))
 			}
 
 			opDef := OperationDefinition{
@@ -1856,11 +1902,11 @@ func CallbackOperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, e
 
 						localParams, err := DescribeParameters(op.Parameters, []string{operationId + "Params"})
 						if err != nil {
-							return nil, fmt.Errorf("error describing callback %q operation params: %w", callbackName, err)
+							return nil, withOrigin(op.Origin, fmt.Errorf("error describing callback %q operation params: %w", callbackName, err))
 						}
 						allParams, err := CombineOperationParameters(globalParams, localParams)
 						if err != nil {
-							return nil, err
+							return nil, withOrigin(op.Origin, err)
 						}
 
 						// See the matching check in WebhookOperationDefinitions:
@@ -1868,18 +1914,18 @@ func CallbackOperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, e
 						// parameter has nowhere to go and must not be dropped
 						// silently.
 						if pathParams := FilterParameterDefinitionByType(allParams, "path"); len(pathParams) > 0 {
-							return nil, fmt.Errorf("callback %q operation %s declares path parameter %q: path parameters are not supported for callbacks",
-								callbackName, opName, pathParams[0].ParamName)
+							return nil, withOrigin(op.Origin, fmt.Errorf("callback %q operation %s declares path parameter %q: path parameters are not supported for callbacks",
+								callbackName, opName, pathParams[0].ParamName))
 						}
 
 						bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody, cbPathItem.Ref)
 						if err != nil {
-							return nil, fmt.Errorf("error generating body definitions for callback %q: %w", callbackName, err)
+							return nil, withOrigin(op.Origin, fmt.Errorf("error generating body definitions for callback %q: %w", callbackName, err))
 						}
 
 						responseDefinitions, err := GenerateResponseDefinitions(operationId, op.Responses.Map(), cbPathItem.Ref)
 						if err != nil {
-							return nil, fmt.Errorf("error generating response definitions for callback %q: %w", callbackName, err)
+							return nil, withOrigin(op.Origin, fmt.Errorf("error generating response definitions for callback %q: %w", callbackName, err))
 						}
 
 						opDef := OperationDefinition{
@@ -2123,7 +2169,7 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 			// the imported package generated the same hoisted name, so we
 			// reference it instead of redeclaring locally.
 			if !IsGoTypeReference(responseOrRef.Ref) && contentSchema.RefType == "" &&
-				(len(contentSchema.UnionElements) != 0 || contentSchema.HasAdditionalProperties ||
+				(len(contentSchema.UnionElements) != 0 || contentSchema.SealedUnion != nil || isSealedUnionHolder(contentSchema) || contentSchema.HasAdditionalProperties ||
 					(globalState.options.OutputOptions.GenerateTypesForAnonymousSchemas && len(contentSchema.Properties) > 0)) {
 				if externalPkg != "" {
 					contentSchema.RefType = fmt.Sprintf("%s.%s", externalPkg, responseBodyTypeName)
@@ -2519,6 +2565,35 @@ func GenerateStdHTTPServer(t *template.Template, operations []OperationDefinitio
 	return buf.String(), nil
 }
 
+// GenerateSpecRoutes generates RegisterSpecRoutes, serving the embedded spec,
+// for the server selected by opts, against its template tree.
+func GenerateSpecRoutes(t *template.Template, serverTemplates map[string]*template.Template, opts Configuration) (string, error) {
+	g := opts.Generate
+	tree := t
+	switch {
+	case g.ChiServer:
+		tree = serverTemplates["chi"]
+	case g.GorillaServer:
+		tree = serverTemplates["gorilla"]
+	case g.EchoServer:
+		tree = serverTemplates["echo"]
+	case g.Echo5Server:
+		tree = serverTemplates["echo5"]
+	case g.GinServer:
+		tree = serverTemplates["gin"]
+	case g.FiberServer:
+		tree = serverTemplates["fiber"]
+	case g.FiberV3Server:
+		tree = serverTemplates["fiberv3"]
+	case g.IrisServer:
+		tree = serverTemplates["iris"]
+	case g.StdHTTPServer:
+	default:
+		return "", errors.New("spec routes need a server")
+	}
+	return GenerateTemplates([]string{"spec-routes.tmpl"}, tree, nil)
+}
+
 func GenerateStrictServer(t *template.Template, serverTemplates map[string]*template.Template, operations []OperationDefinition, opts Configuration) (string, error) {
 
 	// Each strict framework renders its interface + glue templates against a
@@ -2578,6 +2653,109 @@ func GenerateClient(t *template.Template, ops []OperationDefinition) (string, er
 	return GenerateTemplates([]string{"client.tmpl"}, t, ops)
 }
 
+// GenerateClientRateLimit generates the client's rate limiting options, with
+// the client-rate-limit output option, when there are operations.
+func GenerateClientRateLimit(t *template.Template, ops []OperationDefinition) (string, error) {
+	if !globalState.options.OutputOptions.ClientRateLimit || len(ops) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"client-rate-limit.tmpl"}, t, ops)
+}
+
+// clientSecurity is the data of the client-security.tmpl template.
+type clientSecurity struct {
+	Schemes    []clientSecurityScheme
+	Operations []OperationDefinition
+}
+
+// clientSecurityScheme is a security scheme of the spec, whose credentials
+// the client attaches.
+type clientSecurityScheme struct {
+	// Name is the name of the scheme in components.securitySchemes.
+	Name string
+	// TypeName is the name of the type of its credentials.
+	TypeName string
+	// Description describes the scheme, such as "an http bearer scheme".
+	Description string
+	// Provider is the type of pkg/securityprovider whose Intercept attaches
+	// the credentials, if there's one.
+	Provider string
+	// CertificateOption is the name of the option configuring the client
+	// certificate of a mutualTLS scheme, which has no credentials to attach
+	// to requests.
+	CertificateOption string
+}
+
+// GenerateClientSecurity generates the client's WithSecurity option, which
+// attaches the credentials of the security schemes to the operations
+// requiring them, with the client-security output option, when any operation
+// does. typeNames are the types generated alongside the client, which the
+// types of the schemes mustn't be named as.
+func GenerateClientSecurity(t *template.Template, schemes openapi3.SecuritySchemes, ops []OperationDefinition, typeNames map[string]bool) (string, error) {
+	if !globalState.options.OutputOptions.ClientSecurity {
+		return "", nil
+	}
+	data := clientSecurity{}
+	for _, op := range ops {
+		if len(op.SecurityRequirements) > 0 {
+			data.Operations = append(data.Operations, op)
+		}
+	}
+	if len(data.Operations) == 0 {
+		return "", nil
+	}
+	for _, name := range SortedSecuritySchemeKeys(schemes) {
+		scheme := clientSecurityScheme{Name: name, TypeName: SchemaNameToTypeName(name) + "Credentials"}
+		if v := schemes[name].Value; v != nil {
+			scheme.Description, scheme.Provider = describeClientSecurityScheme(v)
+			if v.Type == "mutualTLS" {
+				scheme.CertificateOption = "With" + SchemaNameToTypeName(name) + "Certificate"
+			}
+		}
+		data.Schemes = append(data.Schemes, scheme)
+	}
+
+	names := map[string]string{"SecurityCredentials": ""}
+	for _, scheme := range data.Schemes {
+		if other, ok := names[scheme.TypeName]; ok {
+			return "", fmt.Errorf("the credentials of security schemes %q and %q are both named %s", other, scheme.Name, scheme.TypeName)
+		}
+		names[scheme.TypeName] = scheme.Name
+	}
+	for _, name := range SortedMapKeys(names) {
+		if !typeNames[name] {
+			continue
+		}
+		if scheme := names[name]; scheme != "" {
+			return "", fmt.Errorf("the credentials of security scheme %q are named %s, as a generated type is; rename the scheme or the type", scheme, name)
+		}
+		return "", fmt.Errorf("client-security generates the type %s, which a generated type is named as too; rename the type", name)
+	}
+	return GenerateTemplates([]string{"client-security.tmpl"}, t, data)
+}
+
+// describeClientSecurityScheme returns the Description and Provider of a
+// clientSecurityScheme of scheme.
+func describeClientSecurityScheme(scheme *openapi3.SecurityScheme) (string, string) {
+	switch scheme.Type {
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			return "an http basic scheme", "SecurityProviderBasicAuth"
+		case "bearer":
+			return "an http bearer scheme", "SecurityProviderBearerToken"
+		}
+		return fmt.Sprintf("an http %s scheme", scheme.Scheme), ""
+	case "apiKey":
+		return fmt.Sprintf("an apiKey scheme, in the %s %s", scheme.In, scheme.Name), "SecurityProviderApiKey"
+	case "oauth2":
+		return "an oauth2 scheme", "SecurityProviderOAuth2"
+	case "":
+		return "", ""
+	}
+	return fmt.Sprintf("a %s scheme", scheme.Type), ""
+}
+
 // GenerateClientWithResponses generates a client which extends the basic client which does response
 // unmarshaling.
 func GenerateClientWithResponses(t *template.Template, ops []OperationDefinition) (string, error) {
@@ -1026,3 +1026,15 @@ paths:
 		})
 	}
 }
+
+func TestDescribeSecurityRequirements(t *testing.T) {
+	defined := map[string]struct{}{"apiKey": {}, "appId": {}, "bearer": {}}
+	requirements := openapi3.SecurityRequirements{
+		{"bearer": {}},
+		{"appId": {}, "apiKey": {}},
+		{"undefined": {}},
+		{},
+	}
+	assert.Equal(t, [][]string{{"bearer"}, {"apiKey", "appId"}, {}}, describeSecurityRequirements(requirements, defined))
+	assert.Nil(t, describeSecurityRequirements(openapi3.SecurityRequirements{}, defined), "security: []")
+}
@@ -0,0 +1,94 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SourceError is an error about an element of the spec, which tells where the
+// element is declared, e.g. "api/pets.yaml:123:7: ...". It's the file of the
+// element, which may be one referred to by the spec with a $ref, rather than
+// the spec itself.
+//
+// The locations are only known when the spec was loaded with
+// openapi3.Loader.IncludeOrigin set, as util.LoadSwagger does.
+type SourceError struct {
+	Location openapi3.Location
+	Err      error
+}
+
+func (e *SourceError) Error() string {
+	return e.Position() + ": " + e.Err.Error()
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Position returns the location as "file:line:column", where the file is
+// relative to the working directory when it's within it.
+func (e *SourceError) Position() string {
+	return locationPosition(e.Location)
+}
+
+// originPosition returns the position of the element whose origin is given,
+// as SourceError.Position does, or "" when it isn't known.
+func originPosition(origin *openapi3.Origin) string {
+	if origin == nil || origin.Key == nil || origin.Key.Line == 0 {
+		return ""
+	}
+	return locationPosition(*origin.Key)
+}
+
+func locationPosition(loc openapi3.Location) string {
+	file := loc.File
+	if file == "" {
+		return fmt.Sprintf("line %d, column %d", loc.Line, loc.Column)
+	}
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return fmt.Sprintf("%s:%d:%d", file, loc.Line, loc.Column)
+}
+
+// sourceComment returns the "// Source: file:line:column" line which the
+// doc-comment-origins output option adds to the doc comment of what's
+// generated from the element whose origin is given, or "" when the option is
+// off or the location isn't known. Unlike an error's position, the file is
+// always relative to the working directory, so the generated code doesn't
+// depend on where it's generated.
+func sourceComment(origin *openapi3.Origin) string {
+	if !globalState.options.OutputOptions.DocCommentOrigins || origin == nil || origin.Key == nil || origin.Key.Line == 0 {
+		return ""
+	}
+	loc := *origin.Key
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(loc.File) {
+		if rel, err := filepath.Rel(wd, loc.File); err == nil {
+			loc.File = rel
+		}
+	}
+	loc.File = filepath.ToSlash(loc.File)
+	return "// Source: " + locationPosition(loc)
+}
+
+// withOrigin returns err as a SourceError at the element whose origin is
+// given. As errors are wrapped on their way out of nested elements, the
+// location of the innermost one is kept: err is returned as is when it has a
+// location already, and also when it's nil or origin has no location.
+func withOrigin(origin *openapi3.Origin, err error) error {
+	if err == nil || origin == nil || origin.Key == nil || origin.Key.Line == 0 {
+		return err
+	}
+	var located *SourceError
+	if errors.As(err, &located) {
+		return err
+	}
+	return &SourceError{Location: *origin.Key, Err: err}
+}
@@ -0,0 +1,123 @@
+package codegen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func writeSpecFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+const originMainSpec = `openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    $ref: "./pets.yaml#/paths/~1pets"
+`
+
+func TestGenerateErrorLocation(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"api.yaml": originMainSpec,
+		"pets.yaml": `paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                    x-go-type-skip-optional-pointer: maybe
+`,
+	})
+	swagger, err := util.LoadSwagger(filepath.Join(dir, "api.yaml"))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{SkipPrune: true},
+	})
+	require.Error(t, err)
+
+	// The error is at the property, in the file referred to by the spec.
+	var located *SourceError
+	require.True(t, errors.As(err, &located))
+	assert.Equal(t, filepath.Join(dir, "pets.yaml"), located.Location.File)
+	assert.Equal(t, 13, located.Location.Line)
+	assert.Equal(t, 19, located.Location.Column)
+	assert.Contains(t, err.Error(), filepath.Join(dir, "pets.yaml")+":13:19: invalid value for \"x-go-type-skip-optional-pointer\"")
+}
+
+func TestValidateSpecErrorLocation(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"api.yaml": originMainSpec,
+		"pets.yaml": `paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  "na\"me":
+                    type: string
+                  tag:
+                    type: string
+                    x-go-name: "not an identifier"
+`,
+	})
+	swagger, err := util.LoadSwagger(filepath.Join(dir, "api.yaml"))
+	require.NoError(t, err)
+
+	err = ValidateSpec(swagger)
+	require.Error(t, err)
+	pets := filepath.Join(dir, "pets.yaml")
+	assert.Contains(t, err.Error(), pets+`:13:19: property name in content type "application/json" in response "200" in GET path "/pets" may not contain a double quote`)
+	assert.Contains(t, err.Error(), pets+`:15:19: x-go-name in property "tag"`)
+}
+
+func TestSourceErrorPosition(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	err = withOrigin(&openapi3.Origin{Key: &openapi3.Location{
+		File: filepath.Join(wd, "api", "pets.yaml"), Line: 12, Column: 7,
+	}}, errors.New("bad schema"))
+	assert.Equal(t, filepath.Join("api", "pets.yaml")+":12:7: bad schema", err.Error())
+
+	// The innermost location is kept.
+	outer := withOrigin(&openapi3.Origin{Key: &openapi3.Location{Line: 3, Column: 1}}, err)
+	assert.Equal(t, err, outer)
+
+	// A spec loaded from data has no file.
+	err = withOrigin(&openapi3.Origin{Key: &openapi3.Location{Line: 3, Column: 5}}, errors.New("bad schema"))
+	assert.Equal(t, "line 3, column 5: bad schema", err.Error())
+
+	// Without origins, the error is as it was.
+	plain := errors.New("bad schema")
+	assert.Equal(t, plain, withOrigin(nil, plain))
+}
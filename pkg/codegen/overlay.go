@@ -0,0 +1,90 @@
+package codegen
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Overlay is an OpenAPI Overlay document
+// (https://github.com/OAI/Overlay-Specification), such as the fixes of lint
+// findings or the names pinned by PinNames, which can be given to
+// output-options.overlay.
+type Overlay struct {
+	Overlay string          `yaml:"overlay"`
+	Info    OverlayInfo     `yaml:"info"`
+	Actions []OverlayAction `yaml:"actions"`
+}
+
+// OverlayInfo is the info of an Overlay document.
+type OverlayInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// OverlayAction updates the elements of the spec which Target, a JSONPath
+// expression, selects with the fields of Update.
+type OverlayAction struct {
+	Target string         `yaml:"target" json:"target"`
+	Update map[string]any `yaml:"update" json:"update"`
+}
+
+// NewOverlay returns an Overlay document with the actions given.
+func NewOverlay(title string, actions []OverlayAction) Overlay {
+	return Overlay{
+		Overlay: "1.0.0",
+		Info:    OverlayInfo{Title: title, Version: "1.0.0"},
+		Actions: actions,
+	}
+}
+
+// overlayUpdate returns an overlay action updating the element at the JSON
+// pointer source with the field given.
+func overlayUpdate(source, field string, value any) *OverlayAction {
+	return &OverlayAction{
+		Target: pointerToJSONPath(source),
+		Update: map[string]any{field: value},
+	}
+}
+
+// jsonPathNameRE matches the names which a JSONPath can select with the
+// .name shorthand.
+var jsonPathNameRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pointerToJSONPath returns a JSONPath expression, as targets of overlay
+// actions are, selecting the element at a JSON pointer of the spec.
+func pointerToJSONPath(pointer string) string {
+	var b strings.Builder
+	b.WriteString("$")
+	tokens := strings.Split(strings.TrimPrefix(pointer, "#/"), "/")
+	for i, token := range tokens {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch {
+		case isArrayIndexToken(tokens, i):
+			b.WriteString("[" + token + "]")
+		case jsonPathNameRE.MatchString(token):
+			b.WriteString("." + token)
+		default:
+			b.WriteString("['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(token) + "']")
+		}
+	}
+	return b.String()
+}
+
+// isArrayIndexToken reports whether the token i of a JSON pointer is an index
+// of an array: of the parameters of a path item or operation, or of the
+// members of an allOf, anyOf or oneOf.
+func isArrayIndexToken(tokens []string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	if _, err := strconv.Atoi(tokens[i]); err != nil {
+		return false
+	}
+	switch tokens[i-1] {
+	case "parameters", "allOf", "anyOf", "oneOf":
+		// Unless it's a property of that name.
+		return i < 2 || tokens[i-2] != "properties"
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package codegen
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// PinNames returns the actions of an Overlay which pins the names of the
+// code generated for spec with opts, so that they stay as they are when a
+// new version of oapi-codegen, or a change to the spec, would name things
+// otherwise, e.g. by resolving enum or type name collisions differently. It
+// adds:
+//
+//   - x-go-name to the component schemas, parameters, responses and request
+//     bodies, with the names of their types
+//   - x-go-type-name to the inline schemas generated as types
+//   - x-go-name to the properties, and the parameters of operations, with the
+//     names of their fields
+//   - x-enum-varnames to the enums, with the names of their constants
+//   - operationId to the operations without one
+//
+// Properties and parameters which have an x-go-name, and enums which have
+// their names, are left as they are. The names of the types generated for an
+// operation, such as its Params, follow from its operationId.
+//
+// Like Generate, it filters the operations of spec and prunes its unused
+// components, as opts say, before generating the code.
+func PinNames(spec *openapi3.T, opts Configuration) ([]OverlayAction, error) {
+	generateMu.Lock()
+	defer generateMu.Unlock()
+
+	if err := initGlobalState(spec, opts); err != nil {
+		return nil, err
+	}
+	// Generation writes the names of the operations to the spec, so the
+	// operations without an operationId are found first.
+	ops, err := namedOperations(spec)
+	if err != nil {
+		return nil, err
+	}
+	var unnamed []namedOperation
+	for _, o := range ops {
+		if o.op.OperationID == "" {
+			unnamed = append(unnamed, o)
+		}
+	}
+
+	rb := newReportBuilder(spec)
+	if _, err := generateLocked(spec, opts, rb); err != nil {
+		return nil, err
+	}
+	report := rb.finish()
+
+	var actions []OverlayAction
+	for _, o := range unnamed {
+		if source := rb.operationPointer(o.op); source != "" {
+			actions = append(actions, *overlayUpdate(source, "operationId", o.id))
+		}
+	}
+	actions = append(actions, pinTypeNames(report.Types)...)
+	actions = append(actions, pinFieldNames(rb)...)
+	actions = append(actions, pinEnumVarNames(rb)...)
+	return actions, nil
+}
+
+// operationTypeSourceRE matches the JSON pointers of the elements of an
+// operation whose types are named after it: its parameters, and the schemas
+// of its request and response bodies.
+var operationTypeSourceRE = regexp.MustCompile(`/(parameters|requestBody/content/[^/]+/schema|responses/[^/]+/content/[^/]+/schema)$`)
+
+// pinTypeNames pins the names of the types generated from the sources given.
+func pinTypeNames(types []ReportType) []OverlayAction {
+	// A response or request body component is named with one x-go-name, so
+	// it's only pinned when it has one type.
+	bodyTypes := map[string][]string{}
+	for _, rt := range types {
+		if tokens := strings.Split(rt.Source, "/"); len(tokens) > 4 && tokens[1] == "components" &&
+			(tokens[2] == "responses" || tokens[2] == "requestBodies") {
+			component := strings.Join(tokens[:4], "/")
+			bodyTypes[component] = append(bodyTypes[component], rt.Name)
+		}
+	}
+
+	var actions []OverlayAction
+	for _, rt := range types {
+		tokens := strings.Split(rt.Source, "/")
+		switch {
+		case rt.Source == "" || len(tokens) < 4:
+		case tokens[1] == "components" && len(tokens) == 4 && (tokens[2] == "schemas" || tokens[2] == "parameters"):
+			actions = append(actions, *overlayUpdate(rt.Source, extGoName, rt.Name))
+		case tokens[1] == "components" && (tokens[2] == "responses" || tokens[2] == "requestBodies") && isNamedSchemaPointer(rt.Source):
+			if component := strings.Join(tokens[:4], "/"); len(bodyTypes[component]) == 1 {
+				actions = append(actions, *overlayUpdate(component, extGoName, rt.Name))
+			}
+		case tokens[1] == "components" && tokens[2] == "securitySchemes":
+		case tokens[1] != "components" && operationTypeSourceRE.MatchString(rt.Source):
+		default:
+			actions = append(actions, *overlayUpdate(rt.Source, extGoTypeName, rt.Name))
+		}
+	}
+	return actions
+}
+
+// pinFieldNames pins the names of the fields of the properties of the
+// schemas, and of the parameters of the path items and operations, of the
+// spec.
+func pinFieldNames(rb *reportBuilder) []OverlayAction {
+	var actions []OverlayAction
+	schemas := rb.schemasBySource()
+	for _, source := range SortedMapKeys(schemas) {
+		properties := schemas[source].Properties
+		for _, name := range SortedMapKeys(properties) {
+			ref := properties[name]
+			if hasGoName(ref) {
+				continue
+			}
+			p := Property{JsonFieldName: name, Extensions: combinedSchemaExtensions(ref)}
+			actions = append(actions, *overlayUpdate(joinPointer(source, "properties", name), extGoName, p.GoFieldName()))
+		}
+	}
+
+	pinParameters := func(params openapi3.Parameters, pointer string) {
+		for i, ref := range params {
+			if ref == nil || ref.Ref != "" || ref.Value == nil {
+				// A parameter component's x-go-name names its type.
+				continue
+			}
+			if _, ok := ref.Value.Extensions[extGoName]; ok {
+				continue
+			}
+			pd := ParameterDefinition{ParamName: ref.Value.Name, Spec: ref.Value}
+			actions = append(actions, *overlayUpdate(joinPointer(pointer, "parameters", strconv.Itoa(i)), extGoName, pd.GoName()))
+		}
+	}
+	items := make(map[string]*openapi3.PathItem, len(rb.pathItems))
+	for item, source := range rb.pathItems {
+		items[source] = item
+	}
+	for _, source := range SortedMapKeys(items) {
+		item := items[source]
+		pinParameters(item.Parameters, source)
+		ops := item.Operations()
+		for _, method := range SortedMapKeys(ops) {
+			pinParameters(ops[method].Parameters, joinPointer(source, strings.ToLower(method)))
+		}
+	}
+	return actions
+}
+
+// hasGoName reports whether a property has an x-go-name of its own, rather
+// than one of the component it refers to.
+func hasGoName(ref *openapi3.SchemaRef) bool {
+	if _, ok := ref.Extensions[extGoName]; ok {
+		return true
+	}
+	if ref.Ref == "" && ref.Value != nil {
+		_, ok := ref.Value.Extensions[extGoName]
+		return ok
+	}
+	return false
+}
+
+// pinEnumVarNames pins the names of the constants of the enums of the spec.
+func pinEnumVarNames(rb *reportBuilder) []OverlayAction {
+	var actions []OverlayAction
+	schemas := rb.schemasBySource()
+	for _, source := range SortedMapKeys(schemas) {
+		schema := schemas[source]
+		if len(schema.Enum) == 0 {
+			continue
+		}
+		if slices.ContainsFunc([]string{extEnumVarNames, extEnumNames}, func(key string) bool {
+			_, ok := schema.Extensions[key]
+			return ok
+		}) {
+			continue
+		}
+		// Values which share a constant can't be named on their own.
+		if varNames, _ := enumVarNames(schema); len(varNames) == len(schema.Enum) {
+			actions = append(actions, *overlayUpdate(source, extEnumVarNames, varNames))
+		}
+	}
+	return actions
+}
@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+const pinSpec = `openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: page_size, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          $ref: "#/components/responses/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        pet_id: {type: string}
+        owner:
+          $ref: "#/components/schemas/Owner"
+        size:
+          type: string
+          enum: [big-dog, big_dog, small]
+    Owner:
+      type: object
+      properties:
+        name: {type: string}
+  responses:
+    Pet:
+      description: A pet
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Pet"
+`
+
+func TestPinNames(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{"api.yaml": pinSpec})
+	specPath := filepath.Join(dir, "api.yaml")
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true},
+		OutputOptions: OutputOptions{
+			ResolveTypeNameCollisions: true,
+		},
+	}
+
+	swagger, err := util.LoadSwagger(specPath)
+	require.NoError(t, err)
+	before, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	swagger, err = util.LoadSwagger(specPath)
+	require.NoError(t, err)
+	actions, err := PinNames(swagger, opts)
+	require.NoError(t, err)
+
+	pins := map[string]any{}
+	for _, a := range actions {
+		for k, v := range a.Update {
+			pins[a.Target+" "+k] = v
+		}
+	}
+	assert.Equal(t, map[string]any{
+		"$.paths['/pets'].get operationId":                         "GetPets",
+		"$.components.schemas.Owner x-go-name":                     "Owner",
+		"$.components.schemas.Pet x-go-name":                       "Pet",
+		"$.components.responses.Pet x-go-name":                     "PetResponse",
+		"$.components.schemas.Pet.properties.size x-go-type-name":  "PetSize",
+		"$.components.schemas.Owner.properties.name x-go-name":     "Name",
+		"$.components.schemas.Pet.properties.owner x-go-name":      "Owner",
+		"$.components.schemas.Pet.properties.pet_id x-go-name":     "PetId",
+		"$.components.schemas.Pet.properties.size x-go-name":       "Size",
+		"$.paths['/pets'].get.parameters[0] x-go-name":             "PageSize",
+		"$.components.schemas.Pet.properties.size x-enum-varnames": []string{"BigDog", "BigDog1", "Small"},
+	}, pins)
+
+	// With the names pinned, the code is the same, but for the doc comments
+	// of the types named with x-go-type-name.
+	overlay, err := yaml.Marshal(NewOverlay("Pinned names", actions))
+	require.NoError(t, err)
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, overlay, 0o644))
+	pinned, err := util.LoadSwaggerWithOverlay(specPath, util.LoadSwaggerWithOverlayOpts{Path: overlayPath, Strict: true})
+	require.NoError(t, err)
+	after, err := Generate(pinned, opts)
+	require.NoError(t, err)
+
+	comments := regexp.MustCompile(`(?m)^\s*//.*\n`)
+	assert.Equal(t, comments.ReplaceAllString(before, ""), comments.ReplaceAllString(after, ""))
+}
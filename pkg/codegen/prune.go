@@ -385,17 +385,19 @@ func findComponentRefs(swagger *openapi3.T) []string {
 	return refs
 }
 
-func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
+// removeOrphanedComponents removes the components which aren't in refs, and
+// returns their refs.
+func removeOrphanedComponents(swagger *openapi3.T, refs []string) []string {
 	if swagger.Components == nil {
-		return 0
+		return nil
 	}
 
-	countRemoved := 0
+	var removed []string
 
 	for key := range swagger.Components.Schemas {
 		ref := fmt.Sprintf("#/components/schemas/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Schemas, key)
 		}
 	}
@@ -403,7 +405,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Parameters {
 		ref := fmt.Sprintf("#/components/parameters/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Parameters, key)
 		}
 	}
@@ -414,7 +416,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	// for key, _ := range swagger.Components.SecuritySchemes {
 	// 	ref := fmt.Sprintf("#/components/securitySchemes/%s", key)
 	// 	if !slices.Contains(refs, ref) {
-	// 		countRemoved++
+	// 		removed = append(removed, ref)
 	// 		delete(swagger.Components.SecuritySchemes, key)
 	// 	}
 	// }
@@ -422,7 +424,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.RequestBodies {
 		ref := fmt.Sprintf("#/components/requestBodies/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.RequestBodies, key)
 		}
 	}
@@ -430,7 +432,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Responses {
 		ref := fmt.Sprintf("#/components/responses/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Responses, key)
 		}
 	}
@@ -438,7 +440,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Headers {
 		ref := fmt.Sprintf("#/components/headers/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Headers, key)
 		}
 	}
@@ -446,7 +448,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Examples {
 		ref := fmt.Sprintf("#/components/examples/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Examples, key)
 		}
 	}
@@ -454,7 +456,7 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Links {
 		ref := fmt.Sprintf("#/components/links/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Links, key)
 		}
 	}
@@ -462,20 +464,24 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	for key := range swagger.Components.Callbacks {
 		ref := fmt.Sprintf("#/components/callbacks/%s", key)
 		if !slices.Contains(refs, ref) {
-			countRemoved++
+			removed = append(removed, ref)
 			delete(swagger.Components.Callbacks, key)
 		}
 	}
 
-	return countRemoved
+	return removed
 }
 
-func pruneUnusedComponents(swagger *openapi3.T) {
+// pruneUnusedComponents removes the components which aren't used, and returns
+// their refs.
+func pruneUnusedComponents(swagger *openapi3.T) []string {
+	var pruned []string
 	for {
 		refs := findComponentRefs(swagger)
-		countRemoved := removeOrphanedComponents(swagger, refs)
-		if countRemoved < 1 {
-			break
+		removed := removeOrphanedComponents(swagger, refs)
+		if len(removed) == 0 {
+			return pruned
 		}
+		pruned = append(pruned, removed...)
 	}
 }
@@ -0,0 +1,197 @@
+package codegen
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultPublicSpecExcludeExtensions are the ExcludeExtensions of a
+// PublicSpecOptions which has none.
+var defaultPublicSpecExcludeExtensions = []string{"x-internal"}
+
+// publicSpecExtensionPrefixes are the prefixes of the extensions, of
+// oapi-codegen, that the public spec is without.
+var publicSpecExtensionPrefixes = []string{"x-go-", "x-oapi-codegen-"}
+
+// publicSpec returns the public variant of the JSON spec data, for opts.
+func publicSpec(data []byte, opts PublicSpecOptions) ([]byte, error) {
+	// It's a copy, which is changed, of the spec.
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the spec: %w", err)
+	}
+	extensions := opts.ExcludeExtensions
+	if len(extensions) == 0 {
+		extensions = defaultPublicSpecExcludeExtensions
+	}
+
+	tags := sliceToMap(opts.ExcludeTags)
+	excluded := func(op *openapi3.Operation) bool {
+		return operationHasTag(op, tags) || hasExtension(op.Extensions, extensions)
+	}
+	if spec.Paths != nil {
+		for path, pathItem := range spec.Paths.Map() {
+			removeOperations(pathItem, excluded)
+			if len(pathItem.Operations()) == 0 {
+				spec.Paths.Delete(path)
+			}
+		}
+	}
+	for name, pathItem := range spec.Webhooks {
+		removeOperations(pathItem, excluded)
+		if len(pathItem.Operations()) == 0 {
+			delete(spec.Webhooks, name)
+		}
+	}
+	spec.Tags = slices.DeleteFunc(spec.Tags, func(tag *openapi3.Tag) bool {
+		return tags[tag.Name]
+	})
+
+	pruneUnusedComponents(spec)
+	if used := excludedComponents(spec.Components, extensions); len(used) > 0 {
+		return nil, fmt.Errorf("the public spec uses components with %s: %s", strings.Join(extensions, ", "), strings.Join(used, ", "))
+	}
+
+	stripCodegenExtensions(spec)
+	return spec.MarshalJSON()
+}
+
+// removeOperations removes the operations of pathItem which are excluded.
+func removeOperations(pathItem *openapi3.PathItem, excluded func(*openapi3.Operation) bool) {
+	for method, op := range pathItem.Operations() {
+		if excluded(op) {
+			pathItem.SetOperation(method, nil)
+		}
+	}
+}
+
+// hasExtension returns whether extensions have any of names, set to other
+// than false.
+func hasExtension(extensions map[string]any, names []string) bool {
+	for _, name := range names {
+		if v, ok := extensions[name]; ok && v != false && v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedComponents returns the refs of the components which have any of
+// the extensions, sorted.
+func excludedComponents(components *openapi3.Components, extensions []string) []string {
+	if components == nil {
+		return nil
+	}
+	var refs []string
+	add := func(kind string, name string, ext map[string]any) {
+		if hasExtension(ext, extensions) {
+			refs = append(refs, "#/components/"+kind+"/"+name)
+		}
+	}
+	for name, ref := range components.Schemas {
+		if ref.Value != nil {
+			add("schemas", name, ref.Value.Extensions)
+		}
+	}
+	for name, ref := range components.Parameters {
+		if ref.Value != nil {
+			add("parameters", name, ref.Value.Extensions)
+		}
+	}
+	for name, ref := range components.RequestBodies {
+		if ref.Value != nil {
+			add("requestBodies", name, ref.Value.Extensions)
+		}
+	}
+	for name, ref := range components.Responses {
+		if ref.Value != nil {
+			add("responses", name, ref.Value.Extensions)
+		}
+	}
+	for name, ref := range components.Headers {
+		if ref.Value != nil {
+			add("headers", name, ref.Value.Extensions)
+		}
+	}
+	slices.Sort(refs)
+	return refs
+}
+
+// stripCodegenExtensions removes the extensions of oapi-codegen from spec:
+// from its operations, and the schemas, parameters, request bodies,
+// responses and headers they, and the components, have.
+func stripCodegenExtensions(spec *openapi3.T) {
+	strip := func(extensions map[string]any) {
+		for name := range maps.Keys(extensions) {
+			for _, prefix := range publicSpecExtensionPrefixes {
+				if strings.HasPrefix(name, prefix) {
+					delete(extensions, name)
+				}
+			}
+		}
+	}
+
+	var pathItems []*openapi3.PathItem
+	if spec.Paths != nil {
+		pathItems = slices.Collect(maps.Values(spec.Paths.Map()))
+	}
+	pathItems = append(pathItems, slices.Collect(maps.Values(spec.Webhooks))...)
+	for _, pathItem := range pathItems {
+		strip(pathItem.Extensions)
+		for _, op := range pathItem.Operations() {
+			strip(op.Extensions)
+			// The webhooks aren't walked, so their operations are here.
+			_ = walkOperation(op, func(ref RefWrapper) (bool, error) {
+				stripRefExtensions(ref, strip)
+				return true, nil
+			})
+		}
+		for _, param := range pathItem.Parameters {
+			_ = walkParameterRef(param, func(ref RefWrapper) (bool, error) {
+				stripRefExtensions(ref, strip)
+				return true, nil
+			})
+		}
+	}
+	_ = walkComponents(spec.Components, func(ref RefWrapper) (bool, error) {
+		stripRefExtensions(ref, strip)
+		return true, nil
+	})
+}
+
+// stripRefExtensions strips the extensions of the value of ref.
+func stripRefExtensions(ref RefWrapper, strip func(map[string]any)) {
+	switch r := ref.SourceRef.(type) {
+	case *openapi3.SchemaRef:
+		if r.Value != nil {
+			strip(r.Value.Extensions)
+		}
+	case *openapi3.ParameterRef:
+		if r.Value != nil {
+			strip(r.Value.Extensions)
+		}
+	case *openapi3.RequestBodyRef:
+		if r.Value != nil {
+			strip(r.Value.Extensions)
+		}
+	case *openapi3.ResponseRef:
+		if r.Value != nil {
+			strip(r.Value.Extensions)
+		}
+	case *openapi3.HeaderRef:
+		if r.Value != nil {
+			strip(r.Value.Extensions)
+		}
+	}
+}
+
+// publicSpecFileName returns the name of the file of the public spec, next to
+// the embedded spec file name: openapi.json's is openapi.public.json.
+func publicSpecFileName(name string) string {
+	ext := name[strings.LastIndex(name, "."):]
+	return strings.TrimSuffix(name, ext) + ".public" + ext
+}
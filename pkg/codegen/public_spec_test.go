@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const publicSpecTestSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "x-go-name": "ListAllPets",
+        "responses": {"200": {"description": "The pets", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+      },
+      "post": {
+        "operationId": "addPet",
+        "x-internal": false,
+        "responses": {"204": {"description": "Added"}}
+      }
+    },
+    "/internal": {
+      "get": {
+        "operationId": "getInternal",
+        "x-internal": true,
+        "responses": {"200": {"description": "Internal", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Internal"}}}}}
+      }
+    },
+    "/admin": {
+      "get": {
+        "operationId": "getAdmin",
+        "tags": ["admin"],
+        "responses": {"200": {"description": "Admin", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Admin"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "x-go-type-name": "Animal", "properties": {"id": {"type": "integer", "x-go-type": "int64", "x-oapi-codegen-extra-tags": {"db": "id"}, "x-order": 1}}},
+      "Internal": {"type": "object", "x-internal": true},
+      "Admin": {"type": "object"}
+    }
+  }
+}`
+
+func TestPublicSpec(t *testing.T) {
+	data, err := publicSpec([]byte(publicSpecTestSpec), PublicSpecOptions{ExcludeTags: []string{"admin"}})
+	require.NoError(t, err)
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+
+	pets := spec.Paths.Value("/pets")
+	require.NotNil(t, pets)
+	assert.NotNil(t, pets.Get)
+	assert.NotNil(t, pets.Post, "x-internal: false doesn't exclude it")
+	assert.Nil(t, spec.Paths.Value("/internal"))
+	assert.Nil(t, spec.Paths.Value("/admin"))
+
+	assert.Equal(t, []string{"Pet"}, SortedMapKeys(spec.Components.Schemas))
+	assert.NotContains(t, pets.Get.Extensions, "x-go-name")
+	pet := spec.Components.Schemas["Pet"].Value
+	assert.NotContains(t, pet.Extensions, "x-go-type-name")
+	id := pet.Properties["id"].Value
+	assert.NotContains(t, id.Extensions, "x-go-type")
+	assert.NotContains(t, id.Extensions, "x-oapi-codegen-extra-tags")
+	assert.Contains(t, id.Extensions, "x-order", "other extensions are kept")
+}
+
+func TestPublicSpecExcludeExtensions(t *testing.T) {
+	// With other extensions, x-internal isn't excluded.
+	data, err := publicSpec([]byte(publicSpecTestSpec), PublicSpecOptions{ExcludeExtensions: []string{"x-go-name"}})
+	require.NoError(t, err)
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	assert.Nil(t, spec.Paths.Value("/pets").Get)
+	assert.NotNil(t, spec.Paths.Value("/internal"))
+}
+
+func TestPublicSpecUsedExcludedComponent(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {"200": {"description": "The pets", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Internal"}}}}}
+      }
+    }
+  },
+  "components": {"schemas": {"Internal": {"type": "object", "x-internal": true}}}
+}`
+	_, err := publicSpec([]byte(spec), PublicSpecOptions{})
+	assert.ErrorContains(t, err, "the public spec uses components with x-internal: #/components/schemas/Internal")
+}
+
+func TestPublicSpecFileName(t *testing.T) {
+	assert.Equal(t, "openapi.public.json", publicSpecFileName("openapi.json"))
+	assert.Equal(t, "spec.public.yml", publicSpecFileName("spec.yml"))
+}
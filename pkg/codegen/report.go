@@ -0,0 +1,408 @@
+package codegen
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Report describes what a Generate run produced, where in the spec it came
+// from, and what it left out, for tools such as API catalogs and review bots.
+// Its locations in the spec are JSON pointers, e.g.
+// "#/components/schemas/Pet" or "#/paths/~1pets/get".
+type Report struct {
+	// Types are the Go types generated for the models, sorted by name.
+	Types []ReportType `json:"types"`
+	// Operations are the operations of the paths, webhooks and callbacks.
+	Operations []ReportOperation `json:"operations"`
+	// NameResolutions are the type names changed by
+	// `resolve-type-name-collisions` to avoid a collision.
+	NameResolutions []ReportNameResolution `json:"name-resolutions"`
+	// PrunedComponents are the unused components removed from the spec.
+	PrunedComponents []string `json:"pruned-components"`
+	// SkippedMediaTypes are the request and response media types which
+	// don't have a Go type, whose bodies are left to the user as bytes.
+	SkippedMediaTypes []ReportMediaType `json:"skipped-media-types"`
+	// Warnings are the warnings of the configuration, sorted by code.
+	Warnings []ReportWarning `json:"warnings"`
+}
+
+// ReportType is a generated Go type.
+type ReportType struct {
+	Name string `json:"name"`
+	// Source is the schema the type is generated from. It's empty for the
+	// few types which have no place in the spec.
+	Source string `json:"source,omitempty"`
+}
+
+// ReportOperation is an operation, and the name of its handler in the
+// generated server interfaces and client.
+type ReportOperation struct {
+	// Kind is "path", "webhook" or "callback".
+	Kind string `json:"kind"`
+	// OperationID is the operationId of the spec, if it has one.
+	OperationID string `json:"operation-id,omitempty"`
+	Method      string `json:"method"`
+	// Path is the path of a path operation, the name of a webhook, or the
+	// name of a callback.
+	Path    string `json:"path"`
+	Handler string `json:"handler"`
+	Source  string `json:"source,omitempty"`
+}
+
+// ReportNameResolution is a type renamed to resolve a name collision.
+type ReportNameResolution struct {
+	Source string `json:"source"`
+	// Context is where the schema is used, e.g. "Response".
+	Context string `json:"context"`
+	// Candidate is the name the type would have had, and Name its name.
+	Candidate string `json:"candidate"`
+	Name      string `json:"name"`
+}
+
+// ReportMediaType is a media type of a request body or a response.
+type ReportMediaType struct {
+	Source    string `json:"source"`
+	MediaType string `json:"media-type"`
+}
+
+// ReportWarning is a warning of the configuration.
+type ReportWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// reportBuilder collects the Report during generation. Its methods do
+// nothing on a nil builder, which is the case unless a report was asked for.
+type reportBuilder struct {
+	report    Report
+	spec      *openapi3.T
+	typeNames map[string]bool
+	skipped   map[string]bool
+	// schemas, operations and pathItems are the JSON pointers of those
+	// elements of the spec, indexed once it's filtered and pruned.
+	schemas    map[*openapi3.Schema]string
+	operations map[*openapi3.Operation]string
+	pathItems  map[*openapi3.PathItem]string
+}
+
+func newReportBuilder(spec *openapi3.T) *reportBuilder {
+	return &reportBuilder{
+		spec:      spec,
+		typeNames: map[string]bool{},
+		skipped:   map[string]bool{},
+	}
+}
+
+// addType records a generated type, unless one of the same name was.
+func (rb *reportBuilder) addType(name, source string) {
+	if rb == nil || name == "" || rb.typeNames[name] {
+		return
+	}
+	rb.typeNames[name] = true
+	rb.report.Types = append(rb.report.Types, ReportType{Name: name, Source: source})
+}
+
+// addTypes records generated types, found in the spec by their schemas.
+func (rb *reportBuilder) addTypes(types []TypeDefinition) {
+	if rb == nil {
+		return
+	}
+	for _, td := range types {
+		rb.addType(td.TypeName, rb.schemaPointer(td.Schema.OAPISchema))
+	}
+}
+
+// addOperation records an operation, its skipped media types, and with
+// models, the types generated for it.
+func (rb *reportBuilder) addOperation(op OperationDefinition, models bool) {
+	if rb == nil {
+		return
+	}
+	source := rb.operationPointer(op.Spec)
+	ro := ReportOperation{
+		Kind:        "path",
+		OperationID: op.SpecOperationId,
+		Method:      op.Method,
+		Path:        op.Path,
+		Handler:     op.HandlerName(),
+		Source:      source,
+	}
+	switch {
+	case op.IsWebhook:
+		ro.Kind, ro.Path = "webhook", op.WebhookName
+	case op.IsCallback:
+		ro.Kind, ro.Path = "callback", op.CallbackName
+	}
+	rb.report.Operations = append(rb.report.Operations, ro)
+
+	for _, body := range op.Bodies {
+		if !body.HasModel() {
+			rb.addSkippedMediaType(joinPointer(source, "requestBody", "content", body.ContentType), body.ContentType)
+		} else if models {
+			rb.addType(body.TypeDef(op.OperationId).TypeName, joinPointer(source, "requestBody", "content", body.ContentType, "schema"))
+		}
+	}
+	for _, response := range op.Responses {
+		for _, content := range response.Contents {
+			if content.NameTag == "" {
+				rb.addSkippedMediaType(joinPointer(source, "responses", response.StatusCode, "content", content.ContentType), content.ContentType)
+			}
+		}
+	}
+
+	if models {
+		// The Params type has no schema of its own.
+		paramsType := op.OperationId + "Params"
+		for _, td := range op.TypeDefinitions {
+			if td.TypeName == paramsType {
+				rb.addType(paramsType, joinPointer(source, "parameters"))
+			}
+		}
+	}
+}
+
+func (rb *reportBuilder) addSkippedMediaType(source, mediaType string) {
+	if rb == nil || rb.skipped[source] {
+		return
+	}
+	rb.skipped[source] = true
+	rb.report.SkippedMediaTypes = append(rb.report.SkippedMediaTypes, ReportMediaType{Source: source, MediaType: mediaType})
+}
+
+func (rb *reportBuilder) addPrunedComponents(refs []string) {
+	if rb == nil {
+		return
+	}
+	rb.report.PrunedComponents = append(rb.report.PrunedComponents, refs...)
+}
+
+// addNameResolutions records the names which resolveCollisions changed.
+func (rb *reportBuilder) addNameResolutions(names []*ResolvedName) {
+	if rb == nil {
+		return
+	}
+	for _, n := range names {
+		if n.GoName == n.Candidate {
+			continue
+		}
+		rb.report.NameResolutions = append(rb.report.NameResolutions, ReportNameResolution{
+			Source:    joinPointer("#", n.Schema.Path...),
+			Context:   n.Schema.Context.String(),
+			Candidate: n.Candidate,
+			Name:      n.GoName,
+		})
+	}
+}
+
+func (rb *reportBuilder) addWarnings(warnings map[string]string) {
+	for code, message := range warnings {
+		rb.report.Warnings = append(rb.report.Warnings, ReportWarning{Code: code, Message: message})
+	}
+}
+
+// finish sorts the report, and sets its empty lists, so they're written as
+// [] rather than null.
+func (rb *reportBuilder) finish() *Report {
+	r := &rb.report
+	slices.SortStableFunc(r.Types, func(a, b ReportType) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(r.NameResolutions, func(a, b ReportNameResolution) int { return strings.Compare(a.Source, b.Source) })
+	slices.Sort(r.PrunedComponents)
+	slices.SortFunc(r.SkippedMediaTypes, func(a, b ReportMediaType) int { return strings.Compare(a.Source, b.Source) })
+	slices.SortFunc(r.Warnings, func(a, b ReportWarning) int { return strings.Compare(a.Code, b.Code) })
+	r.Types = nonNil(r.Types)
+	r.Operations = nonNil(r.Operations)
+	r.NameResolutions = nonNil(r.NameResolutions)
+	r.PrunedComponents = nonNil(r.PrunedComponents)
+	r.SkippedMediaTypes = nonNil(r.SkippedMediaTypes)
+	r.Warnings = nonNil(r.Warnings)
+	return r
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func (rb *reportBuilder) schemaPointer(schema *openapi3.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	rb.index()
+	return rb.schemas[schema]
+}
+
+func (rb *reportBuilder) operationPointer(op *openapi3.Operation) string {
+	if op == nil {
+		return ""
+	}
+	rb.index()
+	return rb.operations[op]
+}
+
+// schemasBySource returns the schemas of the spec by their JSON pointers.
+func (rb *reportBuilder) schemasBySource() map[string]*openapi3.Schema {
+	rb.index()
+	schemas := make(map[string]*openapi3.Schema, len(rb.schemas))
+	for schema, source := range rb.schemas {
+		schemas[source] = schema
+	}
+	return schemas
+}
+
+func (rb *reportBuilder) pathItemPointer(item *openapi3.PathItem) string {
+	if item == nil {
+		return ""
+	}
+	rb.index()
+	return rb.pathItems[item]
+}
+
+// index finds the JSON pointers of the schemas, operations and path items of
+// the spec.
+func (rb *reportBuilder) index() {
+	if rb.schemas != nil {
+		return
+	}
+	rb.schemas = map[*openapi3.Schema]string{}
+	rb.operations = map[*openapi3.Operation]string{}
+	rb.pathItems = map[*openapi3.PathItem]string{}
+
+	if c := rb.spec.Components; c != nil {
+		for _, name := range SortedMapKeys(c.Schemas) {
+			rb.indexSchema(c.Schemas[name], "#", "components", "schemas", name)
+		}
+		for _, name := range SortedMapKeys(c.Parameters) {
+			if p := c.Parameters[name]; p != nil && p.Value != nil {
+				rb.indexParameter(p.Value, "#", "components", "parameters", name)
+			}
+		}
+		for _, name := range SortedMapKeys(c.Headers) {
+			if h := c.Headers[name]; h != nil && h.Value != nil {
+				rb.indexParameter(&h.Value.Parameter, "#", "components", "headers", name)
+			}
+		}
+		for _, name := range SortedMapKeys(c.RequestBodies) {
+			if b := c.RequestBodies[name]; b != nil && b.Value != nil {
+				rb.indexContent(b.Value.Content, "#", "components", "requestBodies", name)
+			}
+		}
+		for _, name := range SortedMapKeys(c.Responses) {
+			if r := c.Responses[name]; r != nil && r.Value != nil {
+				rb.indexContent(r.Value.Content, "#", "components", "responses", name)
+			}
+		}
+	}
+	if rb.spec.Paths != nil {
+		for _, path := range SortedMapKeys(rb.spec.Paths.Map()) {
+			rb.indexPathItem(rb.spec.Paths.Value(path), "#", "paths", path)
+		}
+	}
+	for _, name := range SortedMapKeys(rb.spec.Webhooks) {
+		rb.indexPathItem(rb.spec.Webhooks[name], "#", "webhooks", name)
+	}
+}
+
+func (rb *reportBuilder) indexPathItem(item *openapi3.PathItem, pointer ...string) {
+	if item == nil {
+		return
+	}
+	if _, ok := rb.pathItems[item]; !ok {
+		rb.pathItems[item] = joinPointer(pointer[0], pointer[1:]...)
+	}
+	for i, p := range item.Parameters {
+		if p != nil && p.Value != nil {
+			rb.indexParameter(p.Value, append(pointer, "parameters", strconv.Itoa(i))...)
+		}
+	}
+	ops := item.Operations()
+	for _, method := range SortedMapKeys(ops) {
+		op := ops[method]
+		opPointer := append(slices.Clone(pointer), strings.ToLower(method))
+		if _, ok := rb.operations[op]; !ok {
+			rb.operations[op] = joinPointer(opPointer[0], opPointer[1:]...)
+		}
+		for i, p := range op.Parameters {
+			if p != nil && p.Value != nil {
+				rb.indexParameter(p.Value, append(opPointer, "parameters", strconv.Itoa(i))...)
+			}
+		}
+		if op.RequestBody != nil && op.RequestBody.Value != nil {
+			rb.indexContent(op.RequestBody.Value.Content, append(opPointer, "requestBody")...)
+		}
+		if op.Responses != nil {
+			for _, code := range SortedMapKeys(op.Responses.Map()) {
+				if r := op.Responses.Value(code); r != nil && r.Value != nil {
+					rb.indexContent(r.Value.Content, append(opPointer, "responses", code)...)
+				}
+			}
+		}
+		for _, name := range SortedMapKeys(op.Callbacks) {
+			cb := op.Callbacks[name]
+			if cb == nil || cb.Value == nil {
+				continue
+			}
+			for _, expr := range SortedMapKeys(cb.Value.Map()) {
+				rb.indexPathItem(cb.Value.Value(expr), append(opPointer, "callbacks", name, expr)...)
+			}
+		}
+	}
+}
+
+func (rb *reportBuilder) indexParameter(p *openapi3.Parameter, pointer ...string) {
+	rb.indexSchema(p.Schema, append(pointer, "schema")...)
+	rb.indexContent(p.Content, pointer...)
+}
+
+func (rb *reportBuilder) indexContent(content openapi3.Content, pointer ...string) {
+	for _, mediaType := range SortedMapKeys(content) {
+		if mt := content[mediaType]; mt != nil {
+			rb.indexSchema(mt.Schema, append(pointer, "content", mediaType, "schema")...)
+		}
+	}
+}
+
+func (rb *reportBuilder) indexSchema(ref *openapi3.SchemaRef, pointer ...string) {
+	// A schema referred to within the spec is indexed where it's declared.
+	if ref == nil || ref.Value == nil || strings.HasPrefix(ref.Ref, "#/") {
+		return
+	}
+	if _, ok := rb.schemas[ref.Value]; ok {
+		return
+	}
+	pointer = slices.Clone(pointer)
+	rb.schemas[ref.Value] = joinPointer(pointer[0], pointer[1:]...)
+
+	s := ref.Value
+	for _, name := range SortedMapKeys(s.Properties) {
+		rb.indexSchema(s.Properties[name], append(pointer, "properties", name)...)
+	}
+	rb.indexSchema(s.Items, append(pointer, "items")...)
+	rb.indexSchema(s.AdditionalProperties.Schema, append(pointer, "additionalProperties")...)
+	rb.indexSchema(s.Not, append(pointer, "not")...)
+	for i, sub := range s.AllOf {
+		rb.indexSchema(sub, append(pointer, "allOf", strconv.Itoa(i))...)
+	}
+	for i, sub := range s.AnyOf {
+		rb.indexSchema(sub, append(pointer, "anyOf", strconv.Itoa(i))...)
+	}
+	for i, sub := range s.OneOf {
+		rb.indexSchema(sub, append(pointer, "oneOf", strconv.Itoa(i))...)
+	}
+}
+
+// joinPointer appends the tokens to a JSON pointer, escaping them as RFC
+// 6901 requires.
+func joinPointer(pointer string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(pointer)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
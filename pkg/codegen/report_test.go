@@ -0,0 +1,162 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reportSpec = `
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+        - {name: verbose, in: query, schema: {type: boolean}}
+      responses:
+        "200":
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+            application/xml:
+              schema:
+                $ref: "#/components/schemas/Pet"
+    put:
+      operationId: putPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+          image/png:
+            schema: {type: string, format: binary}
+      responses:
+        "204":
+          description: Updated
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        owner:
+          type: object
+          x-go-type-name: Owner
+          properties:
+            name: {type: string}
+    Unused:
+      type: string
+`
+
+func TestGenerateWithReport(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(reportSpec))
+	require.NoError(t, err)
+
+	code, report, err := GenerateWithReport(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, "type Pet struct {")
+
+	assert.Equal(t, []ReportType{
+		{Name: "GetPetParams", Source: "#/paths/~1pets~1{id}/get/parameters"},
+		{Name: "Owner", Source: "#/components/schemas/Pet/properties/owner"},
+		{Name: "Pet", Source: "#/components/schemas/Pet"},
+		{Name: "PutPetJSONRequestBody", Source: "#/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema"},
+	}, report.Types)
+	assert.Equal(t, []ReportOperation{
+		{Kind: "path", OperationID: "getPet", Method: "GET", Path: "/pets/{id}", Handler: "GetPet", Source: "#/paths/~1pets~1{id}/get"},
+		{Kind: "path", OperationID: "putPet", Method: "PUT", Path: "/pets/{id}", Handler: "PutPet", Source: "#/paths/~1pets~1{id}/put"},
+	}, report.Operations)
+	assert.Equal(t, []string{"#/components/schemas/Unused"}, report.PrunedComponents)
+	assert.Equal(t, []ReportMediaType{
+		{Source: "#/paths/~1pets~1{id}/get/responses/200/content/application~1xml", MediaType: "application/xml"},
+		{Source: "#/paths/~1pets~1{id}/put/requestBody/content/image~1png", MediaType: "image/png"},
+	}, report.SkippedMediaTypes)
+	assert.Empty(t, report.NameResolutions)
+	assert.NotNil(t, report.NameResolutions, "empty lists are written as []")
+	assert.Empty(t, report.Warnings)
+}
+
+func TestGenerateWithReportNameResolutions(t *testing.T) {
+	const spec = `
+openapi: "3.0.0"
+info: {title: Collisions, version: 1.0.0}
+paths:
+  /pet:
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+  responses:
+    Pet:
+      description: A pet
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Pet"
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Pet"
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, report, err := GenerateWithReport(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{
+			SkipPrune:                 true,
+			ResolveTypeNameCollisions: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ReportNameResolution{
+		{Source: "#/components/requestBodies/Pet/content/application~1json", Context: "RequestBody", Candidate: "Pet", Name: "PetRequestBody"},
+		{Source: "#/components/responses/Pet/content/application~1json", Context: "Response", Candidate: "Pet", Name: "PetResponse"},
+	}, report.NameResolutions)
+}
+
+func TestGenerateWithReportWarnings(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(reportSpec))
+	require.NoError(t, err)
+
+	_, report, err := GenerateWithReport(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Client: true},
+		OutputOptions: OutputOptions{
+			GenerateTypesForAnonymousSchemas: true,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Warnings, 1)
+	assert.Equal(t, "generate-types-for-anonymous-schemas", report.Warnings[0].Code)
+	// Without models, no types are generated.
+	assert.Empty(t, report.Types)
+}
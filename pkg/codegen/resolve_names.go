@@ -18,6 +18,12 @@ type ResolvedName struct {
 // ResolveNames takes the gathered schemas and assigns unique Go type names to each.
 // It returns a map from the schema's path string to the resolved Go type name.
 func ResolveNames(schemas []*GatheredSchema) map[string]string {
+	return resolvedNameMap(resolveNames(schemas))
+}
+
+// resolveNames returns the names of the schemas, with the candidates they
+// were resolved from.
+func resolveNames(schemas []*GatheredSchema) []*ResolvedName {
 	// Step 1: Generate candidate names for all schemas
 	candidates := make([]*ResolvedName, len(schemas))
 	for i, s := range schemas {
@@ -32,8 +38,11 @@ func ResolveNames(schemas []*GatheredSchema) map[string]string {
 
 	// Step 2: Resolve collisions iteratively
 	resolveCollisions(candidates)
+	return candidates
+}
 
-	// Step 3: Build the result map
+// resolvedNameMap maps the path strings of the schemas to their names.
+func resolvedNameMap(candidates []*ResolvedName) map[string]string {
 	result := make(map[string]string, len(candidates))
 	for _, c := range candidates {
 		result[c.Schema.Path.String()] = c.GoName
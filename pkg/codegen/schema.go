@@ -32,6 +32,11 @@ type Schema struct {
 
 	UnionElements []UnionElement // Possible elements of oneOf/anyOf union
 	Discriminator *Discriminator // Describes which value is stored in a union
+	UnionVariants *UnionVariants // Matches the data of a union without a discriminator to its elements
+
+	SealedUnion    *SealedUnion // Declares a discriminated oneOf as a sealed interface
+	FixedValue     *FixedValue  // Declares a 3.1 const as a zero-size type with a fixed value
+	SealedUnionRef bool         // Whether this is a $ref to a sealed union declared elsewhere
 
 	// If this is set, the schema will declare a type via alias, eg,
 	// `type Foo = bool`. If this is not set, we will define this type via
@@ -83,9 +88,16 @@ func (s Schema) IsExternalRef() bool {
 // The .union shortcut also can't reach across packages. So we still need the
 // MarshalJSON delegator here, even though UnionElements is non-empty.
 func (s Schema) HasCustomMarshalJSON() bool {
-	if s.OAPISchema == nil {
+	// A sealed union has no MarshalJSON to delegate to; the templates wrap it
+	// in a struct with JSON methods instead (see sealedUnion.wrapper).
+	if s.OAPISchema == nil || s.IsSealedUnion() {
 		return false
 	}
+	// Like a local inline union, a local named holder of sealed unions is
+	// aliased by the strict templates, keeping its methods.
+	if s.holdsSealedUnion() {
+		return !s.IsRef() || s.IsExternalRef()
+	}
 	if len(s.UnionElements) > 0 {
 		return s.IsExternalRef()
 	}
@@ -97,7 +109,7 @@ func (s Schema) HasCustomMarshalJSON() bool {
 // Unlike strict response types, request body wrappers have no direct union
 // encoding path, so local inline unions need delegation as well.
 func (s Schema) HasCustomMarshalJSONForRequestBody() bool {
-	return len(s.UnionElements) > 0 || s.HasCustomMarshalJSON()
+	return len(s.UnionElements) > 0 || s.holdsSealedUnion() || s.HasCustomMarshalJSON()
 }
 
 func (s Schema) TypeDecl() string {
@@ -166,7 +178,8 @@ func (p Property) GoTypeDef() string {
 	if globalState.options.OutputOptions.NullableType && p.Nullable {
 		return "nullable.Nullable[" + typeDef + "]"
 	}
-	if !p.Schema.SkipOptionalPointer &&
+	// A sealed union is an interface, whose nil value already marks it absent.
+	if !p.Schema.SkipOptionalPointer && !p.Schema.IsSealedUnion() &&
 		(!p.Required || p.Nullable ||
 			(p.ReadOnly && (!p.Required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
 			p.WriteOnly) {
@@ -354,6 +367,11 @@ func (t TypeDefinition) DocComment() string {
 	if dc := t.DeprecationComment(); dc != "" {
 		comment += "\n//\n" + dc
 	}
+	if t.Schema.OAPISchema != nil {
+		if source := sourceComment(t.Schema.OAPISchema.Origin); source != "" {
+			comment += "\n//\n" + source
+		}
+	}
 	return comment
 }
 
@@ -438,9 +456,10 @@ func (s Schema) DiscriminatorStampFor(element UnionElement) *DiscriminatorStamp
 	}
 	stamp.JSONPatch = fmt.Sprintf(`{"%s":"%s"}`, d.Property, stamp.Value)
 	// Match by JSON property name: the discriminator is a JSON-level
-	// concept, and the Go field may be renamed via x-go-name.
+	// concept, and the Go field may be renamed via x-go-name. A fixed value
+	// field writes its own value, so is left alone.
 	for i := range s.Properties {
-		if s.Properties[i].JsonFieldName == d.Property {
+		if s.Properties[i].JsonFieldName == d.Property && !s.Properties[i].Schema.IsFixedValue() {
 			stamp.Property = &s.Properties[i]
 		}
 	}
@@ -748,7 +767,17 @@ func schemaPrimaryType(t *openapi3.Types) *openapi3.Types {
 	return &stripped
 }
 
+// GenerateGoSchema returns the Go type of a schema. Its errors tell where in
+// the spec the schema is; see SourceError.
 func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
+	s, err := generateGoSchema(sref, path)
+	if err != nil && sref != nil && sref.Value != nil {
+		err = withOrigin(sref.Value.Origin, err)
+	}
+	return s, err
+}
+
+func generateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// Add a fallback value in case the sref is nil.
 	// i.e. the parent schema defines a type:array, but the array has
 	// no items defined. Therefore, we have at least valid Go-Code.
@@ -794,11 +823,17 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			}
 		}
 
+		sealed, err := newSealedUnion(schema)
+		if err != nil {
+			return Schema{}, err
+		}
+
 		return Schema{
 			GoType:              refType,
 			Description:         describeWithExamples(schema.Description, schema),
 			DefineViaAlias:      true,
-			SkipOptionalPointer: skipOptionalPointer,
+			SkipOptionalPointer: skipOptionalPointer || sealed != nil || newFixedValue(schema) != nil,
+			SealedUnionRef:      sealed != nil,
 			OAPISchema:          schema,
 		}, nil
 	}
@@ -971,7 +1006,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating type for additional properties: %w", err)
 				}
-				if additionalSchema.HasAdditionalProperties || len(additionalSchema.UnionElements) != 0 {
+				if additionalSchema.HasAdditionalProperties || len(additionalSchema.UnionElements) != 0 || additionalSchema.SealedUnion != nil {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
@@ -1017,7 +1052,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 
 				required := slices.Contains(schema.Required, pName)
 
-				if (pSchema.HasAdditionalProperties || len(pSchema.UnionElements) != 0) && pSchema.RefType == "" {
+				if (pSchema.HasAdditionalProperties || len(pSchema.UnionElements) != 0 || pSchema.SealedUnion != nil) && pSchema.RefType == "" {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
@@ -1055,16 +1090,30 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				}
 			}
 
-			if schema.AnyOf != nil {
+			sealed, err := newSealedUnion(schema)
+			if err != nil {
+				return Schema{}, err
+			}
+			if sealed != nil {
+				// A sealed union is declared as an interface by typedef.tmpl,
+				// so there is no struct to build. "any" only stands in should
+				// the schema ever be rendered inline without being named.
+				outSchema.SealedUnion = sealed
+				outSchema.GoType = "any"
+				outSchema.SkipOptionalPointer = true
+			} else if schema.AnyOf != nil {
 				if err := generateUnion(&outSchema, schema.AnyOf, schema.Discriminator, path); err != nil {
 					return Schema{}, fmt.Errorf("error generating type for anyOf: %w", err)
 				}
 			}
-			if schema.OneOf != nil {
+			if sealed == nil && schema.OneOf != nil {
 				if err := generateUnion(&outSchema, schema.OneOf, schema.Discriminator, path); err != nil {
 					return Schema{}, fmt.Errorf("error generating type for oneOf: %w", err)
 				}
 			}
+			if len(outSchema.UnionElements) != 0 && outSchema.Discriminator == nil {
+				outSchema.UnionVariants = newUnionVariants(schema)
+			}
 
 			// Only generate a struct literal if the schema actually has
 			// struct content. When `generateUnion` collapses a one-
@@ -1092,10 +1141,12 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				Schema:   outSchema,
 			}
 			outSchema = Schema{
-				Description:     newTypeDef.Schema.Description,
-				GoType:          typeName,
-				DefineViaAlias:  true,
-				AdditionalTypes: append(outSchema.AdditionalTypes, newTypeDef),
+				Description:         newTypeDef.Schema.Description,
+				GoType:              typeName,
+				DefineViaAlias:      true,
+				SkipOptionalPointer: newTypeDef.Schema.SealedUnion != nil,
+				SealedUnionRef:      newTypeDef.Schema.SealedUnion != nil,
+				AdditionalTypes:     append(outSchema.AdditionalTypes, newTypeDef),
 			}
 		}
 
@@ -1129,8 +1180,10 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				// array-item-hoist) and prevents any future hoist
 				// caller that asks "has this schema been named yet?"
 				// from re-hoisting under the same path-derived name.
-				RefType:         typeName,
-				AdditionalTypes: append(outSchema.AdditionalTypes, typeDef),
+				RefType:             typeName,
+				SkipOptionalPointer: typeDef.Schema.SealedUnion != nil,
+				SealedUnionRef:      typeDef.Schema.SealedUnion != nil,
+				AdditionalTypes:     append(outSchema.AdditionalTypes, typeDef),
 			}
 		}
 
@@ -1193,6 +1246,15 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				outSchema.EnumValues[SchemaNameToTypeName(k)] = v
 			}
 		}
+		if fixed := newFixedValue(schema); fixed != nil {
+			// With fixed-const-fields set, a `const` is a zero-size type
+			// instead of a singleton enum. Its JSON methods write and
+			// verify the value, so it can't be set wrongly or left unset.
+			outSchema.GoType = "struct{}"
+			outSchema.EnumValues = nil
+			outSchema.FixedValue = fixed
+			outSchema.SkipOptionalPointer = true
+		}
 		if len(path) > 1 { // handle additional type only on non-toplevel types
 			// Allow overriding autogenerated enum type names, since these may
 			// cause conflicts - see https://github.com/oapi-codegen/oapi-codegen/issues/832
@@ -1245,6 +1307,7 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 
 		if (arrayType.HasAdditionalProperties ||
 			len(arrayType.UnionElements) != 0 ||
+			arrayType.SealedUnion != nil ||
 			(globalState.options.OutputOptions.GenerateTypesForAnonymousSchemas && len(arrayType.Properties) > 0)) &&
 			arrayType.RefType == "" {
 			// If we have items which have additional properties or union values,
@@ -1284,6 +1347,11 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 		if slices.Contains(globalState.options.OutputOptions.DisableTypeAliasesForType, "array") {
 			outSchema.DefineViaAlias = false
 		}
+		// A named slice of a sealed union needs its own JSON methods, which
+		// an alias of []T can't carry.
+		if arrayType.IsSealedUnion() && outSchema.GoType == "[]"+arrayType.TypeDecl() {
+			outSchema.DefineViaAlias = false
+		}
 		setSkipOptionalPointerForContainerType(outSchema)
 
 	} else if t.Is("integer") {
@@ -1415,86 +1483,100 @@ func GenFieldsFromProperties(props []Property) []string {
 			field += fmt.Sprintf("%s\n", DeprecationComment(deprecationReason))
 		}
 
-		// Check x-go-type-skip-optional-pointer, which will override if the type
-		// should be a pointer or not when the field is optional.
-		if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
-			if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
-				p.Schema.SkipOptionalPointer = skipOptionalPointer
-			}
+		field += fmt.Sprintf("    %s %s", goFieldName, p.withFieldExtensions().GoTypeDef())
+
+		fieldTags := p.fieldTags()
+		// Convert the fieldTags map into Go field annotations.
+		keys := SortedMapKeys(fieldTags)
+		tags := make([]string, len(keys))
+		for i, k := range keys {
+			tags[i] = fmt.Sprintf(`%s:"%s"`, k, fieldTags[k])
+		}
+		field += "`" + strings.Join(tags, " ") + "`"
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// withFieldExtensions returns the property with its field-level
+// x-go-type-skip-optional-pointer extension applied to its schema.
+func (p Property) withFieldExtensions() Property {
+	// Check x-go-type-skip-optional-pointer, which will override if the type
+	// should be a pointer or not when the field is optional.
+	if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+		if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
+			p.Schema.SkipOptionalPointer = skipOptionalPointer
 		}
+	}
+	return p
+}
 
-		field += fmt.Sprintf("    %s %s", goFieldName, p.GoTypeDef())
+// fieldTags returns the struct tags of the property's generated field, keyed
+// by tag name.
+func (p Property) fieldTags() map[string]string {
+	p = p.withFieldExtensions()
 
-		shouldOmitEmpty := (!p.Required || p.ReadOnly || p.WriteOnly) &&
-			(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
+	shouldOmitEmpty := (!p.Required || p.ReadOnly || p.WriteOnly) &&
+		(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
 
-		// Nullable fields don't get omitempty: `null` is a meaningful wire
-		// value distinct from key absence, and a nil pointer under omitempty
-		// could never produce it. Required+nullable fields must always
-		// serialize their key per JSON Schema `required` semantics. The
-		// nullable-type option is the exception — nullable.Nullable[T]
-		// distinguishes absent from null itself and relies on omitempty for
-		// the absent case. Issue #2503.
-		omitEmpty := !p.Nullable && shouldOmitEmpty
+	// Nullable fields don't get omitempty: `null` is a meaningful wire
+	// value distinct from key absence, and a nil pointer under omitempty
+	// could never produce it. Required+nullable fields must always
+	// serialize their key per JSON Schema `required` semantics. The
+	// nullable-type option is the exception — nullable.Nullable[T]
+	// distinguishes absent from null itself and relies on omitempty for
+	// the absent case. Issue #2503.
+	omitEmpty := !p.Nullable && shouldOmitEmpty
 
-		if p.Nullable && globalState.options.OutputOptions.NullableType {
-			omitEmpty = shouldOmitEmpty
-		}
+	if p.Nullable && globalState.options.OutputOptions.NullableType {
+		omitEmpty = shouldOmitEmpty
+	}
 
-		omitZero := false
+	omitZero := false
 
-		// default, but allow turning of
-		if shouldOmitEmpty && p.Schema.SkipOptionalPointer && globalState.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
-			omitZero = true
-		}
+	// default, but allow turning of
+	if shouldOmitEmpty && p.Schema.SkipOptionalPointer && globalState.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
+		omitZero = true
+	}
 
-		// Support x-omitempty and x-omitzero
-		if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
-			if xValue, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
-				omitEmpty = xValue
-			}
+	// Support x-omitempty and x-omitzero
+	if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
+		if xValue, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
+			omitEmpty = xValue
 		}
+	}
 
-		if extOmitEmptyValue, ok := p.Extensions[extPropOmitZero]; ok {
-			if xValue, err := extParseOmitZero(extOmitEmptyValue); err == nil {
-				omitZero = xValue
-			}
+	if extOmitEmptyValue, ok := p.Extensions[extPropOmitZero]; ok {
+		if xValue, err := extParseOmitZero(extOmitEmptyValue); err == nil {
+			omitZero = xValue
 		}
+	}
 
-		fieldTags := schemaFieldTagGenerator().generateTagsMap(StructTagInfo{
-			FieldName:    p.JsonFieldName,
-			IsOptional:   !p.Required,
-			OmitEmpty:    omitEmpty,
-			OmitZero:     omitZero,
-			NeedsFormTag: p.NeedsFormTag,
-		})
+	fieldTags := schemaFieldTagGenerator().generateTagsMap(StructTagInfo{
+		FieldName:    p.JsonFieldName,
+		IsOptional:   !p.Required,
+		OmitEmpty:    omitEmpty,
+		OmitZero:     omitZero,
+		NeedsFormTag: p.NeedsFormTag,
+	})
 
-		// Support x-go-json-ignore
-		if extension, ok := p.Extensions[extPropGoJsonIgnore]; ok {
-			if goJsonIgnore, err := extParseGoJsonIgnore(extension); err == nil && goJsonIgnore {
-				fieldTags["json"] = "-"
-			}
+	// Support x-go-json-ignore
+	if extension, ok := p.Extensions[extPropGoJsonIgnore]; ok {
+		if goJsonIgnore, err := extParseGoJsonIgnore(extension); err == nil && goJsonIgnore {
+			fieldTags["json"] = "-"
 		}
+	}
 
-		// Support x-oapi-codegen-extra-tags
-		if extension, ok := p.Extensions[extPropExtraTags]; ok {
-			if tags, err := extExtraTags(extension); err == nil {
-				keys := SortedMapKeys(tags)
-				for _, k := range keys {
-					fieldTags[k] = tags[k]
-				}
+	// Support x-oapi-codegen-extra-tags
+	if extension, ok := p.Extensions[extPropExtraTags]; ok {
+		if tags, err := extExtraTags(extension); err == nil {
+			keys := SortedMapKeys(tags)
+			for _, k := range keys {
+				fieldTags[k] = tags[k]
 			}
 		}
-		// Convert the fieldTags map into Go field annotations.
-		keys := SortedMapKeys(fieldTags)
-		tags := make([]string, len(keys))
-		for i, k := range keys {
-			tags[i] = fmt.Sprintf(`%s:"%s"`, k, fieldTags[k])
-		}
-		field += "`" + strings.Join(tags, " ") + "`"
-		fields = append(fields, field)
 	}
-	return fields
+	return fieldTags
 }
 
 func additionalPropertiesType(schema Schema) string {
@@ -1743,5 +1825,6 @@ func hasInlineStructuralContent(s *Schema) bool {
 	}
 	return len(s.Properties) > 0 ||
 		s.HasAdditionalProperties ||
-		len(s.UnionElements) > 0
+		len(s.UnionElements) > 0 ||
+		s.SealedUnion != nil
 }
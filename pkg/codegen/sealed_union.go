@@ -0,0 +1,262 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SealedUnion describes a discriminated oneOf generated as a sealed Go
+// interface, when the output-options.sealed-discriminated-unions option is
+// set. Each member type implements the interface's unexported marker method,
+// and the generated Unmarshal<Union> function selects the member to decode
+// into from the discriminator property.
+type SealedUnion struct {
+	// Property is the JSON name of the discriminator property.
+	Property string
+	// Members are the union's member types, in oneOf order.
+	Members []SealedUnionMember
+}
+
+// SealedUnionMember is one member type of a sealed union.
+type SealedUnionMember struct {
+	// GoType is the member's Go type name, e.g. "Cat".
+	GoType string
+	// Values are the discriminator values which select this member, sorted.
+	Values []string
+}
+
+// JSONPatch returns the JSON object literal merged into the member's JSON
+// when marshaling through the union, e.g. {"petType":"cat"}, or "" when
+// several discriminator values map to the member and the one to stamp is
+// ambiguous. Safe to embed in a backtick string literal for the same reason
+// as DiscriminatorStamp.JSONPatch.
+func (u SealedUnion) JSONPatch(m SealedUnionMember) string {
+	if len(m.Values) != 1 {
+		return ""
+	}
+	return fmt.Sprintf(`{"%s":"%s"}`, u.Property, m.Values[0])
+}
+
+// newSealedUnion returns the sealed union representation of schema, or nil
+// when the schema doesn't qualify: the option is off, the schema isn't a
+// discriminated oneOf, it declares properties of its own, or a member isn't
+// a $ref to a local object schema which can carry the marker method. A
+// qualifying schema is decided the same way at its declaration and at every
+// $ref to it, so both agree on the representation.
+func newSealedUnion(schema *openapi3.Schema) (*SealedUnion, error) {
+	opts := globalState.options
+	if !opts.OutputOptions.SealedDiscriminatedUnions || opts.Compatibility.OldAliasing {
+		return nil, nil
+	}
+	if schema == nil || schema.Discriminator == nil || len(schema.OneOf) == 0 ||
+		schema.AnyOf != nil || schema.AllOf != nil ||
+		len(schema.Properties) != 0 || SchemaHasAdditionalProperties(schema) {
+		return nil, nil
+	}
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return nil, nil
+	}
+
+	union := &SealedUnion{Property: schema.Discriminator.PropertyName}
+	for _, element := range schema.OneOf {
+		if element != nil && isNullTypeSchema(element.Value) {
+			continue
+		}
+		if element == nil || !sealedUnionMemberQualifies(element) {
+			return nil, nil
+		}
+		goType, err := RefPathToGoType(element.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", element.Ref, err)
+		}
+		member := SealedUnionMember{GoType: goType}
+		for _, value := range SortedMapKeys(schema.Discriminator.Mapping) {
+			if schema.Discriminator.Mapping[value].Ref == element.Ref {
+				member.Values = append(member.Values, value)
+			}
+		}
+		if len(member.Values) == 0 {
+			member.Values = []string{RefPathToObjName(element.Ref)}
+		}
+		union.Members = append(union.Members, member)
+	}
+	if len(union.Members) == 0 {
+		return nil, nil
+	}
+	return union, nil
+}
+
+// sealedUnionMemberQualifies reports whether a oneOf element can be a sealed
+// union member: a $ref to a local component schema which generates as a
+// named struct type, so that the marker method can be declared on it.
+func sealedUnionMemberQualifies(element *openapi3.SchemaRef) bool {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(element.Ref, prefix) || element.Value == nil {
+		return false
+	}
+	// A member which is itself a $ref would generate as an alias, and the
+	// marker method would land on the aliased type.
+	if globalState.spec != nil && globalState.spec.Components != nil {
+		if sref, ok := globalState.spec.Components.Schemas[strings.TrimPrefix(element.Ref, prefix)]; ok && sref.Ref != "" {
+			return false
+		}
+	}
+	v := element.Value
+	if v.OneOf != nil || v.AnyOf != nil {
+		return false
+	}
+	for _, ext := range []string{extPropGoType, extGoTypeName} {
+		if _, ok := v.Extensions[ext]; ok {
+			return false
+		}
+	}
+	return len(v.Properties) != 0 || v.AllOf != nil
+}
+
+// IsSealedUnion reports whether the schema is a sealed union, either declared
+// at this position or referenced from it.
+func (s Schema) IsSealedUnion() bool {
+	return s.SealedUnion != nil || s.SealedUnionRef
+}
+
+// holdsSealedUnion reports whether the schema is a named type whose own JSON
+// methods decode and encode the sealed unions it holds: a struct with sealed
+// union fields, or a slice or map of a sealed union. Declaring another type
+// from it drops those methods, so the templates delegate to them.
+func (s Schema) holdsSealedUnion() bool {
+	if s.IsSealedUnion() || strings.ContainsAny(s.TypeDecl(), "[]{}* ") {
+		return false
+	}
+	return schemaHoldsSealedUnion(s.OAPISchema)
+}
+
+// schemaHoldsSealedUnion reports whether an OpenAPI schema's items,
+// additionalProperties or properties (including those merged from allOf) are
+// sealed unions, or slices or maps of them.
+func schemaHoldsSealedUnion(schema *openapi3.Schema) bool {
+	if schema == nil || !globalState.options.OutputOptions.SealedDiscriminatedUnions {
+		return false
+	}
+	isSealed := func(sref *openapi3.SchemaRef) bool {
+		if sref == nil {
+			return false
+		}
+		sealed, err := newSealedUnion(sref.Value)
+		return err == nil && sealed != nil
+	}
+	holdsSealed := func(sref *openapi3.SchemaRef) bool {
+		return isSealed(sref) || (sref != nil && sref.Value != nil &&
+			(isSealed(sref.Value.Items) || isSealed(sref.Value.AdditionalProperties.Schema)))
+	}
+	if isSealed(schema.Items) || isSealed(schema.AdditionalProperties.Schema) {
+		return true
+	}
+	for _, p := range schema.Properties {
+		if holdsSealed(p) {
+			return true
+		}
+	}
+	for _, member := range schema.AllOf {
+		if member != nil && member.Value != nil {
+			for _, p := range member.Value.Properties {
+				if holdsSealed(p) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SealedUnionCodec returns how a value of this schema is decoded and encoded
+// through the sealed union functions: either the schema is itself a sealed
+// union, or a slice or map of one. It returns nil for any other schema,
+// including named types which decode themselves.
+func (s Schema) SealedUnionCodec() *SealedUnionCodec {
+	if s.IsSealedUnion() {
+		return &SealedUnionCodec{Union: s.TypeDecl()}
+	}
+	if s.IsRef() {
+		return nil
+	}
+	if s.ArrayType != nil && s.ArrayType.IsSealedUnion() && s.GoType == "[]"+s.ArrayType.TypeDecl() {
+		return &SealedUnionCodec{Union: s.ArrayType.TypeDecl(), Suffix: "Slice"}
+	}
+	if a := s.AdditionalPropertiesType; a != nil && a.IsSealedUnion() && s.GoType == "map[string]"+a.TypeDecl() {
+		return &SealedUnionCodec{Union: a.TypeDecl(), Suffix: "Map"}
+	}
+	return nil
+}
+
+// SealedUnionCodec names the generated functions which decode and encode a
+// sealed union, or a slice or map of one.
+type SealedUnionCodec struct {
+	// Union is the Go type of the sealed interface, possibly package
+	// qualified, e.g. "externalRef0.Pet".
+	Union string
+	// Suffix is "Slice" or "Map" for containers of the union, "" otherwise.
+	Suffix string
+	// Pointer is set when the property holding a slice or map of the union
+	// is an optional pointer to it.
+	Pointer bool
+}
+
+// UnmarshalFunc returns the decode function, e.g. "externalRef0.UnmarshalPetSlice".
+func (c SealedUnionCodec) UnmarshalFunc() string {
+	return c.funcName("Unmarshal")
+}
+
+// MarshalFunc returns the encode function, e.g. "externalRef0.MarshalPetSlice".
+func (c SealedUnionCodec) MarshalFunc() string {
+	return c.funcName("Marshal")
+}
+
+// FieldName returns the field name of the union when embedded in a struct.
+func (c SealedUnionCodec) FieldName() string {
+	return c.Union[strings.LastIndex(c.Union, ".")+1:]
+}
+
+func (c SealedUnionCodec) funcName(verb string) string {
+	pkg, name := "", c.Union
+	if i := strings.LastIndex(c.Union, "."); i >= 0 {
+		pkg, name = c.Union[:i+1], c.Union[i+1:]
+	}
+	return pkg + verb + name + c.Suffix
+}
+
+// SealedUnionFields returns the properties of a struct schema which hold a
+// sealed union, or a slice or map of one, and so need decoding and encoding
+// through the union's functions.
+func (s Schema) SealedUnionFields() []Property {
+	var fields []Property
+	for _, p := range s.Properties {
+		if p.SealedUnionCodec() != nil {
+			fields = append(fields, p)
+		}
+	}
+	return fields
+}
+
+// SealedUnionCodec returns the codec for a property holding a sealed union,
+// or nil. Properties ignored by encoding/json have none.
+func (p Property) SealedUnionCodec() *SealedUnionCodec {
+	if p.fieldTags()["json"] == "-" {
+		return nil
+	}
+	if globalState.options.OutputOptions.NullableType && p.Nullable {
+		return nil
+	}
+	codec := p.Schema.SealedUnionCodec()
+	if codec != nil {
+		codec.Pointer = p.withFieldExtensions().IsPointer()
+	}
+	return codec
+}
+
+// SealedUnionJSONTag returns the json struct tag of the property's field, for
+// the raw field which shadows it while its holder decodes and encodes it.
+func (p Property) SealedUnionJSONTag() string {
+	return fmt.Sprintf("`json:%q`", p.fieldTags()["json"])
+}
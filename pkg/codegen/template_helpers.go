@@ -36,13 +36,17 @@ const (
 	defaultClientTypeName = "Client"
 )
 
+// defaultResponseTypeSuffix is the suffix of client response types, unless
+// output-options.response-type-suffix sets another.
+const defaultResponseTypeSuffix = "Response"
+
 var (
 	contentTypesJSON    = []string{"application/json", "text/x-json", "application/problem+json"}
 	contentTypesHalJSON = []string{"application/hal+json"}
 	contentTypesYAML    = []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"}
 	contentTypesXML     = []string{"application/xml", "text/xml", "application/problems+xml"}
 
-	responseTypeSuffix = "Response"
+	responseTypeSuffix = defaultResponseTypeSuffix
 
 	titleCaser = cases.Title(language.English)
 )
@@ -178,6 +182,18 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
 						typeDefinition.TypeName)
+					// A sealed union, or a slice or map of one, can't be
+					// decoded by encoding/json, so go through its generated
+					// decode function instead.
+					if codec := typeDefinition.Schema.SealedUnionCodec(); codec != nil {
+						caseAction = fmt.Sprintf("dest, err := %s(bodyBytes)\n"+
+							"if err != nil { \n"+
+							" return nil, err \n"+
+							"}\n"+
+							"response.%s = &dest",
+							codec.UnmarshalFunc(),
+							typeDefinition.TypeName)
+					}
 
 					if jsonCount > 1 {
 						caseKey, caseClause := buildUnmarshalCaseStrict(typeDefinition, caseAction, contentTypeName)
@@ -1,6 +1,12 @@
 package codegen
 
-import "maps"
+import (
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 // SimpleTypeSpec defines the Go type for an OpenAPI type/format combination,
 // along with any import required to use it.
@@ -22,10 +28,68 @@ type TypeMapping struct {
 	Number  FormatMapping `yaml:"number,omitempty" json:"number"`
 	Boolean FormatMapping `yaml:"boolean,omitempty" json:"boolean"`
 	String  FormatMapping `yaml:"string,omitempty" json:"string"`
+
+	// Presets selects named sets of mappings from TypeMappingPresets, which
+	// are applied in order before the mappings above, so those can override
+	// them.
+	Presets []string `yaml:"presets,omitempty" json:"presets,omitempty"`
+}
+
+// numericImport is the package of the types used by the numeric presets.
+const numericImport = "github.com/oapi-codegen/oapi-codegen/v2/pkg/numeric"
+
+// TypeMappingPresets are the named sets of mappings which can be selected
+// with TypeMapping.Presets:
+//
+//   - "decimal" maps `type: string, format: decimal` to numeric.Decimal,
+//     encoded as a JSON string, and `type: number, format: decimal` to
+//     numeric.Number, encoded as a JSON number, both of arbitrary precision.
+//   - "int64-as-string" maps `type: string, format: int64` to
+//     numeric.Int64String, an int64 encoded as a JSON string.
+var TypeMappingPresets = map[string]TypeMapping{
+	"decimal": {
+		Number: FormatMapping{
+			Formats: map[string]SimpleTypeSpec{
+				"decimal": {Type: "numeric.Number", Import: numericImport},
+			},
+		},
+		String: FormatMapping{
+			Formats: map[string]SimpleTypeSpec{
+				"decimal": {Type: "numeric.Decimal", Import: numericImport},
+			},
+		},
+	},
+	"int64-as-string": {
+		String: FormatMapping{
+			Formats: map[string]SimpleTypeSpec{
+				"int64": {Type: "numeric.Int64String", Import: numericImport},
+			},
+		},
+	},
+}
+
+// unknownPresets returns the names in presets which aren't in
+// TypeMappingPresets.
+func unknownPresets(presets []string) []string {
+	var unknown []string
+	for _, name := range presets {
+		if _, ok := TypeMappingPresets[name]; !ok && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
 }
 
 // Merge returns a new TypeMapping with user overrides applied on top of base.
+// The presets selected by user are applied first; unknown presets are
+// ignored here, and reported by OutputOptions.Validate.
 func (base TypeMapping) Merge(user TypeMapping) TypeMapping {
+	for _, name := range user.Presets {
+		if preset, ok := TypeMappingPresets[name]; ok {
+			base = base.Merge(preset)
+		}
+	}
 	return TypeMapping{
 		Integer: base.Integer.merge(user.Integer),
 		Number:  base.Number.merge(user.Number),
@@ -65,6 +129,33 @@ func (fm FormatMapping) Resolve(format string) SimpleTypeSpec {
 	return fm.Default
 }
 
+// importFor returns the import needed by the Go type which a schema maps to,
+// or nil when it needs none, or the type is set with x-go-type instead.
+func (tm TypeMapping) importFor(schema *openapi3.Schema) *goImport {
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return nil
+	}
+	t := schemaPrimaryType(schema.Type)
+	if t == nil {
+		return nil
+	}
+	var spec SimpleTypeSpec
+	switch {
+	case t.Is("integer"):
+		spec = tm.Integer.Resolve(schema.Format)
+	case t.Is("number"):
+		spec = tm.Number.Resolve(schema.Format)
+	case t.Is("boolean"):
+		spec = tm.Boolean.Resolve(schema.Format)
+	case t.Is("string"):
+		spec = tm.String.Resolve(schema.Format)
+	}
+	if spec.Import == "" {
+		return nil
+	}
+	return &goImport{Path: spec.Import}
+}
+
 // DefaultTypeMapping provides the default OpenAPI type/format to Go type mappings.
 var DefaultTypeMapping = TypeMapping{
 	Integer: FormatMapping{
@@ -141,3 +141,72 @@ components:
 	assert.Contains(t, code, "Backoff string `json:\"backoff\"`")
 	assert.NotContains(t, code, "openapi_types.Duration")
 }
+
+func TestTypeMapping_MergePresets(t *testing.T) {
+	user := TypeMapping{
+		Presets: []string{"decimal", "int64-as-string"},
+		String: FormatMapping{
+			Formats: map[string]SimpleTypeSpec{
+				"decimal": {Type: "string"},
+			},
+		},
+	}
+
+	merged := DefaultTypeMapping.Merge(user)
+
+	assert.Equal(t, "numeric.Number", merged.Number.Resolve("decimal").Type)
+	assert.Equal(t, numericImport, merged.Number.Resolve("decimal").Import)
+	assert.Equal(t, "float64", merged.Number.Resolve("double").Type)
+	assert.Equal(t, "numeric.Int64String", merged.String.Resolve("int64").Type)
+	// Explicit mappings win over presets.
+	assert.Equal(t, "string", merged.String.Resolve("decimal").Type)
+	assert.Empty(t, merged.Presets)
+
+	assert.Equal(t, []string{"bigint", "money"}, unknownPresets([]string{"money", "decimal", "bigint", "money"}))
+	problems := OutputOptions{TypeMapping: &TypeMapping{Presets: []string{"money"}}}.Validate()
+	assert.Contains(t, problems["type-mapping"], "money")
+}
+
+// TestDecimalPresetImports verifies that the types of the numeric presets are
+// imported by the generated code, including as map values.
+func TestDecimalPresetImports(t *testing.T) {
+	const spec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Prices
+paths: {}
+components:
+  schemas:
+    Price:
+      type: object
+      required: [amount]
+      properties:
+        amount:
+          type: string
+          format: decimal
+    Rates:
+      type: object
+      additionalProperties:
+        type: number
+        format: decimal
+`
+	loader := openapi3.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{
+			SkipPrune:   true,
+			TypeMapping: &TypeMapping{Presets: []string{"decimal"}},
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Amount numeric.Decimal `json:\"amount\"`")
+	assert.Contains(t, code, "type Rates map[string]numeric.Number")
+	assert.Contains(t, code, `"github.com/oapi-codegen/oapi-codegen/v2/pkg/numeric"`)
+}
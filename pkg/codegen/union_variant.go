@@ -0,0 +1,271 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// UnionVariants describes how the data of a union without a discriminator is
+// matched against its members by the generated Detect and Variant methods.
+type UnionVariants struct {
+	// AnyOf is set for an anyOf union, which any number of members may
+	// match; a oneOf union must match exactly one.
+	AnyOf bool
+	// Nullable is set when the union is nullable, so that JSON null is a
+	// valid value matching no member type.
+	Nullable bool
+	// Members are the structural signatures of the union's members, in the
+	// same order as Schema.UnionElements.
+	Members []UnionVariant
+}
+
+// UnionVariant is the structural signature of one union member: the shape
+// JSON data must have to be an instance of the member.
+type UnionVariant struct {
+	// Kind is the JSON type of the data: "object", "array", "string",
+	// "number", "integer" or "boolean", or "" when unconstrained.
+	Kind string
+	// Nullable is set when the data may also be JSON null.
+	Nullable bool
+	// Enum holds the JSON encoding of each allowed value, if constrained.
+	Enum []string
+	// Required are the properties an object must have.
+	Required []string
+	// Properties are the object's declared properties, sorted by name.
+	Properties []UnionVariantProperty
+	// Closed is set by additionalProperties: false, so the object may only
+	// have its declared properties.
+	Closed bool
+}
+
+// UnionVariantProperty is the signature of one property of an object member.
+type UnionVariantProperty struct {
+	Name string
+	// Kinds are the JSON types the property's value may have, or none when
+	// unconstrained.
+	Kinds []string
+	// Enum holds the JSON encoding of each allowed value, if constrained.
+	Enum []string
+}
+
+// newUnionVariants returns the structural signatures of the members of a
+// union without a discriminator, or nil when the union-variant-detection
+// option is off or the schema mixes oneOf and anyOf, whose elements then
+// share one list.
+func newUnionVariants(schema *openapi3.Schema) *UnionVariants {
+	if !globalState.options.OutputOptions.UnionVariantDetection ||
+		(len(schema.OneOf) != 0 && len(schema.AnyOf) != 0) {
+		return nil
+	}
+	elements := schema.OneOf
+	variants := &UnionVariants{Nullable: schemaIsNullable(schema)}
+	if len(schema.AnyOf) != 0 {
+		elements = schema.AnyOf
+		variants.AnyOf = true
+	}
+	for _, element := range elements {
+		if element != nil && isNullTypeSchema(element.Value) {
+			continue
+		}
+		var value *openapi3.Schema
+		if element != nil {
+			value = element.Value
+		}
+		variants.Members = append(variants.Members, newUnionVariant(value))
+	}
+	return variants
+}
+
+// UsesObject reports whether any member's Detect condition inspects the
+// properties of the decoded top-level object.
+func (u UnionVariants) UsesObject() bool {
+	for _, m := range u.Members {
+		if len(m.Required) != 0 || len(m.Properties) != 0 || m.Closed {
+			return true
+		}
+	}
+	return false
+}
+
+// UnionVariantCase pairs a union element with the Go condition, evaluated
+// by the generated Detect method, under which the data matches it.
+type UnionVariantCase struct {
+	Element   UnionElement
+	Condition string
+}
+
+// UnionVariantCases returns the Detect conditions for each union element. It
+// is paired up at render time, after externalref.go has qualified the union
+// elements of types living in imported packages.
+func (s Schema) UnionVariantCases() []UnionVariantCase {
+	if s.UnionVariants == nil || len(s.UnionVariants.Members) != len(s.UnionElements) {
+		return nil
+	}
+	cases := make([]UnionVariantCase, len(s.UnionElements))
+	for i, el := range s.UnionElements {
+		cases[i] = UnionVariantCase{Element: el, Condition: s.UnionVariants.Members[i].Condition()}
+	}
+	return cases
+}
+
+// newUnionVariant derives the structural signature of a union member schema.
+func newUnionVariant(schema *openapi3.Schema) UnionVariant {
+	var v UnionVariant
+	if schema == nil {
+		return v
+	}
+	v.Kind = jsonKind(schema)
+	v.Nullable = schemaIsNullable(schema)
+	v.Enum = jsonEnum(schema, v.Nullable)
+
+	properties := map[string]*openapi3.Schema{}
+	var required []string
+	collectUnionVariantProperties(schema, properties, &required, map[*openapi3.Schema]bool{})
+	if v.Kind == "" && len(properties) != 0 {
+		v.Kind = "object"
+	}
+	if v.Kind != "object" {
+		return v
+	}
+
+	slices.Sort(required)
+	v.Required = slices.Compact(required)
+	for _, name := range SortedMapKeys(properties) {
+		nullable := schemaIsNullable(properties[name])
+		p := UnionVariantProperty{Name: name, Enum: jsonEnum(properties[name], nullable)}
+		if kind := jsonKind(properties[name]); kind != "" {
+			p.Kinds = jsonKinds(kind, nullable)
+		}
+		v.Properties = append(v.Properties, p)
+	}
+	if has := schema.AdditionalProperties.Has; has != nil && !*has {
+		v.Closed = true
+	}
+	return v
+}
+
+// collectUnionVariantProperties gathers the properties and required
+// properties of an object schema, including those merged in through allOf.
+func collectUnionVariantProperties(schema *openapi3.Schema, properties map[string]*openapi3.Schema, required *[]string, seen map[*openapi3.Schema]bool) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+	for name, p := range schema.Properties {
+		if p != nil {
+			properties[name] = p.Value
+		}
+	}
+	*required = append(*required, schema.Required...)
+	for _, member := range schema.AllOf {
+		if member != nil {
+			collectUnionVariantProperties(member.Value, properties, required, seen)
+		}
+	}
+}
+
+// jsonKind returns the JSON type a schema declares, or "" when it declares
+// none, or several.
+func jsonKind(schema *openapi3.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	t := schemaPrimaryType(schema.Type)
+	if t == nil || len(t.Slice()) != 1 {
+		return ""
+	}
+	switch kind := t.Slice()[0]; kind {
+	case "object", "array", "string", "number", "integer", "boolean":
+		return kind
+	}
+	return ""
+}
+
+// jsonKinds returns the JSON types the generated kind function may report for
+// data of the given kind: integral numbers are reported as "integer", and
+// null as "null".
+func jsonKinds(kind string, nullable bool) []string {
+	kinds := []string{kind}
+	if kind == "number" {
+		kinds = append(kinds, "integer")
+	}
+	if nullable {
+		kinds = append(kinds, "null")
+	}
+	return kinds
+}
+
+// jsonEnum returns the JSON encoding of each value a schema's enum (or 3.1
+// const) allows, including null for a nullable schema, or nil when it doesn't
+// constrain its values.
+func jsonEnum(schema *openapi3.Schema, nullable bool) []string {
+	if schema == nil {
+		return nil
+	}
+	values := schema.Enum
+	if len(values) == 0 && globalState.is31 && schema.Const != nil {
+		values = []any{schema.Const}
+	}
+	var enum []string
+	for _, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		enum = append(enum, string(b))
+	}
+	if len(enum) != 0 && nullable && !slices.Contains(enum, "null") {
+		enum = append(enum, "null")
+	}
+	return enum
+}
+
+// Condition returns the Go boolean expression, evaluated by the generated
+// Detect method, under which the union data matches this member. It refers
+// to the union's raw data t.union, its JSON type dataKind, its decoded
+// top-level object and the kind function, all declared by the template.
+func (v UnionVariant) Condition() string {
+	var conds []string
+	if v.Kind != "" {
+		conds = append(conds, fmt.Sprintf("slices.Contains(%s, dataKind)", goStringSlice(jsonKinds(v.Kind, v.Nullable))))
+	}
+	if len(v.Enum) != 0 {
+		conds = append(conds, fmt.Sprintf("slices.Contains(%s, string(bytes.TrimSpace(t.union)))", goStringSlice(v.Enum)))
+	}
+	for _, name := range v.Required {
+		conds = append(conds, fmt.Sprintf("object[%s] != nil", StringToGoString(name)))
+	}
+	for _, p := range v.Properties {
+		field := fmt.Sprintf("object[%s]", StringToGoString(p.Name))
+		if len(p.Kinds) != 0 {
+			conds = append(conds, fmt.Sprintf("(%s == nil || slices.Contains(%s, kind(%s)))", field, goStringSlice(p.Kinds), field))
+		}
+		if len(p.Enum) != 0 {
+			conds = append(conds, fmt.Sprintf("(%s == nil || slices.Contains(%s, string(%s)))", field, goStringSlice(p.Enum), field))
+		}
+	}
+	if v.Closed {
+		names := make([]string, len(v.Properties))
+		for i, p := range v.Properties {
+			names[i] = p.Name
+		}
+		conds = append(conds, fmt.Sprintf("func() bool {\nfor name := range object {\nif !slices.Contains(%s, name) {\nreturn false\n}\n}\nreturn true\n}()", goStringSlice(names)))
+	}
+	if len(conds) == 0 {
+		return "true"
+	}
+	return strings.Join(conds, " &&\n")
+}
+
+// goStringSlice renders a Go []string literal.
+func goStringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = StringToGoString(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
@@ -720,7 +720,7 @@ func ValidateStdHTTPPaths(spec *openapi3.T) error {
 			continue
 		}
 		if err := ValidateStdHTTPPath(path); err != nil {
-			errs = append(errs, err)
+			errs = append(errs, withOrigin(pathItem.Origin, err))
 		}
 	}
 	return errors.Join(errs...)
@@ -27,6 +27,9 @@ import (
 // are checked. `$ref`s are not followed: every ref resolves to a component that
 // is validated at its own definition, and the contents of external documents are
 // never copied into the generated output, so they cannot affect it.
+//
+// When the spec was loaded with origins, each error is a SourceError at the
+// element with the offending value.
 func ValidateSpec(spec *openapi3.T) error {
 	if spec == nil {
 		return nil
@@ -38,10 +41,22 @@ func ValidateSpec(spec *openapi3.T) error {
 
 type specValidator struct {
 	errs []error
+	// origin is the location of the element being validated.
+	origin *openapi3.Origin
 }
 
 func (v *specValidator) addf(format string, args ...any) {
-	v.errs = append(v.errs, fmt.Errorf(format, args...))
+	v.errs = append(v.errs, withOrigin(v.origin, fmt.Errorf(format, args...)))
+}
+
+// at sets the element being validated to the one with origin, if it has a
+// location, until the returned func is called.
+func (v *specValidator) at(origin *openapi3.Origin) (restore func()) {
+	prev := v.origin
+	if origin != nil && origin.Key != nil {
+		v.origin = origin
+	}
+	return func() { v.origin = prev }
 }
 
 // checkText validates a free-form string that is copied verbatim into the
@@ -226,7 +241,13 @@ func (v *specValidator) walkDocument(spec *openapi3.T) {
 		paths := spec.Paths.Map()
 		for _, path := range SortedMapKeys(paths) {
 			where := fmt.Sprintf("path %q", path)
+			var origin *openapi3.Origin
+			if item := paths[path]; item != nil {
+				origin = item.Origin
+			}
+			restore := v.at(origin)
 			v.checkText(path, "OpenAPI path")
+			restore()
 			v.walkPathItem(paths[path], where)
 		}
 	}
@@ -265,6 +286,7 @@ func (v *specValidator) walkPathItem(item *openapi3.PathItem, where string) {
 	if item == nil {
 		return
 	}
+	defer v.at(item.Origin)()
 	for _, p := range item.Parameters {
 		v.walkParameterRef(p, where)
 	}
@@ -277,6 +299,7 @@ func (v *specValidator) walkOperation(op *openapi3.Operation, where string) {
 	if op == nil {
 		return
 	}
+	defer v.at(op.Origin)()
 	v.checkExtensions(op.Extensions, where)
 	if op.Security != nil {
 		v.checkSecurity(*op.Security, where)
@@ -306,6 +329,7 @@ func (v *specValidator) walkParameterRef(ref *openapi3.ParameterRef, where strin
 		return
 	}
 	p := ref.Value
+	defer v.at(p.Origin)()
 	loc := fmt.Sprintf("parameter %q in %s", p.Name, where)
 	v.checkText(p.Name, "parameter name in "+where)
 	v.checkText(p.Style, "style of "+loc)
@@ -322,6 +346,7 @@ func (v *specValidator) walkRequestBodyRef(ref *openapi3.RequestBodyRef, where s
 	if ref.Ref != "" || ref.Value == nil {
 		return
 	}
+	defer v.at(ref.Value.Origin)()
 	loc := "request body in " + where
 	v.checkExtensions(ref.Value.Extensions, loc)
 	v.walkContent(ref.Value.Content, loc)
@@ -336,6 +361,7 @@ func (v *specValidator) walkResponseRef(ref *openapi3.ResponseRef, where string)
 		return
 	}
 	r := ref.Value
+	defer v.at(r.Origin)()
 	for _, name := range SortedMapKeys(r.Headers) {
 		v.checkText(name, "response header name in "+where)
 		v.walkHeaderRef(r.Headers[name], fmt.Sprintf("header %q in %s", name, where))
@@ -352,6 +378,7 @@ func (v *specValidator) walkHeaderRef(ref *openapi3.HeaderRef, where string) {
 	if ref.Ref != "" || ref.Value == nil {
 		return
 	}
+	defer v.at(ref.Value.Origin)()
 	v.checkExtensions(ref.Value.Extensions, where)
 	v.walkContent(ref.Value.Content, where)
 	v.walkSchemaRef(ref.Value.Schema, where)
@@ -378,9 +405,11 @@ func (v *specValidator) walkContent(content openapi3.Content, where string) {
 		if media == nil {
 			continue
 		}
+		restore := v.at(media.Origin)
 		loc := fmt.Sprintf("content type %q in %s", mediaType, where)
 		v.checkExtensions(media.Extensions, loc)
 		v.walkSchemaRef(media.Schema, loc)
+		restore()
 	}
 }
 
@@ -404,6 +433,7 @@ func (v *specValidator) walkSchema(s *openapi3.Schema, where string) {
 	if s == nil {
 		return
 	}
+	defer v.at(s.Origin)()
 	v.checkExtensions(s.Extensions, where)
 	v.checkText(s.Format, "format in "+where)
 	if s.Type != nil {
@@ -425,7 +455,15 @@ func (v *specValidator) walkSchema(s *openapi3.Schema, where string) {
 		}
 	}
 	for _, name := range SortedMapKeys(s.Properties) {
+		// A property's name is declared where its schema is, unless that's
+		// a $ref to a schema elsewhere.
+		var origin *openapi3.Origin
+		if p := s.Properties[name]; p != nil && p.Ref == "" && p.Value != nil {
+			origin = p.Value.Origin
+		}
+		restore := v.at(origin)
 		v.checkText(name, "property name in "+where)
+		restore()
 		v.walkSchemaRef(s.Properties[name], fmt.Sprintf("property %q in %s", name, where))
 	}
 	v.walkSchemaRef(s.Items, "items in "+where)
@@ -17,6 +17,9 @@ import (
 //    openssl ecparam -name prime256v1 -genkey -noout -out ecprivatekey.pem
 // 2) Generate public key from private key
 //    openssl ec -in ecprivatekey.pem -pubout -out ecpubkey.pem
+//
+// Ed25519 and RSA keys are handled likewise, in keys.go, and keys in JSON Web
+// Key (JWK) documents in jwk.go.
 
 // LoadEcdsaPublicKey reads an ECDSA public key from an X509 encoding stored in a PEM encoding.
 func LoadEcdsaPublicKey(buf []byte) (*ecdsa.PublicKey, error) {
@@ -53,7 +56,15 @@ func LoadEcdsaPrivateKey(buf []byte) (*ecdsa.PrivateKey, error) {
 	// and we're assuming this encoding contains X509 key material.
 	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
 	if err != nil {
-		return nil, fmt.Errorf("error loading private ECDSA key: %w", err)
+		// It may be in the PKCS#8 encoding, as openssl genpkey writes it.
+		keyIface, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if pkcs8Err != nil {
+			return nil, fmt.Errorf("error loading private ECDSA key: %w", err)
+		}
+		var ok bool
+		if privateKey, ok = keyIface.(*ecdsa.PrivateKey); !ok {
+			return nil, errors.New("file contents were not an ECDSA private key")
+		}
 	}
 	return privateKey, nil
 }
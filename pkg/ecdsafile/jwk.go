@@ -0,0 +1,412 @@
+package ecdsafile
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// These are utilities for working with JSON Web Keys (RFC 7517), such as the
+// keys of a JWKS document an authorization server publishes, and their
+// thumbprints (RFC 7638). The ECDSA P-256, P-384 and P-521, Ed25519, RSA and
+// symmetric ("oct") key types of RFC 7518 and RFC 8037 are supported.
+
+// ErrJWKNotFound is returned by JWKS.Key when there's no key with the key ID.
+var ErrJWKNotFound = errors.New("no matching JWK found")
+
+// JWK is a JSON Web Key. Its key material is base64url encoded, as in the JSON
+// document; Key decodes it.
+type JWK struct {
+	Kty    string   `json:"kty"`
+	Use    string   `json:"use,omitempty"`
+	KeyOps []string `json:"key_ops,omitempty"`
+	Alg    string   `json:"alg,omitempty"`
+	Kid    string   `json:"kid,omitempty"`
+
+	// Crv, X and Y are the members of EC and OKP keys, and D their private
+	// key.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+
+	// N and E are the members of RSA keys, and D, P, Q, DP, DQ and QI their
+	// private key.
+	N  string `json:"n,omitempty"`
+	E  string `json:"e,omitempty"`
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	DP string `json:"dp,omitempty"`
+	DQ string `json:"dq,omitempty"`
+	QI string `json:"qi,omitempty"`
+
+	// K is the key of symmetric keys.
+	K string `json:"k,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// LoadJWK reads a JSON Web Key from a JSON encoding.
+func LoadJWK(buf []byte) (*JWK, error) {
+	var jwk JWK
+	if err := json.Unmarshal(buf, &jwk); err != nil {
+		return nil, fmt.Errorf("error loading JWK: %w", err)
+	}
+	if jwk.Kty == "" {
+		return nil, errors.New("JWK has no kty")
+	}
+	return &jwk, nil
+}
+
+// StoreJWK writes a JSON Web Key to a JSON encoding
+func StoreJWK(jwk *JWK) ([]byte, error) {
+	return json.Marshal(jwk)
+}
+
+// LoadJWKS reads a JSON Web Key Set from a JSON encoding. Its keys of
+// unsupported types are kept, for Key to fail to decode.
+func LoadJWKS(buf []byte) (*JWKS, error) {
+	var jwks JWKS
+	if err := json.Unmarshal(buf, &jwks); err != nil {
+		return nil, fmt.Errorf("error loading JWKS: %w", err)
+	}
+	if jwks.Keys == nil {
+		return nil, errors.New("JWKS has no keys")
+	}
+	return &jwks, nil
+}
+
+// StoreJWKS writes a JSON Web Key Set to a JSON encoding
+func StoreJWKS(jwks *JWKS) ([]byte, error) {
+	return json.Marshal(jwks)
+}
+
+// Key returns the key of the set with the key ID, or, when kid is empty, the
+// set's only key. It returns ErrJWKNotFound when there's no such key.
+func (s *JWKS) Key(kid string) (*JWK, error) {
+	if kid == "" {
+		if len(s.Keys) != 1 {
+			return nil, fmt.Errorf("%w: no kid to choose from %d keys", ErrJWKNotFound, len(s.Keys))
+		}
+		return s.Keys[0], nil
+	}
+	for _, jwk := range s.Keys {
+		if jwk.Kid == kid {
+			return jwk, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: kid %q", ErrJWKNotFound, kid)
+}
+
+// NewJWK returns the JSON Web Key of an *ecdsa.PublicKey, *ecdsa.PrivateKey,
+// ed25519.PublicKey, ed25519.PrivateKey, *rsa.PublicKey, *rsa.PrivateKey, or
+// of the []byte of a symmetric key. The private members of the JWK of a
+// private key are set, so Public should be used to publish it.
+func NewJWK(key any) (*JWK, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		jwk, err := NewJWK(&k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		d, err := k.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding private ECDSA key: %w", err)
+		}
+		jwk.D = encodeJWKMember(d)
+		return jwk, nil
+	case *ecdsa.PublicKey:
+		crv, err := jwkCurveName(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		point, err := k.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding ECDSA public key: %w", err)
+		}
+		// The uncompressed point is 0x04, X and Y.
+		size := (len(point) - 1) / 2
+		return &JWK{
+			Kty: "EC",
+			Crv: crv,
+			X:   encodeJWKMember(point[1 : 1+size]),
+			Y:   encodeJWKMember(point[1+size:]),
+		}, nil
+	case ed25519.PrivateKey:
+		jwk, err := NewJWK(k.Public())
+		if err != nil {
+			return nil, err
+		}
+		jwk.D = encodeJWKMember(k.Seed())
+		return jwk, nil
+	case ed25519.PublicKey:
+		return &JWK{Kty: "OKP", Crv: "Ed25519", X: encodeJWKMember(k)}, nil
+	case *rsa.PrivateKey:
+		if len(k.Primes) != 2 {
+			return nil, errors.New("multi-prime RSA keys are unsupported")
+		}
+		k.Precompute()
+		jwk, _ := NewJWK(&k.PublicKey)
+		jwk.D = encodeJWKMember(k.D.Bytes())
+		jwk.P = encodeJWKMember(k.Primes[0].Bytes())
+		jwk.Q = encodeJWKMember(k.Primes[1].Bytes())
+		jwk.DP = encodeJWKMember(k.Precomputed.Dp.Bytes())
+		jwk.DQ = encodeJWKMember(k.Precomputed.Dq.Bytes())
+		jwk.QI = encodeJWKMember(k.Precomputed.Qinv.Bytes())
+		return jwk, nil
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA",
+			N:   encodeJWKMember(k.N.Bytes()),
+			E:   encodeJWKMember(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case []byte:
+		return &JWK{Kty: "oct", K: encodeJWKMember(k)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// Key decodes the key of the JWK: an *ecdsa.PublicKey or *ecdsa.PrivateKey,
+// an ed25519.PublicKey or ed25519.PrivateKey, an *rsa.PublicKey or
+// *rsa.PrivateKey, as the JWK has private members or not, or the []byte of a
+// symmetric key.
+func (k *JWK) Key() (any, error) {
+	switch k.Kty {
+	case "EC":
+		return k.ecdsaKey()
+	case "OKP":
+		return k.ed25519Key()
+	case "RSA":
+		return k.rsaKey()
+	case "oct":
+		key, err := decodeJWKMember("k", k.K)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return nil, errors.New("JWK has no k")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWK kty %q", k.Kty)
+	}
+}
+
+// PublicKey decodes the public key of the JWK, as Key does, but returning the
+// public key of a private one. Symmetric keys have none.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	public := k.Public()
+	if public == nil {
+		return nil, fmt.Errorf("JWK kty %q has no public key", k.Kty)
+	}
+	return public.Key()
+}
+
+// Public returns a copy of the JWK without its private members, to publish,
+// or nil for a symmetric key.
+func (k *JWK) Public() *JWK {
+	if k.Kty == "oct" {
+		return nil
+	}
+	public := *k
+	public.D, public.P, public.Q, public.DP, public.DQ, public.QI = "", "", "", "", "", ""
+	return &public
+}
+
+// Thumbprint returns the base64url encoded SHA-256 JWK thumbprint of RFC 7638,
+// which is often used as the kid of the key. The JWK of a private key has the
+// thumbprint of its public key.
+func (k *JWK) Thumbprint() (string, error) {
+	// The required members, which json.Marshal writes in lexicographic
+	// order, without whitespace.
+	var members map[string]string
+	switch k.Kty {
+	case "EC":
+		members = map[string]string{"crv": k.Crv, "kty": k.Kty, "x": k.X, "y": k.Y}
+	case "OKP":
+		members = map[string]string{"crv": k.Crv, "kty": k.Kty, "x": k.X}
+	case "RSA":
+		members = map[string]string{"e": k.E, "kty": k.Kty, "n": k.N}
+	case "oct":
+		members = map[string]string{"k": k.K, "kty": k.Kty}
+	default:
+		return "", fmt.Errorf("unsupported JWK kty %q", k.Kty)
+	}
+	for name, value := range members {
+		if value == "" {
+			return "", fmt.Errorf("JWK has no %s", name)
+		}
+	}
+	buf, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("error encoding JWK thumbprint members: %w", err)
+	}
+	sum := sha256.Sum256(buf)
+	return encodeJWKMember(sum[:]), nil
+}
+
+func (k *JWK) ecdsaKey() (any, error) {
+	curve, err := jwkCurve(k.Crv)
+	if err != nil {
+		return nil, err
+	}
+	x, err := decodeJWKMember("x", k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeJWKMember("y", k.Y)
+	if err != nil {
+		return nil, err
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("JWK x and y aren't %d bytes long for %s", size, k.Crv)
+	}
+	publicKey, err := ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	if err != nil {
+		return nil, fmt.Errorf("error loading ECDSA public key from JWK: %w", err)
+	}
+	if k.D == "" {
+		return publicKey, nil
+	}
+	d, err := decodeJWKMember("d", k.D)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := ecdsa.ParseRawPrivateKey(curve, d)
+	if err != nil {
+		return nil, fmt.Errorf("error loading private ECDSA key from JWK: %w", err)
+	}
+	if !privateKey.PublicKey.Equal(publicKey) {
+		return nil, errors.New("JWK d doesn't match its x and y")
+	}
+	return privateKey, nil
+}
+
+func (k *JWK) ed25519Key() (any, error) {
+	if k.Crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported JWK crv %q", k.Crv)
+	}
+	x, err := decodeJWKMember("x", k.X)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("JWK x isn't %d bytes long for Ed25519", ed25519.PublicKeySize)
+	}
+	publicKey := ed25519.PublicKey(x)
+	if k.D == "" {
+		return publicKey, nil
+	}
+	d, err := decodeJWKMember("d", k.D)
+	if err != nil {
+		return nil, err
+	}
+	if len(d) != ed25519.SeedSize {
+		return nil, fmt.Errorf("JWK d isn't %d bytes long for Ed25519", ed25519.SeedSize)
+	}
+	privateKey := ed25519.NewKeyFromSeed(d)
+	if !publicKey.Equal(privateKey.Public()) {
+		return nil, errors.New("JWK d doesn't match its x")
+	}
+	return privateKey, nil
+}
+
+func (k *JWK) rsaKey() (any, error) {
+	n, err := decodeJWKBigInt("n", k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeJWKBigInt("e", k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, errors.New("JWK e is too large")
+	}
+	publicKey := &rsa.PublicKey{N: n, E: int(e.Int64())}
+	if k.D == "" {
+		return publicKey, nil
+	}
+	privateKey := &rsa.PrivateKey{PublicKey: *publicKey}
+	if privateKey.D, err = decodeJWKBigInt("d", k.D); err != nil {
+		return nil, err
+	}
+	// The first factors, dp, dq and qi, are recomputed from p and q.
+	if k.P == "" || k.Q == "" {
+		return nil, errors.New("JWK of a private RSA key has no p and q")
+	}
+	p, err := decodeJWKBigInt("p", k.P)
+	if err != nil {
+		return nil, err
+	}
+	q, err := decodeJWKBigInt("q", k.Q)
+	if err != nil {
+		return nil, err
+	}
+	privateKey.Primes = []*big.Int{p, q}
+	if err := privateKey.Validate(); err != nil {
+		return nil, fmt.Errorf("error loading private RSA key from JWK: %w", err)
+	}
+	privateKey.Precompute()
+	return privateKey, nil
+}
+
+// jwkCurves are the crv of the supported EC keys.
+var jwkCurves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+func jwkCurve(crv string) (elliptic.Curve, error) {
+	curve, ok := jwkCurves[crv]
+	if !ok {
+		return nil, fmt.Errorf("unsupported JWK crv %q", crv)
+	}
+	return curve, nil
+}
+
+func jwkCurveName(curve elliptic.Curve) (string, error) {
+	for crv, c := range jwkCurves {
+		if c == curve {
+			return crv, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported ECDSA curve %s", curve.Params().Name)
+}
+
+func encodeJWKMember(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJWKMember(name, value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("JWK has no %s", name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding JWK %s: %w", name, err)
+	}
+	return b, nil
+}
+
+func decodeJWKBigInt(name, value string) (*big.Int, error) {
+	b, err := decodeJWKMember(name, value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
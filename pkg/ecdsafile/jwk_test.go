@@ -0,0 +1,188 @@
+package ecdsafile
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJWKThumbprint checks the thumbprints of the examples of RFC 7638 section
+// 3.1 and RFC 8037 appendix A.3.
+func TestJWKThumbprint(t *testing.T) {
+	rsaJWK, err := LoadJWK([]byte(`{
+		"kty": "RSA",
+		"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		"e": "AQAB",
+		"alg": "RS256",
+		"kid": "2011-04-29"
+	}`))
+	require.NoError(t, err)
+	thumbprint, err := rsaJWK.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+
+	edJWK, err := LoadJWK([]byte(`{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"d": "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",
+		"x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	}`))
+	require.NoError(t, err)
+	thumbprint, err = edJWK.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", thumbprint)
+
+	key, err := edJWK.Key()
+	require.NoError(t, err)
+	assert.IsType(t, ed25519.PrivateKey{}, key)
+	publicKey, err := edJWK.PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, key.(ed25519.PrivateKey).Public(), publicKey)
+}
+
+func TestJWKRoundTrip(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, key := range map[string]interface {
+		Public() crypto.PublicKey
+		Equal(crypto.PrivateKey) bool
+	}{
+		"ecdsa":   ecKey,
+		"ed25519": edKey,
+		"rsa":     rsaKey,
+	} {
+		t.Run(name, func(t *testing.T) {
+			jwk, err := NewJWK(key)
+			require.NoError(t, err)
+			buf, err := StoreJWK(jwk)
+			require.NoError(t, err)
+			loaded, err := LoadJWK(buf)
+			require.NoError(t, err)
+
+			privateKey, err := loaded.Key()
+			require.NoError(t, err)
+			assert.True(t, key.Equal(privateKey))
+
+			public := loaded.Public()
+			assert.Empty(t, public.D)
+			publicKey, err := public.Key()
+			require.NoError(t, err)
+			assert.True(t, publicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(key.Public()))
+
+			// The thumbprint is of the public key, and stable.
+			publicJWK, err := NewJWK(key.Public())
+			require.NoError(t, err)
+			want, err := publicJWK.Thumbprint()
+			require.NoError(t, err)
+			got, err := loaded.Thumbprint()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err = NewJWK(&ecdsa.PublicKey{Curve: elliptic.P224()})
+	assert.ErrorContains(t, err, "unsupported ECDSA curve P-224")
+}
+
+func TestJWKS(t *testing.T) {
+	jwks, err := LoadJWKS([]byte(`{"keys": [
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+		{"kty": "OKP", "crv": "Ed25519", "kid": "ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{"kty": "OKP", "crv": "X25519", "kid": "x25519", "x": "hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo"}
+	]}`))
+	require.NoError(t, err)
+
+	jwk, err := jwks.Key("ed25519")
+	require.NoError(t, err)
+	key, err := jwk.Key()
+	require.NoError(t, err)
+	assert.IsType(t, ed25519.PublicKey{}, key)
+
+	jwk, err = jwks.Key("hmac")
+	require.NoError(t, err)
+	key, err = jwk.Key()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), key)
+	assert.Nil(t, jwk.Public(), "a symmetric key isn't published")
+
+	jwk, err = jwks.Key("x25519")
+	require.NoError(t, err)
+	_, err = jwk.Key()
+	assert.EqualError(t, err, `unsupported JWK crv "X25519"`)
+
+	_, err = jwks.Key("other")
+	assert.ErrorIs(t, err, ErrJWKNotFound)
+	_, err = jwks.Key("")
+	assert.ErrorIs(t, err, ErrJWKNotFound, "there's no only key")
+
+	buf, err := StoreJWKS(&JWKS{Keys: jwks.Keys[1:2]})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys": [{"kty": "OKP", "crv": "Ed25519", "kid": "ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, string(buf))
+	loaded, err := LoadJWKS(buf)
+	require.NoError(t, err)
+	jwk, err = loaded.Key("")
+	require.NoError(t, err)
+	assert.Equal(t, "ed25519", jwk.Kid)
+}
+
+func TestLoadStoreKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	buf, err := StoreEd25519PrivateKey(edKey)
+	require.NoError(t, err)
+	loadedEd, err := LoadEd25519PrivateKey(buf)
+	require.NoError(t, err)
+	assert.True(t, edKey.Equal(loadedEd))
+	_, err = LoadRsaPrivateKey(buf)
+	assert.EqualError(t, err, "file contents were not an RSA private key")
+
+	buf, err = StoreEd25519PublicKey(edPublic)
+	require.NoError(t, err)
+	loadedEdPublic, err := LoadEd25519PublicKey(buf)
+	require.NoError(t, err)
+	assert.True(t, edPublic.Equal(loadedEdPublic))
+
+	buf, err = StoreRsaPrivateKey(rsaKey)
+	require.NoError(t, err)
+	loadedRsa, err := LoadRsaPrivateKey(buf)
+	require.NoError(t, err)
+	assert.True(t, rsaKey.Equal(loadedRsa))
+
+	buf, err = StoreRsaPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	loadedRsaPublic, err := LoadRsaPublicKey(buf)
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(loadedRsaPublic))
+
+	// Both the SEC 1 encoding StoreEcdsaPrivateKey writes and the PKCS#8
+	// encoding StorePrivateKey writes are loaded.
+	sec1, err := StoreEcdsaPrivateKey(ecKey)
+	require.NoError(t, err)
+	pkcs8, err := StorePrivateKey(ecKey)
+	require.NoError(t, err)
+	for _, buf := range [][]byte{sec1, pkcs8} {
+		loadedEc, err := LoadEcdsaPrivateKey(buf)
+		require.NoError(t, err)
+		assert.True(t, ecKey.Equal(loadedEc))
+		signer, err := LoadPrivateKey(buf)
+		require.NoError(t, err)
+		assert.True(t, ecKey.Equal(signer))
+	}
+}
@@ -0,0 +1,192 @@
+package ecdsafile
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// These are utilities for working with files containing Ed25519 and RSA keys,
+// or keys of any of the supported types. Private keys are stored in the PKCS#8
+// encoding, and public keys in the X509 (PKIX) encoding, which openssl writes:
+//
+// 1) Generate an Ed25519 private key
+//    openssl genpkey -algorithm ed25519 -out ed25519privatekey.pem
+// 2) Generate a 2048 bit RSA private key
+//    openssl genpkey -algorithm rsa -pkeyopt rsa_keygen_bits:2048 -out rsaprivatekey.pem
+// 3) Generate public key from private key
+//    openssl pkey -in rsaprivatekey.pem -pubout -out rsapubkey.pem
+
+// LoadEd25519PublicKey reads an Ed25519 public key from an X509 encoding stored in a PEM encoding.
+func LoadEd25519PublicKey(buf []byte) (ed25519.PublicKey, error) {
+	keyIface, err := LoadPublicKey(buf)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := keyIface.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("file contents were not an Ed25519 public key")
+	}
+	return publicKey, nil
+}
+
+// LoadEd25519PrivateKey reads an Ed25519 private key from a PKCS#8 encoding stored in a PEM encoding.
+func LoadEd25519PrivateKey(buf []byte) (ed25519.PrivateKey, error) {
+	keyIface, err := LoadPrivateKey(buf)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := keyIface.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("file contents were not an Ed25519 private key")
+	}
+	return privateKey, nil
+}
+
+// StoreEd25519PublicKey writes an Ed25519 public key to a PEM encoding
+func StoreEd25519PublicKey(publicKey ed25519.PublicKey) ([]byte, error) {
+	return StorePublicKey(publicKey)
+}
+
+// StoreEd25519PrivateKey writes an Ed25519 private key to a PEM encoding
+func StoreEd25519PrivateKey(privateKey ed25519.PrivateKey) ([]byte, error) {
+	return StorePrivateKey(privateKey)
+}
+
+// LoadRsaPublicKey reads an RSA public key from an X509 or PKCS#1 encoding stored in a PEM encoding.
+func LoadRsaPublicKey(buf []byte) (*rsa.PublicKey, error) {
+	keyIface, err := LoadPublicKey(buf)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := keyIface.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("file contents were not an RSA public key")
+	}
+	return publicKey, nil
+}
+
+// LoadRsaPrivateKey reads an RSA private key from a PKCS#8 or PKCS#1 encoding stored in a PEM encoding.
+func LoadRsaPrivateKey(buf []byte) (*rsa.PrivateKey, error) {
+	keyIface, err := LoadPrivateKey(buf)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := keyIface.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("file contents were not an RSA private key")
+	}
+	return privateKey, nil
+}
+
+// StoreRsaPublicKey writes an RSA public key to a PEM encoding
+func StoreRsaPublicKey(publicKey *rsa.PublicKey) ([]byte, error) {
+	return StorePublicKey(publicKey)
+}
+
+// StoreRsaPrivateKey writes an RSA private key to a PEM encoding
+func StoreRsaPrivateKey(privateKey *rsa.PrivateKey) ([]byte, error) {
+	return StorePrivateKey(privateKey)
+}
+
+// LoadPublicKey reads a public key of any of the supported types from an X509
+// encoding stored in a PEM encoding, or an RSA public key from a PKCS#1
+// encoding. It's an *ecdsa.PublicKey, ed25519.PublicKey or *rsa.PublicKey.
+func LoadPublicKey(buf []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(buf)
+
+	if block == nil {
+		return nil, errors.New("no PEM data block found")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error loading public key: %w", err)
+		}
+		return publicKey, nil
+	}
+	keyIface, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error loading public key: %w", err)
+	}
+	switch keyIface.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return keyIface, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", keyIface)
+	}
+}
+
+// LoadPrivateKey reads a private key of any of the supported types from a
+// PKCS#8 encoding stored in a PEM encoding, or from the SEC 1 and PKCS#1
+// encodings of ECDSA and RSA private keys. It's an *ecdsa.PrivateKey,
+// ed25519.PrivateKey or *rsa.PrivateKey.
+func LoadPrivateKey(buf []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(buf)
+
+	if block == nil {
+		return nil, errors.New("no PEM data block found")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return LoadEcdsaPrivateKey(buf)
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error loading private RSA key: %w", err)
+		}
+		return privateKey, nil
+	}
+	keyIface, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		// StoreEcdsaPrivateKey writes the SEC 1 encoding of an ECDSA key as a
+		// "PRIVATE KEY" block.
+		if privateKey, ecErr := x509.ParseECPrivateKey(block.Bytes); ecErr == nil {
+			return privateKey, nil
+		}
+		return nil, fmt.Errorf("error loading private key: %w", err)
+	}
+	switch privateKey := keyIface.(type) {
+	case *ecdsa.PrivateKey:
+		return privateKey, nil
+	case ed25519.PrivateKey:
+		return privateKey, nil
+	case *rsa.PrivateKey:
+		return privateKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", keyIface)
+	}
+}
+
+// StorePublicKey writes a public key of any of the supported types to a PEM
+// encoding of its X509 encoding.
+func StorePublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	encodedKey, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error x509 encoding public key: %w", err)
+	}
+	pemEncodedKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: encodedKey,
+	})
+	return pemEncodedKey, nil
+}
+
+// StorePrivateKey writes a private key of any of the supported types to a PEM
+// encoding of its PKCS#8 encoding.
+func StorePrivateKey(privateKey crypto.Signer) ([]byte, error) {
+	encodedKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error pkcs8 encoding private key: %w", err)
+	}
+	pemEncodedKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: encodedKey,
+	})
+	return pemEncodedKey, nil
+}
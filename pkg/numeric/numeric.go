@@ -0,0 +1,282 @@
+// Package numeric contains arbitrary-precision and string-encoded number
+// types, which generated code uses for the `decimal` and `int64-as-string`
+// type mapping presets. Each type has JSON and text methods, so it's encoded
+// correctly in bodies as well as in path, query, header and cookie parameters.
+package numeric
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxScale bounds the exponent of a parsed Decimal, so that a short input
+// such as "1e999999999" can't expand into a huge string.
+const maxScale = 1 << 16
+
+// Decimal is an arbitrary-precision decimal number, for schemas of
+// `type: string, format: decimal`. It's encoded in JSON as a string, e.g.
+// "12.50", and decoded from either a string or a number, without going
+// through a float. The scale of the parsed value is kept, so "12.50" is
+// written back as "12.50". The zero value is 0.
+type Decimal struct {
+	// The value is unscaled × 10^-scale; a nil unscaled is zero.
+	unscaled *big.Int
+	scale    int32
+}
+
+// Parse parses a decimal number in the syntax of a JSON number, with an
+// optional leading sign, e.g. "-12.50", "+3" or "1.5e-3".
+func Parse(s string) (Decimal, error) {
+	mantissa, exponent, hasExponent := s, "", false
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent, hasExponent = s[:i], s[i+1:], true
+	}
+
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	whole, fraction, hasPoint := strings.Cut(mantissa, ".")
+	if whole == "" || !isDigits(whole) || (hasPoint && (fraction == "" || !isDigits(fraction))) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fraction))
+	if hasExponent {
+		e, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: bad exponent", s)
+		}
+		scale -= e
+	}
+	if scale > maxScale || scale < -maxScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+whole+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParse is like Parse, but panics if s isn't a valid decimal number.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// New returns the decimal unscaled × 10^-scale.
+func New(unscaled *big.Int, scale int32) Decimal {
+	return Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// NewFromInt64 returns the decimal with the integer value v.
+func NewFromInt64(v int64) Decimal {
+	return Decimal{unscaled: big.NewInt(v)}
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Unscaled returns the unscaled value of d, which is d × 10^Scale().
+func (d Decimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the number of digits of d after the decimal point, or minus
+// the number of trailing zeros of an integer given with an exponent.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 for a negative, zero or positive d.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// IsZero reports whether d is zero, at any scale.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.Unscaled())
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(d.scale))), nil)
+	if d.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow))
+}
+
+// Float64 returns the float64 nearest to d, and whether it's exact.
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Cmp compares the values of d and other, regardless of their scales,
+// returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Equal reports whether d and other have the same value, so that 1.50 equals
+// 1.5.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// String returns d in plain decimal notation, without an exponent, e.g.
+// "-12.50".
+func (d Decimal) String() string {
+	digits := d.Unscaled().String()
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if d.scale <= 0 {
+		if digits == "0" {
+			return sign + digits
+		}
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	scale := int(d.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes d from a JSON string or number. A null leaves d
+// unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s, err := numberText(data)
+	if err != nil || s == "" {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalText encodes d in plain decimal notation, as in parameters.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes d from its text form.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Bind decodes d from a parameter value, as the Binder interface of the
+// oapi-codegen runtime, which binds parameters of struct types such as
+// Decimal with it, rather than as objects.
+func (d *Decimal) Bind(src string) error {
+	return d.UnmarshalText([]byte(src))
+}
+
+// Number is a Decimal for schemas of `type: number, format: decimal`. It's
+// encoded in JSON as a number, e.g. 12.50, with all of its digits, and decoded
+// from either a number or a string.
+type Number struct {
+	Decimal
+}
+
+// MarshalJSON encodes n as a JSON number.
+func (n Number) MarshalJSON() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// Int64String is an int64 encoded in JSON as a string, e.g. "9007199254740993",
+// for schemas of `type: string, format: int64`. The string keeps integers
+// beyond 2^53 intact for clients that decode JSON numbers as floats. It's
+// decoded from either a string or a number, through json.Number, so no
+// precision is lost either way.
+type Int64String int64
+
+// MarshalJSON encodes i as a JSON string.
+func (i Int64String) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(i), 10))), nil
+}
+
+// UnmarshalJSON decodes i from a JSON string or number. A null leaves i
+// unchanged.
+func (i *Int64String) UnmarshalJSON(data []byte) error {
+	s, err := numberText(data)
+	if err != nil || s == "" {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// MarshalText encodes i in decimal, as in parameters.
+func (i Int64String) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(i), 10)), nil
+}
+
+// UnmarshalText decodes i from its text form.
+func (i *Int64String) UnmarshalText(text []byte) error {
+	v, err := json.Number(text).Int64()
+	if err != nil {
+		return fmt.Errorf("invalid int64 %q", text)
+	}
+	*i = Int64String(v)
+	return nil
+}
+
+// numberText returns the text of a JSON string or number, or "" for null.
+func numberText(data []byte) (string, error) {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case json.Number:
+		return string(v), nil
+	case string:
+		if v == "" {
+			return "", fmt.Errorf("empty string is not a number")
+		}
+		return v, nil
+	}
+	return "", fmt.Errorf("cannot decode %s as a number", data)
+}
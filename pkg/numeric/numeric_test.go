@@ -0,0 +1,91 @@
+package numeric
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"12.50", "12.50"},
+		{"-0.5", "-0.5"},
+		{"+3", "3"},
+		{"007", "7"},
+		{"1.5e-3", "0.0015"},
+		{"1.5E3", "1500"},
+		{"0e5", "0"},
+		{"123456789012345678901234567890.000000001", "123456789012345678901234567890.000000001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := Parse(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+
+	for _, in := range []string{"", "-", ".5", "1.", "1.2.3", "1e", "abc", "1_000", "1e99999999"} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, err := Parse(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	assert.True(t, MustParse("1.50").Equal(MustParse("1.5")))
+	assert.Equal(t, -1, MustParse("0.1").Cmp(MustParse("1e-0")))
+	assert.Equal(t, 1, MustParse("-1").Cmp(MustParse("-1.0001")))
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "0", Decimal{}.String())
+
+	f, exact := MustParse("0.25").Float64()
+	assert.Equal(t, 0.25, f)
+	assert.True(t, exact)
+}
+
+func TestJSON(t *testing.T) {
+	type values struct {
+		Decimal Decimal     `json:"decimal"`
+		Number  Number      `json:"number"`
+		Int     Int64String `json:"int"`
+	}
+
+	var v values
+	require.NoError(t, json.Unmarshal([]byte(`{"decimal":0.10,"number":"98765432109876543210.5","int":9007199254740993}`), &v))
+	assert.Equal(t, "0.10", v.Decimal.String())
+	assert.Equal(t, "98765432109876543210.5", v.Number.String())
+	assert.Equal(t, Int64String(9007199254740993), v.Int)
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"decimal":"0.10","number":98765432109876543210.5,"int":"9007199254740993"}`, string(b))
+	assert.Contains(t, string(b), `"number":98765432109876543210.5`)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"decimal":null,"int":null}`), &v))
+	assert.Equal(t, "0.10", v.Decimal.String())
+
+	for _, in := range []string{`{"decimal":""}`, `{"decimal":true}`, `{"int":"1.5"}`, `{"int":"x"}`} {
+		assert.Error(t, json.Unmarshal([]byte(in), &v), in)
+	}
+}
+
+func TestText(t *testing.T) {
+	var n Number
+	require.NoError(t, n.UnmarshalText([]byte("-1.25")))
+	text, err := n.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "-1.25", string(text))
+
+	var i Int64String
+	require.NoError(t, i.UnmarshalText([]byte("-42")))
+	assert.Equal(t, Int64String(-42), i)
+	assert.Error(t, i.UnmarshalText([]byte("42.0")))
+}
@@ -0,0 +1,644 @@
+package securityprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/ecdsafile"
+)
+
+// These implement HTTP Message Signatures, RFC 9421, with the Content-Digest
+// of RFC 9530 for the body.
+
+const (
+	// ErrHTTPSignatureMissing indicates a request without an HTTP message
+	// signature.
+	ErrHTTPSignatureMissing = SecurityProviderError("no http message signature")
+	// ErrHTTPSignatureInvalid indicates a request whose HTTP message
+	// signature doesn't verify.
+	ErrHTTPSignatureInvalid = SecurityProviderError("invalid http message signature")
+	// ErrHTTPSignatureBodyTooLarge indicates a request whose body is larger
+	// than the MaxBodyBytes of HTTPSignatureVerifierOptions.
+	ErrHTTPSignatureBodyTooLarge = SecurityProviderError("request body too large to verify its http message signature")
+)
+
+const (
+	defaultHTTPSignatureLabel  = "sig1"
+	defaultHTTPSignatureMaxAge = 5 * time.Minute
+	// defaultHTTPSignatureMaxBodyBytes is the MaxBodyBytes of
+	// HTTPSignatureVerifierOptions which have none.
+	defaultHTTPSignatureMaxBodyBytes = 10 << 20
+	// httpSignatureClockSkew is how far in the future a created time is
+	// accepted.
+	httpSignatureClockSkew = time.Minute
+)
+
+// HTTPSignatureSigner signs signature bases, with an algorithm of the HTTP
+// Signature Algorithms registry.
+type HTTPSignatureSigner interface {
+	// Algorithm is the name of the algorithm, such as ed25519.
+	Algorithm() string
+	Sign(base []byte) ([]byte, error)
+}
+
+// HTTPSignatureVerifier verifies the signatures of signature bases, with an
+// algorithm of the HTTP Signature Algorithms registry.
+type HTTPSignatureVerifier interface {
+	// Algorithm is the name of the algorithm, such as ed25519.
+	Algorithm() string
+	Verify(base, signature []byte) error
+}
+
+// NewHTTPSignatureSignerHMACSHA256 returns a signer with the hmac-sha256
+// algorithm and the shared secret.
+func NewHTTPSignatureSignerHMACSHA256(secret []byte) (HTTPSignatureSigner, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty hmac secret")
+	}
+	return hmacSHA256{secret: secret}, nil
+}
+
+// NewHTTPSignatureVerifierHMACSHA256 returns a verifier with the hmac-sha256
+// algorithm and the shared secret.
+func NewHTTPSignatureVerifierHMACSHA256(secret []byte) (HTTPSignatureVerifier, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty hmac secret")
+	}
+	return hmacSHA256{secret: secret}, nil
+}
+
+// NewHTTPSignatureSignerECDSAP256 returns a signer with the
+// ecdsa-p256-sha256 algorithm and the P-256 key.
+func NewHTTPSignatureSignerECDSAP256(key *ecdsa.PrivateKey) (HTTPSignatureSigner, error) {
+	if key == nil || key.Curve != elliptic.P256() {
+		return nil, errors.New("not an ECDSA P-256 private key")
+	}
+	return ecdsaP256Signer{key: key}, nil
+}
+
+// NewHTTPSignatureVerifierECDSAP256 returns a verifier with the
+// ecdsa-p256-sha256 algorithm and the P-256 key.
+func NewHTTPSignatureVerifierECDSAP256(key *ecdsa.PublicKey) (HTTPSignatureVerifier, error) {
+	if key == nil || key.Curve != elliptic.P256() {
+		return nil, errors.New("not an ECDSA P-256 public key")
+	}
+	return ecdsaP256Verifier{key: key}, nil
+}
+
+// LoadHTTPSignatureSignerECDSAP256 is NewHTTPSignatureSignerECDSAP256 with
+// the key ecdsafile.LoadEcdsaPrivateKey reads from the PEM data.
+func LoadHTTPSignatureSignerECDSAP256(data []byte) (HTTPSignatureSigner, error) {
+	key, err := ecdsafile.LoadEcdsaPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewHTTPSignatureSignerECDSAP256(key)
+}
+
+// LoadHTTPSignatureVerifierECDSAP256 is NewHTTPSignatureVerifierECDSAP256
+// with the key ecdsafile.LoadEcdsaPublicKey reads from the PEM data.
+func LoadHTTPSignatureVerifierECDSAP256(data []byte) (HTTPSignatureVerifier, error) {
+	key, err := ecdsafile.LoadEcdsaPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewHTTPSignatureVerifierECDSAP256(key)
+}
+
+// NewHTTPSignatureSignerEd25519 returns a signer with the ed25519 algorithm
+// and the key.
+func NewHTTPSignatureSignerEd25519(key ed25519.PrivateKey) (HTTPSignatureSigner, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+	return ed25519Signer{key: key}, nil
+}
+
+// NewHTTPSignatureVerifierEd25519 returns a verifier with the ed25519
+// algorithm and the key.
+func NewHTTPSignatureVerifierEd25519(key ed25519.PublicKey) (HTTPSignatureVerifier, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("not an Ed25519 public key")
+	}
+	return ed25519Verifier{key: key}, nil
+}
+
+type hmacSHA256 struct {
+	secret []byte
+}
+
+func (k hmacSHA256) Algorithm() string { return "hmac-sha256" }
+
+func (k hmacSHA256) Sign(base []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write(base)
+	return mac.Sum(nil), nil
+}
+
+func (k hmacSHA256) Verify(base, signature []byte) error {
+	expected, _ := k.Sign(base)
+	if !hmac.Equal(expected, signature) {
+		return errors.New("hmac mismatch")
+	}
+	return nil
+}
+
+type ecdsaP256Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+func (k ecdsaP256Signer) Algorithm() string { return "ecdsa-p256-sha256" }
+
+// Sign returns the signature as the concatenated r and s, rather than as
+// ASN.1, as RFC 9421 section 3.3.4 says.
+func (k ecdsaP256Signer) Sign(base []byte) ([]byte, error) {
+	digest := sha256.Sum256(base)
+	r, s, err := ecdsa.Sign(rand.Reader, k.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signature, nil
+}
+
+type ecdsaP256Verifier struct {
+	key *ecdsa.PublicKey
+}
+
+func (k ecdsaP256Verifier) Algorithm() string { return "ecdsa-p256-sha256" }
+
+func (k ecdsaP256Verifier) Verify(base, signature []byte) error {
+	if len(signature) != 64 {
+		return errors.New("ecdsa-p256-sha256 signature isn't 64 bytes")
+	}
+	digest := sha256.Sum256(base)
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(k.key, digest[:], r, s) {
+		return errors.New("ecdsa signature mismatch")
+	}
+	return nil
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func (k ed25519Signer) Algorithm() string { return "ed25519" }
+
+func (k ed25519Signer) Sign(base []byte) ([]byte, error) {
+	return ed25519.Sign(k.key, base), nil
+}
+
+type ed25519Verifier struct {
+	key ed25519.PublicKey
+}
+
+func (k ed25519Verifier) Algorithm() string { return "ed25519" }
+
+func (k ed25519Verifier) Verify(base, signature []byte) error {
+	if !ed25519.Verify(k.key, base, signature) {
+		return errors.New("ed25519 signature mismatch")
+	}
+	return nil
+}
+
+// HTTPSignatureOptions configures NewSecurityProviderHTTPSignature.
+type HTTPSignatureOptions struct {
+	// KeyID is the keyid of the signatures, which the verifier finds the
+	// key with.
+	KeyID string
+	// Signer signs the requests.
+	Signer HTTPSignatureSigner
+	// Label is the label of the signatures. Defaults to sig1.
+	Label string
+	// Components are the covered components: derived components, such as
+	// @method, @target-uri, @authority, @path and @query, and lowercase
+	// header names. Defaults to @method and @target-uri, and content-digest
+	// for requests with a body.
+	Components []string
+	// Expires is how long the signatures are valid for, when it's not zero.
+	Expires time.Duration
+	// Tag is the tag of the signatures, when it's not empty.
+	Tag string
+}
+
+// NewSecurityProviderHTTPSignature provides a SecurityProvider, which signs
+// api-calls with HTTP message signatures, as RFC 9421 says.
+func NewSecurityProviderHTTPSignature(opts HTTPSignatureOptions) (*SecurityProviderHTTPSignature, error) {
+	if opts.Signer == nil {
+		return nil, errors.New("no signer specified for http message signatures")
+	}
+	if opts.Label == "" {
+		opts.Label = defaultHTTPSignatureLabel
+	}
+	return &SecurityProviderHTTPSignature{opts: opts, now: time.Now}, nil
+}
+
+// SecurityProviderHTTPSignature sends an HTTP message signature, in the
+// Signature and Signature-Input headers, and the Content-Digest of the body,
+// along with a request.
+type SecurityProviderHTTPSignature struct {
+	opts HTTPSignatureOptions
+	now  func() time.Time
+}
+
+// Intercept will attach a Content-Digest header to a request with a body,
+// and sign the request. The Intercept must run after request editors which
+// change what the signature covers.
+func (s *SecurityProviderHTTPSignature) Intercept(ctx context.Context, req *http.Request) error {
+	body, err := requestBody(req, 0)
+	if err != nil {
+		return err
+	}
+	components := s.opts.Components
+	if components == nil {
+		components = []string{"@method", "@target-uri"}
+		if body != nil {
+			components = append(components, "content-digest")
+		}
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		req.Header.Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":")
+	}
+
+	created := s.now()
+	params := []httpSigParam{{"created", created.Unix()}}
+	if s.opts.Expires > 0 {
+		params = append(params, httpSigParam{"expires", created.Add(s.opts.Expires).Unix()})
+	}
+	if s.opts.KeyID != "" {
+		params = append(params, httpSigParam{"keyid", s.opts.KeyID})
+	}
+	params = append(params, httpSigParam{"alg", s.opts.Signer.Algorithm()})
+	if s.opts.Tag != "" {
+		params = append(params, httpSigParam{"tag", s.opts.Tag})
+	}
+	sigParams := serializeHTTPSigParams(components, params)
+
+	base, err := httpSignatureBase(req, req.URL.Scheme, components, sigParams)
+	if err != nil {
+		return err
+	}
+	signature, err := s.opts.Signer.Sign(base)
+	if err != nil {
+		return fmt.Errorf("error signing the request: %w", err)
+	}
+	req.Header.Set("Signature-Input", s.opts.Label+"="+sigParams)
+	req.Header.Set("Signature", s.opts.Label+"=:"+base64.StdEncoding.EncodeToString(signature)+":")
+	return nil
+}
+
+// HTTPSignatureVerifierOptions configures VerifyHTTPSignature.
+type HTTPSignatureVerifierOptions struct {
+	// Keys are the verifiers of the keyids.
+	Keys map[string]HTTPSignatureVerifier
+	// Label is the label of the signature which is verified. Defaults to
+	// any of them, one of which must verify.
+	Label string
+	// RequiredComponents must be covered by the signature. Defaults to
+	// @method and @target-uri, and content-digest for requests with a
+	// body.
+	RequiredComponents []string
+	// MaxAge is how long after it's created a signature is accepted.
+	// Defaults to 5 minutes; a negative MaxAge accepts signatures of any
+	// age, and without a created time.
+	MaxAge time.Duration
+	// Scheme is the scheme of @target-uri and @scheme. Defaults to https for
+	// TLS requests, and http otherwise; it's set behind a proxy terminating
+	// TLS.
+	Scheme string
+	// MaxBodyBytes is the largest request body which is read, to verify its
+	// Content-Digest; a request with a larger one is rejected with
+	// ErrHTTPSignatureBodyTooLarge. Defaults to 10 MiB.
+	MaxBodyBytes int64
+}
+
+type httpSignatureKeyIDKey struct{}
+
+// HTTPSignatureKeyID returns the keyid of the HTTP message signature the
+// middleware of NewHTTPSignatureMiddleware verified, when it did.
+func HTTPSignatureKeyID(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(httpSignatureKeyIDKey{}).(string)
+	return keyID, ok
+}
+
+// NewHTTPSignatureMiddleware returns server middleware, which responds 401
+// Unauthorized to requests without a valid HTTP message signature, as
+// VerifyHTTPSignature says, or 413 Request Entity Too Large to those whose
+// body is too large to verify, and puts the keyid of the signature in the
+// context of the others, for HTTPSignatureKeyID.
+func NewHTTPSignatureMiddleware(opts HTTPSignatureVerifierOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID, err := VerifyHTTPSignature(r, opts)
+			if errors.Is(err, ErrHTTPSignatureBodyTooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), httpSignatureKeyIDKey{}, keyID)))
+		})
+	}
+}
+
+// VerifyHTTPSignature verifies the HTTP message signature of the received
+// request r, with the key of its keyid, and that it covers the required
+// components, and the Content-Digest of its body, which is kept readable. It
+// returns the keyid.
+func VerifyHTTPSignature(r *http.Request, opts HTTPSignatureVerifierOptions) (string, error) {
+	if r.Header.Get("Signature-Input") == "" || r.Header.Get("Signature") == "" {
+		return "", ErrHTTPSignatureMissing
+	}
+	inputs, err := parseHTTPSigDictionary(strings.Join(r.Header.Values("Signature-Input"), ", "))
+	if err != nil {
+		return "", fmt.Errorf("%w: Signature-Input: %w", ErrHTTPSignatureInvalid, err)
+	}
+	signatures, err := parseHTTPSigDictionary(strings.Join(r.Header.Values("Signature"), ", "))
+	if err != nil {
+		return "", fmt.Errorf("%w: Signature: %w", ErrHTTPSignatureInvalid, err)
+	}
+
+	maxBodyBytes := opts.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultHTTPSignatureMaxBodyBytes
+	}
+	body, err := requestBody(r, maxBodyBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := verifyContentDigest(r, body); err != nil {
+		return "", err
+	}
+
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+	required := opts.RequiredComponents
+	if required == nil {
+		required = []string{"@method", "@target-uri"}
+		if body != nil {
+			required = append(required, "content-digest")
+		}
+	}
+
+	var errs []error
+	for _, input := range inputs {
+		if opts.Label != "" && input.name != opts.Label {
+			continue
+		}
+		keyID, err := verifyHTTPSignatureInput(r, scheme, input, signatures, required, opts)
+		if err == nil {
+			return keyID, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", input.name, err))
+	}
+	if len(errs) == 0 {
+		return "", ErrHTTPSignatureMissing
+	}
+	return "", fmt.Errorf("%w: %w", ErrHTTPSignatureInvalid, errors.Join(errs...))
+}
+
+// verifyHTTPSignatureInput verifies the signature of input, returning its
+// keyid.
+func verifyHTTPSignatureInput(r *http.Request, scheme string, input httpSigMember, signatures []httpSigMember, required []string, opts HTTPSignatureVerifierOptions) (string, error) {
+	if !input.isList {
+		return "", errors.New("the input isn't an inner list")
+	}
+	var components []string
+	for _, item := range input.list {
+		name, ok := item.value.(string)
+		if !ok || len(item.params) > 0 {
+			return "", errors.New("unsupported component")
+		}
+		components = append(components, name)
+	}
+	for _, name := range required {
+		if !slices.Contains(components, name) {
+			return "", fmt.Errorf("%q isn't covered", name)
+		}
+	}
+
+	var keyID, alg string
+	var created, expires int64
+	var hasCreated bool
+	for _, p := range input.params {
+		switch p.name {
+		case "keyid":
+			keyID, _ = p.value.(string)
+		case "alg":
+			alg, _ = p.value.(string)
+		case "created":
+			created, hasCreated = p.value.(int64)
+		case "expires":
+			expires, _ = p.value.(int64)
+		}
+	}
+	now := time.Now()
+	if expires != 0 && now.After(time.Unix(expires, 0)) {
+		return "", errors.New("expired")
+	}
+	if opts.MaxAge >= 0 {
+		maxAge := opts.MaxAge
+		if maxAge == 0 {
+			maxAge = defaultHTTPSignatureMaxAge
+		}
+		if !hasCreated {
+			return "", errors.New("no created time")
+		}
+		t := time.Unix(created, 0)
+		if now.Sub(t) > maxAge || t.Sub(now) > httpSignatureClockSkew {
+			return "", errors.New("created time out of range")
+		}
+	}
+
+	verifier, ok := opts.Keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown keyid %q", keyID)
+	}
+	if alg != "" && alg != verifier.Algorithm() {
+		return "", fmt.Errorf("alg %q isn't that of the key", alg)
+	}
+	i := slices.IndexFunc(signatures, func(m httpSigMember) bool { return m.name == input.name })
+	if i < 0 {
+		return "", errors.New("no signature")
+	}
+	signature, ok := signatures[i].value.([]byte)
+	if !ok || signatures[i].isList {
+		return "", errors.New("the signature isn't a byte sequence")
+	}
+
+	base, err := httpSignatureBase(r, scheme, components, serializeHTTPSigParams(components, input.params))
+	if err != nil {
+		return "", err
+	}
+	if err := verifier.Verify(base, signature); err != nil {
+		return "", err
+	}
+	return keyID, nil
+}
+
+// requestBody returns the body of req, which is kept readable, or nil when
+// it has none. With a positive limit, a body larger than it isn't read past
+// it, and is an ErrHTTPSignatureBodyTooLarge.
+func requestBody(req *http.Request, limit int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if limit > 0 && req.ContentLength > limit {
+		return nil, ErrHTTPSignatureBodyTooLarge
+	}
+	readAll := func(r io.Reader) ([]byte, error) {
+		if limit <= 0 {
+			return io.ReadAll(r)
+		}
+		body, err := io.ReadAll(io.LimitReader(r, limit+1))
+		if err == nil && int64(len(body)) > limit {
+			return nil, ErrHTTPSignatureBodyTooLarge
+		}
+		return body, err
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		return readAll(rc)
+	}
+	body, err := readAll(req.Body)
+	_ = req.Body.Close()
+	if errors.Is(err, ErrHTTPSignatureBodyTooLarge) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// verifyContentDigest verifies the sha-256 and sha-512 digests of the
+// Content-Digest of r, when it has one, of which there must be one.
+func verifyContentDigest(r *http.Request, body []byte) error {
+	header := strings.Join(r.Header.Values("Content-Digest"), ", ")
+	if header == "" {
+		return nil
+	}
+	digests, err := parseHTTPSigDictionary(header)
+	if err != nil {
+		return fmt.Errorf("%w: Content-Digest: %w", ErrHTTPSignatureInvalid, err)
+	}
+	verified := false
+	for _, d := range digests {
+		var sum []byte
+		switch d.name {
+		case "sha-256":
+			s := sha256.Sum256(body)
+			sum = s[:]
+		case "sha-512":
+			s := sha512.Sum512(body)
+			sum = s[:]
+		default:
+			continue
+		}
+		digest, ok := d.value.([]byte)
+		if !ok || subtle.ConstantTimeCompare(digest, sum) != 1 {
+			return fmt.Errorf("%w: the Content-Digest %s doesn't match the body", ErrHTTPSignatureInvalid, d.name)
+		}
+		verified = true
+	}
+	if !verified {
+		return fmt.Errorf("%w: the Content-Digest has no sha-256 or sha-512 digest", ErrHTTPSignatureInvalid)
+	}
+	return nil
+}
+
+// httpSignatureBase returns the signature base of RFC 9421 section 2.5, of
+// the components of req, whose scheme is scheme.
+func httpSignatureBase(req *http.Request, scheme string, components []string, sigParams string) ([]byte, error) {
+	var b strings.Builder
+	for _, name := range components {
+		value, err := httpSigComponent(req, scheme, name)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(strconv.Quote(name))
+		b.WriteString(": ")
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	b.WriteString(`"@signature-params": `)
+	b.WriteString(sigParams)
+	return []byte(b.String()), nil
+}
+
+// httpSigComponent returns the value of the component name of req.
+func httpSigComponent(req *http.Request, scheme, name string) (string, error) {
+	authority := req.Host
+	if authority == "" {
+		authority = req.URL.Host
+	}
+	authority = strings.ToLower(authority)
+	switch name {
+	case "@method":
+		return req.Method, nil
+	case "@target-uri":
+		return strings.ToLower(scheme) + "://" + authority + req.URL.RequestURI(), nil
+	case "@authority":
+		return authority, nil
+	case "@scheme":
+		return strings.ToLower(scheme), nil
+	case "@request-target":
+		return req.URL.RequestURI(), nil
+	case "@path":
+		if p := req.URL.EscapedPath(); p != "" {
+			return p, nil
+		}
+		return "/", nil
+	case "@query":
+		return "?" + req.URL.RawQuery, nil
+	}
+	if strings.HasPrefix(name, "@") || name != strings.ToLower(name) {
+		return "", fmt.Errorf("unsupported component %q", name)
+	}
+	values := slices.Clone(req.Header.Values(name))
+	if len(values) == 0 {
+		return "", fmt.Errorf("the request has no %q header", name)
+	}
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(values, ", "), nil
+}
@@ -0,0 +1,270 @@
+package securityprovider
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// These are the parts of the Structured Field Values of RFC 8941 which the
+// Signature-Input, Signature and Content-Digest dictionaries use: inner
+// lists, strings, tokens, integers, booleans and byte sequences, with
+// parameters.
+
+// httpSigToken is a token, which is serialized without quotes.
+type httpSigToken string
+
+// httpSigParam is a parameter; its value is a string, httpSigToken, int64,
+// bool or []byte.
+type httpSigParam struct {
+	name  string
+	value any
+}
+
+// httpSigItem is an item, of an inner list.
+type httpSigItem struct {
+	value  any
+	params []httpSigParam
+}
+
+// httpSigMember is a member of a dictionary: an inner list, in list, or an
+// item, in value.
+type httpSigMember struct {
+	name   string
+	isList bool
+	list   []httpSigItem
+	value  any
+	params []httpSigParam
+}
+
+// serializeHTTPSigParams serializes the inner list of the components, with
+// params, as the value of the @signature-params component.
+func serializeHTTPSigParams(components []string, params []httpSigParam) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, c := range components {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(serializeHTTPSigBareItem(c))
+	}
+	b.WriteByte(')')
+	for _, p := range params {
+		b.WriteByte(';')
+		b.WriteString(p.name)
+		if p.value != true {
+			b.WriteByte('=')
+			b.WriteString(serializeHTTPSigBareItem(p.value))
+		}
+	}
+	return b.String()
+}
+
+func serializeHTTPSigBareItem(v any) string {
+	switch v := v.(type) {
+	case string:
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+	case httpSigToken:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		if v {
+			return "?1"
+		}
+		return "?0"
+	case []byte:
+		return ":" + base64.StdEncoding.EncodeToString(v) + ":"
+	}
+	return fmt.Sprint(v)
+}
+
+// parseHTTPSigDictionary parses the dictionary s.
+func parseHTTPSigDictionary(s string) ([]httpSigMember, error) {
+	p := &httpSigParser{s: s}
+	p.skip(" \t")
+	var members []httpSigMember
+	for p.pos < len(s) {
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		m := httpSigMember{name: name, value: true}
+		if p.consume('=') {
+			if p.peek() == '(' {
+				m.isList = true
+				m.list, err = p.innerList()
+			} else {
+				m.value, err = p.bareItem()
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if m.params, err = p.params(); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+
+		p.skip(" \t")
+		if p.pos == len(s) {
+			break
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("unexpected %q at %d", s[p.pos], p.pos)
+		}
+		p.skip(" \t")
+		if p.pos == len(s) {
+			return nil, errors.New("trailing comma")
+		}
+	}
+	return members, nil
+}
+
+type httpSigParser struct {
+	s   string
+	pos int
+}
+
+func (p *httpSigParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *httpSigParser) consume(c byte) bool {
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *httpSigParser) skip(chars string) {
+	for p.pos < len(p.s) && strings.IndexByte(chars, p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *httpSigParser) key() (string, error) {
+	start := p.pos
+	if c := p.peek(); !(c >= 'a' && c <= 'z') && c != '*' {
+		return "", fmt.Errorf("invalid key at %d", p.pos)
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && strings.IndexByte("_-.*", c) < 0 {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *httpSigParser) innerList() ([]httpSigItem, error) {
+	p.pos++ // (
+	var items []httpSigItem
+	for {
+		p.skip(" ")
+		if p.consume(')') {
+			return items, nil
+		}
+		v, err := p.bareItem()
+		if err != nil {
+			return nil, err
+		}
+		params, err := p.params()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, httpSigItem{value: v, params: params})
+		if c := p.peek(); c != ' ' && c != ')' {
+			return nil, fmt.Errorf("unterminated inner list at %d", p.pos)
+		}
+	}
+}
+
+func (p *httpSigParser) params() ([]httpSigParam, error) {
+	var params []httpSigParam
+	for p.consume(';') {
+		p.skip(" ")
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		var value any = true
+		if p.consume('=') {
+			if value, err = p.bareItem(); err != nil {
+				return nil, err
+			}
+		}
+		params = append(params, httpSigParam{name: name, value: value})
+	}
+	return params, nil
+}
+
+func (p *httpSigParser) bareItem() (any, error) {
+	start := p.pos
+	switch c := p.peek(); {
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.pos++
+		p.skip("0123456789")
+		n, err := strconv.ParseInt(p.s[start:p.pos], 10, 64)
+		if err != nil || p.peek() == '.' {
+			return nil, fmt.Errorf("unsupported number at %d", start)
+		}
+		return n, nil
+	case c == '"':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.s) {
+			c := p.s[p.pos]
+			p.pos++
+			switch {
+			case c == '"':
+				return b.String(), nil
+			case c == '\\' && p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\\'):
+				b.WriteByte(p.s[p.pos])
+				p.pos++
+			case c < 0x20 || c > 0x7e || c == '\\':
+				return nil, fmt.Errorf("invalid string at %d", start)
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return nil, fmt.Errorf("unterminated string at %d", start)
+	case c == ':':
+		end := strings.IndexByte(p.s[p.pos+1:], ':')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated byte sequence at %d", start)
+		}
+		data, err := base64.StdEncoding.DecodeString(p.s[p.pos+1 : p.pos+1+end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid byte sequence at %d: %w", start, err)
+		}
+		p.pos += end + 2
+		return data, nil
+	case c == '?':
+		p.pos++
+		switch {
+		case p.consume('1'):
+			return true, nil
+		case p.consume('0'):
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean at %d", start)
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '*':
+		for p.pos < len(p.s) {
+			c := p.s[p.pos]
+			if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),;<=>?@[\]{}`, c) >= 0 {
+				break
+			}
+			p.pos++
+		}
+		return httpSigToken(p.s[start:p.pos]), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", p.peek(), p.pos)
+}
@@ -0,0 +1,707 @@
+package securityprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // For the SHA-256 of RS256, ES256 and so on.
+	_ "crypto/sha512" // For the SHA-384 and SHA-512 of RS384, ES512 and so on.
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/ecdsafile"
+)
+
+// These verify the JSON Web Tokens (RFC 7519) of `http` security schemes with
+// `bearerFormat: JWT`, which are sent as bearer tokens (RFC 6750).
+
+const (
+	// ErrJWTMissing indicates a request without a bearer token.
+	ErrJWTMissing = SecurityProviderError("no bearer token")
+	// ErrJWTInvalid indicates a bearer token which isn't a valid JWT, whose
+	// signature doesn't verify, or whose claims aren't accepted.
+	ErrJWTInvalid = SecurityProviderError("invalid bearer token")
+	// ErrJWTInsufficientScope indicates a valid JWT without the scopes the
+	// operation requires.
+	ErrJWTInsufficientScope = SecurityProviderError("insufficient scope")
+	// ErrJWTInvalidRequest indicates a request with more than one bearer
+	// token.
+	ErrJWTInvalidRequest = SecurityProviderError("invalid bearer token request")
+)
+
+const (
+	defaultJWTScopeClaim          = "scope"
+	defaultJWKSRefreshInterval    = time.Hour
+	defaultJWKSMinRefreshInterval = time.Minute
+	defaultJWKSRequestTimeout     = 30 * time.Second
+)
+
+// defaultJWTAlgorithms are the Algorithms of JWTOptions which have none. The
+// HMAC ones aren't, as a secret shared with the issuer isn't usual.
+var defaultJWTAlgorithms = []string{
+	"ES256", "ES384", "ES512", "EdDSA",
+	"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+}
+
+// JWTKeys provides the keys which verify JWTs.
+type JWTKeys interface {
+	// JWTKey returns the key with the kid of the header of a JWT, which is
+	// empty when it has none. It returns an error wrapping
+	// ecdsafile.ErrJWKNotFound when there's no such key.
+	JWTKey(ctx context.Context, kid string) (*ecdsafile.JWK, error)
+}
+
+// NewJWTKeys returns the JWTKeys of a JWKS, such as one ecdsafile.LoadJWKS
+// reads.
+func NewJWTKeys(jwks *ecdsafile.JWKS) JWTKeys {
+	return jwksKeys{jwks: jwks}
+}
+
+// NewJWTKeysFromPublicKey returns JWTKeys of a single public key, such as
+// one ecdsafile.LoadPublicKey reads, which verifies JWTs of any kid.
+func NewJWTKeysFromPublicKey(key crypto.PublicKey) (JWTKeys, error) {
+	jwk, err := ecdsafile.NewJWK(key)
+	if err != nil {
+		return nil, err
+	}
+	return singleKey{jwk: jwk}, nil
+}
+
+// NewJWTKeysFromURL returns JWTKeys of the JWKS document at jwksURL, such as
+// the jwks_uri of an authorization server, which is fetched when it's first
+// needed and every RefreshInterval, and again for a kid it doesn't have. The
+// requests waiting for it share a single fetch.
+func NewJWTKeysFromURL(jwksURL string, opts JWKSOptions) JWTKeys {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = defaultJWKSRefreshInterval
+	}
+	if opts.MinRefreshInterval == 0 {
+		opts.MinRefreshInterval = defaultJWKSMinRefreshInterval
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = defaultJWKSRequestTimeout
+	}
+	return &remoteJWKS{url: jwksURL, opts: opts, now: time.Now}
+}
+
+// JWKSOptions configures NewJWTKeysFromURL.
+type JWKSOptions struct {
+	// HTTPClient fetches the JWKS. Defaults to http.DefaultClient.
+	HTTPClient HttpRequestDoer
+	// RefreshInterval is how often the JWKS is fetched again. Defaults to
+	// an hour.
+	RefreshInterval time.Duration
+	// MinRefreshInterval is how soon after it was last fetched, or attempted
+	// to be, that the JWKS is fetched again, for a kid it doesn't have, as
+	// when the keys are rotated, or after failing to. Defaults to a minute.
+	MinRefreshInterval time.Duration
+	// RequestTimeout bounds each fetch of the JWKS, which is shared by the
+	// requests waiting for it, so isn't canceled with any of them. Defaults
+	// to 30 seconds.
+	RequestTimeout time.Duration
+}
+
+// JWTOptions configures VerifyJWT and NewJWTMiddleware.
+type JWTOptions struct {
+	// Keys verify the signatures of the JWTs.
+	Keys JWTKeys
+	// Algorithms are the accepted alg of the JWTs. Defaults to the ECDSA,
+	// EdDSA and RSA ones; HS256, HS384 and HS512, for keys of kty "oct",
+	// must be listed to be accepted.
+	Algorithms []string
+	// Issuer is the iss the JWTs must have, when it's set.
+	Issuer string
+	// Audience is the aud the JWTs must have, or have among theirs, when
+	// it's set.
+	Audience string
+	// Leeway is the clock skew allowed when checking exp and nbf.
+	Leeway time.Duration
+	// ScopeClaim is the claim with the scopes of a JWT, either a space
+	// separated string or an array of strings. Defaults to scope.
+	ScopeClaim string
+	// ScopesContextKey is the context key of the scopes the operation
+	// requires of the security scheme, such as the generated
+	// BearerAuthScopes with the compatibility option
+	// enable-auth-scopes-on-context. A request without scopes in its context
+	// is authenticated without requiring any, unless
+	// SkipOperationsWithoutScheme is set.
+	ScopesContextKey any
+	// SkipOperationsWithoutScheme passes on the requests without scopes under
+	// ScopesContextKey in their context, for operations which don't require
+	// the scheme, without verifying a token. It's only safe where the
+	// middleware runs after the generated server has put the scopes in the
+	// context, rather than for the whole router, where no request has them.
+	SkipOperationsWithoutScheme bool
+	// Realm is the realm of the WWW-Authenticate challenges, when it's set.
+	Realm string
+}
+
+// JWTClaims are the claims of a JWT.
+type JWTClaims map[string]any
+
+// Subject returns the sub claim.
+func (c JWTClaims) Subject() string {
+	sub, _ := c["sub"].(string)
+	return sub
+}
+
+// Scopes returns the scopes of the claim, either a space separated string or
+// an array of strings.
+func (c JWTClaims) Scopes(claim string) []string {
+	switch v := c[claim].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		scopes := make([]string, 0, len(v))
+		for _, s := range v {
+			if scope, ok := s.(string); ok {
+				scopes = append(scopes, scope)
+			}
+		}
+		return scopes
+	default:
+		return nil
+	}
+}
+
+type jwtClaimsKey struct{}
+
+// JWTClaimsFromContext returns the claims of the JWT the middleware of
+// NewJWTMiddleware verified, when it did.
+func JWTClaimsFromContext(ctx context.Context) (JWTClaims, bool) {
+	claims, ok := ctx.Value(jwtClaimsKey{}).(JWTClaims)
+	return claims, ok
+}
+
+// NewJWTMiddleware returns server middleware, which authenticates requests
+// with AuthenticateJWT, for the scopes of the operation in the context, under
+// ScopesContextKey. It puts the claims in the context of the requests it
+// accepts, for JWTClaimsFromContext, and responds to the others with
+// WriteJWTError. Requests without scopes in their context are authenticated
+// too, unless SkipOperationsWithoutScheme is set.
+func NewJWTMiddleware(opts JWTOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var scopes []string
+			if opts.ScopesContextKey != nil {
+				var ok bool
+				if scopes, ok = r.Context().Value(opts.ScopesContextKey).([]string); !ok && opts.SkipOperationsWithoutScheme {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			claims, err := AuthenticateJWT(r, scopes, opts)
+			if err != nil {
+				WriteJWTError(w, opts.Realm, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), jwtClaimsKey{}, claims)))
+		})
+	}
+}
+
+// AuthenticateJWT verifies the bearer token of r, as VerifyJWT does, and that
+// it has the scopes in its ScopeClaim, returning its claims. It suits the
+// AuthenticationFunc of the validation middleware, with the Scopes of its
+// AuthenticationInput.
+func AuthenticateJWT(r *http.Request, scopes []string, opts JWTOptions) (JWTClaims, error) {
+	token, err := BearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := VerifyJWT(r.Context(), token, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckJWTScopes(claims, scopes, opts.ScopeClaim); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// WriteJWTError responds to a request AuthenticateJWT failed to authenticate
+// with err, with the WWW-Authenticate challenge of RFC 6750 section 3: 401
+// Unauthorized without a valid token, 403 Forbidden without the scopes, and
+// 400 Bad Request for a malformed request.
+func WriteJWTError(w http.ResponseWriter, realm string, err error) {
+	status := http.StatusUnauthorized
+	switch {
+	case errors.Is(err, ErrJWTInsufficientScope):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrJWTInvalidRequest):
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("WWW-Authenticate", jwtChallenge(realm, err))
+	http.Error(w, err.Error(), status)
+}
+
+// BearerToken returns the bearer token of the Authorization header of r.
+func BearerToken(r *http.Request) (string, error) {
+	var tokens []string
+	for _, value := range r.Header.Values("Authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			tokens = append(tokens, strings.TrimSpace(token))
+		}
+	}
+	switch len(tokens) {
+	case 0:
+		return "", ErrJWTMissing
+	case 1:
+		return tokens[0], nil
+	default:
+		return "", fmt.Errorf("%w: more than one Authorization: Bearer header", ErrJWTInvalidRequest)
+	}
+}
+
+// jwtChallenge returns the WWW-Authenticate challenge for err, with the
+// scopes of a JWTScopeError.
+func jwtChallenge(realm string, err error) string {
+	params := []string{}
+	if realm != "" {
+		params = append(params, "realm="+quoteJWTChallengeParam(realm))
+	}
+	code := ""
+	switch {
+	case errors.Is(err, ErrJWTMissing):
+		// A request without credentials gets no error code.
+	case errors.Is(err, ErrJWTInsufficientScope):
+		code = "insufficient_scope"
+	case errors.Is(err, ErrJWTInvalidRequest):
+		code = "invalid_request"
+	default:
+		code = "invalid_token"
+	}
+	if code != "" {
+		params = append(params, `error="`+code+`"`, "error_description="+quoteJWTChallengeParam(err.Error()))
+	}
+	var scopeErr *JWTScopeError
+	if errors.As(err, &scopeErr) {
+		params = append(params, "scope="+quoteJWTChallengeParam(strings.Join(scopeErr.Scopes, " ")))
+	}
+	if len(params) == 0 {
+		return "Bearer"
+	}
+	return "Bearer " + strings.Join(params, ", ")
+}
+
+// quoteJWTChallengeParam quotes a parameter of a challenge, replacing the
+// characters RFC 6750 doesn't allow in them.
+func quoteJWTChallengeParam(s string) string {
+	return `"` + strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '\''
+		}
+		return r
+	}, s) + `"`
+}
+
+// JWTScopeError is the error of CheckJWTScopes, which is
+// ErrJWTInsufficientScope.
+type JWTScopeError struct {
+	// Scopes are the scopes which are required.
+	Scopes []string
+	// Missing are those of them the JWT doesn't have.
+	Missing []string
+}
+
+// Error implements the error interface.
+func (e *JWTScopeError) Error() string {
+	return fmt.Sprintf("%s: %s is required", ErrJWTInsufficientScope, strings.Join(e.Missing, " "))
+}
+
+// Is makes a JWTScopeError ErrJWTInsufficientScope.
+func (e *JWTScopeError) Is(target error) bool {
+	return target == ErrJWTInsufficientScope
+}
+
+// CheckJWTScopes checks that the claims have each of the scopes in their
+// scopeClaim, which defaults to scope, returning a *JWTScopeError when they
+// don't.
+func CheckJWTScopes(claims JWTClaims, scopes []string, scopeClaim string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+	if scopeClaim == "" {
+		scopeClaim = defaultJWTScopeClaim
+	}
+	have := claims.Scopes(scopeClaim)
+	var missing []string
+	for _, scope := range scopes {
+		if !slices.Contains(have, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return &JWTScopeError{Scopes: scopes, Missing: missing}
+	}
+	return nil
+}
+
+// VerifyJWT verifies the signature of the JWT token, in the JWS compact
+// serialization, with the key of its kid, and its exp, nbf, iss and aud
+// claims, returning its claims.
+func VerifyJWT(ctx context.Context, token string, opts JWTOptions) (JWTClaims, error) {
+	claims, err := verifyJWT(ctx, token, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWTInvalid, err)
+	}
+	return claims, nil
+}
+
+func verifyJWT(ctx context.Context, token string, opts JWTOptions) (JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token isn't a JWS compact serialization")
+	}
+	var header struct {
+		Alg  string   `json:"alg"`
+		Kid  string   `json:"kid"`
+		Crit []string `json:"crit"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	if len(header.Crit) > 0 {
+		return nil, fmt.Errorf("unsupported crit header parameters %s", strings.Join(header.Crit, ", "))
+	}
+	algorithms := opts.Algorithms
+	if algorithms == nil {
+		algorithms = defaultJWTAlgorithms
+	}
+	if !slices.Contains(algorithms, header.Alg) {
+		return nil, fmt.Errorf("alg %q isn't accepted", header.Alg)
+	}
+	if opts.Keys == nil {
+		return nil, errors.New("no keys to verify the token with")
+	}
+	jwk, err := opts.Keys.JWTKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if jwk.Alg != "" && jwk.Alg != header.Alg {
+		return nil, fmt.Errorf("the key of kid %q is for alg %q", header.Kid, jwk.Alg)
+	}
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, fmt.Errorf("the key of kid %q isn't for signatures", header.Kid)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	if err := verifyJWTSignature(header.Alg, jwk, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims JWTClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	now := time.Now()
+	if exp, ok, err := jwtNumericDate(claims, "exp"); err != nil {
+		return nil, err
+	} else if ok && !now.Before(exp.Add(opts.Leeway)) {
+		return nil, errors.New("the token is expired")
+	}
+	if nbf, ok, err := jwtNumericDate(claims, "nbf"); err != nil {
+		return nil, err
+	} else if ok && now.Before(nbf.Add(-opts.Leeway)) {
+		return nil, errors.New("the token isn't valid yet")
+	}
+	if opts.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != opts.Issuer {
+			return nil, fmt.Errorf("iss %q isn't accepted", iss)
+		}
+	}
+	if opts.Audience != "" && !jwtHasAudience(claims, opts.Audience) {
+		return nil, fmt.Errorf("aud doesn't include %q", opts.Audience)
+	}
+	return claims, nil
+}
+
+// jwtAlgorithmHashes are the hashes of the supported JWS algorithms, of RFC
+// 7518 and RFC 8037; EdDSA has none.
+var jwtAlgorithmHashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"EdDSA": 0,
+}
+
+// verifyJWTSignature verifies the JWS signature of the alg over signed, with
+// the key of jwk, which must be of the type of the alg.
+func verifyJWTSignature(alg string, jwk *ecdsafile.JWK, signed, signature []byte) error {
+	hash, ok := jwtAlgorithmHashes[alg]
+	if !ok {
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	family := alg[:2]
+	var key any
+	var err error
+	if family == "HS" {
+		key, err = jwk.Key()
+	} else {
+		key, err = jwk.PublicKey()
+	}
+	if err != nil {
+		return err
+	}
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write(signed)
+		digest = h.Sum(nil)
+	}
+
+	verified := false
+	switch k := key.(type) {
+	case []byte:
+		if family != "HS" {
+			break
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write(signed)
+		verified = hmac.Equal(mac.Sum(nil), signature)
+	case *ecdsa.PublicKey:
+		if family != "ES" {
+			break
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if curveAlg := map[int]string{32: "ES256", 48: "ES384", 66: "ES512"}[size]; alg != curveAlg {
+			return fmt.Errorf("the key of curve %s isn't for alg %q", k.Curve.Params().Name, alg)
+		}
+		if len(signature) == 2*size {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			verified = ecdsa.Verify(k, digest, r, s)
+		}
+	case ed25519.PublicKey:
+		if family != "Ed" {
+			break
+		}
+		verified = ed25519.Verify(k, signed, signature)
+	case *rsa.PublicKey:
+		switch family {
+		case "RS":
+			verified = rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil
+		case "PS":
+			verified = rsa.VerifyPSS(k, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		default:
+			return fmt.Errorf("the key of kty %q isn't for alg %q", jwk.Kty, alg)
+		}
+	}
+	if verified {
+		return nil
+	}
+	if jwtKeyFamilies[jwk.Kty] != family && !(jwk.Kty == "RSA" && family == "PS") {
+		return fmt.Errorf("the key of kty %q isn't for alg %q", jwk.Kty, alg)
+	}
+	return fmt.Errorf("%s signature mismatch", alg)
+}
+
+// jwtKeyFamilies are the alg prefixes of the keys of the kty.
+var jwtKeyFamilies = map[string]string{"oct": "HS", "RSA": "RS", "EC": "ES", "OKP": "Ed"}
+
+func decodeJWTPart(part string, v any) error {
+	buf, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// The range of the NumericDates accepted, the years 1 to 9999. Times beyond
+// it are rejected, rather than overflowing.
+var (
+	jwtMinNumericDate = float64(time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())
+	jwtMaxNumericDate = float64(time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())
+)
+
+// jwtNumericDate returns the time of the NumericDate claim, and whether
+// there is one.
+func jwtNumericDate(claims JWTClaims, claim string) (time.Time, bool, error) {
+	v, ok := claims[claim]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	seconds, ok := v.(float64)
+	if !ok || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, false, fmt.Errorf("%s isn't a NumericDate", claim)
+	}
+	if seconds < jwtMinNumericDate || seconds >= jwtMaxNumericDate {
+		return time.Time{}, false, fmt.Errorf("%s is out of range", claim)
+	}
+	sec, frac := math.Modf(seconds)
+	return time.Unix(int64(sec), int64(frac*1e9)), true, nil
+}
+
+func jwtHasAudience(claims JWTClaims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []any:
+		return slices.Contains(aud, any(audience))
+	default:
+		return false
+	}
+}
+
+// jwksKeys are the JWTKeys of NewJWTKeys.
+type jwksKeys struct {
+	jwks *ecdsafile.JWKS
+}
+
+func (k jwksKeys) JWTKey(_ context.Context, kid string) (*ecdsafile.JWK, error) {
+	return k.jwks.Key(kid)
+}
+
+// singleKey are the JWTKeys of NewJWTKeysFromPublicKey.
+type singleKey struct {
+	jwk *ecdsafile.JWK
+}
+
+func (k singleKey) JWTKey(context.Context, string) (*ecdsafile.JWK, error) {
+	return k.jwk, nil
+}
+
+// remoteJWKS are the JWTKeys of NewJWTKeysFromURL.
+type remoteJWKS struct {
+	url  string
+	opts JWKSOptions
+	now  func() time.Time
+
+	mu   sync.Mutex
+	jwks *ecdsafile.JWKS
+	// fetched is when jwks was fetched, and attempted when it last was
+	// attempted to be, failing with err, if it did.
+	fetched   time.Time
+	attempted time.Time
+	err       error
+	// inflight is the JWKS request in progress, if any.
+	inflight *jwksFetch
+}
+
+// jwksFetch is a JWKS request, whose jwks and err are set when done is
+// closed.
+type jwksFetch struct {
+	done chan struct{}
+	jwks *ecdsafile.JWKS
+	err  error
+}
+
+func (k *remoteJWKS) JWTKey(ctx context.Context, kid string) (*ecdsafile.JWK, error) {
+	jwks, err := k.keys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	jwk, err := jwks.Key(kid)
+	if errors.Is(err, ecdsafile.ErrJWKNotFound) {
+		// The keys may have been rotated since.
+		if jwks, err = k.keys(ctx, true); err != nil {
+			return nil, err
+		}
+		return jwks.Key(kid)
+	}
+	return jwk, err
+}
+
+// keys returns the JWKS, fetching it when it hasn't been yet, or is due to
+// be refreshed, or refresh is set, unless it was attempted to be within
+// MinRefreshInterval. A JWKS which can't be fetched again is used until it
+// can be.
+func (k *remoteJWKS) keys(ctx context.Context, refresh bool) (*ecdsafile.JWKS, error) {
+	k.mu.Lock()
+	now := k.now()
+	due := k.jwks == nil || refresh || now.Sub(k.fetched) >= k.opts.RefreshInterval
+	f := k.inflight
+	if f == nil && due && now.Sub(k.attempted) >= k.opts.MinRefreshInterval {
+		f = k.fetchLocked(ctx)
+	}
+	jwks, err := k.jwks, k.err
+	k.mu.Unlock()
+
+	if f == nil || (!due && jwks != nil) {
+		if jwks == nil {
+			return nil, err
+		}
+		return jwks, nil
+	}
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.err != nil {
+		if jwks == nil {
+			return nil, f.err
+		}
+		return jwks, nil
+	}
+	return f.jwks, nil
+}
+
+// fetchLocked starts a JWKS request. It's called with k.mu held, and no
+// request in progress.
+func (k *remoteJWKS) fetchLocked(ctx context.Context) *jwksFetch {
+	f := &jwksFetch{done: make(chan struct{})}
+	k.inflight = f
+	attempted := k.now()
+	k.attempted = attempted
+	// The request is shared, so it isn't canceled with the caller's ctx, but
+	// only times out.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), k.opts.RequestTimeout)
+	go func() {
+		defer cancel()
+		jwks, err := k.fetch(ctx)
+		k.mu.Lock()
+		if err == nil {
+			k.jwks = jwks
+			k.fetched = attempted
+		}
+		k.err = err
+		f.jwks, f.err = jwks, err
+		k.inflight = nil
+		k.mu.Unlock()
+		close(f.done)
+	}()
+	return f
+}
+
+// fetch fetches the JWKS.
+func (k *remoteJWKS) fetch(ctx context.Context) (*ecdsafile.JWKS, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the JWKS request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := k.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the JWKS: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching the JWKS: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the JWKS: %w", err)
+	}
+	return ecdsafile.LoadJWKS(body)
+}
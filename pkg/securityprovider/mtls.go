@@ -0,0 +1,146 @@
+package securityprovider
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// These authenticate the clients of `mutualTLS` security schemes, by their
+// TLS client certificates.
+
+const (
+	// ErrMutualTLSMissing indicates a request without a client certificate,
+	// or not over TLS.
+	ErrMutualTLSMissing = SecurityProviderError("no tls client certificate")
+	// ErrMutualTLSInvalid indicates a request whose client certificate
+	// doesn't verify.
+	ErrMutualTLSInvalid = SecurityProviderError("invalid tls client certificate")
+)
+
+// MutualTLSOptions configures AuthenticateMutualTLS and
+// NewMutualTLSMiddleware.
+type MutualTLSOptions struct {
+	// ClientCAs verify the client certificates, for a server whose
+	// tls.Config only requests them, with tls.RequestClientCert, so that
+	// only the operations requiring the scheme require a certificate. When
+	// it's nil, the certificate must have been verified by the server, with
+	// tls.VerifyClientCertIfGiven or tls.RequireAndVerifyClientCert, and its
+	// ClientCAs.
+	ClientCAs *x509.CertPool
+	// ScopesContextKey is the context key of the scopes the operation
+	// requires of the security scheme, such as the generated MtlsScopes with
+	// the compatibility option enable-auth-scopes-on-context. A request
+	// without scopes in its context requires a certificate too, unless
+	// SkipOperationsWithoutScheme is set.
+	ScopesContextKey any
+	// SkipOperationsWithoutScheme passes on the requests without scopes under
+	// ScopesContextKey in their context, for operations which don't require
+	// the scheme, without a certificate. It's only safe where the middleware
+	// runs after the generated server has put the scopes in the context,
+	// rather than for the whole router, where no request has them.
+	SkipOperationsWithoutScheme bool
+}
+
+// MutualTLSPeer is the client of a request, authenticated by its verified
+// TLS client certificate.
+type MutualTLSPeer struct {
+	// Certificate is the client certificate.
+	Certificate *x509.Certificate
+	// Chain is the verified chain of the Certificate, to a root of the
+	// ClientCAs.
+	Chain []*x509.Certificate
+}
+
+// Subject returns the subject of the client certificate.
+func (p *MutualTLSPeer) Subject() pkix.Name {
+	return p.Certificate.Subject
+}
+
+// DNSNames returns the DNS name SANs of the client certificate.
+func (p *MutualTLSPeer) DNSNames() []string {
+	return p.Certificate.DNSNames
+}
+
+// EmailAddresses returns the email address SANs of the client certificate.
+func (p *MutualTLSPeer) EmailAddresses() []string {
+	return p.Certificate.EmailAddresses
+}
+
+// IPAddresses returns the IP address SANs of the client certificate.
+func (p *MutualTLSPeer) IPAddresses() []net.IP {
+	return p.Certificate.IPAddresses
+}
+
+// URIs returns the URI SANs of the client certificate, such as SPIFFE IDs.
+func (p *MutualTLSPeer) URIs() []*url.URL {
+	return p.Certificate.URIs
+}
+
+type mutualTLSPeerKey struct{}
+
+// MutualTLSPeerFromContext returns the client the middleware of
+// NewMutualTLSMiddleware authenticated, when it did.
+func MutualTLSPeerFromContext(ctx context.Context) (*MutualTLSPeer, bool) {
+	peer, ok := ctx.Value(mutualTLSPeerKey{}).(*MutualTLSPeer)
+	return peer, ok
+}
+
+// NewMutualTLSMiddleware returns server middleware, which authenticates
+// requests with AuthenticateMutualTLS. It puts the client in the context of
+// the requests it accepts, for MutualTLSPeerFromContext, and responds 401
+// Unauthorized to the others. With SkipOperationsWithoutScheme, only the
+// requests for operations with scopes in the context, under
+// ScopesContextKey, are authenticated.
+func NewMutualTLSMiddleware(opts MutualTLSOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.ScopesContextKey != nil && opts.SkipOperationsWithoutScheme {
+				if _, ok := r.Context().Value(opts.ScopesContextKey).([]string); !ok {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			peer, err := AuthenticateMutualTLS(r, opts)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), mutualTLSPeerKey{}, peer)))
+		})
+	}
+}
+
+// AuthenticateMutualTLS returns the client of r, authenticated by its TLS
+// client certificate, which the server verified, or which verifies with
+// ClientCAs. It suits the AuthenticationFunc of the validation middleware.
+func AuthenticateMutualTLS(r *http.Request, opts MutualTLSOptions) (*MutualTLSPeer, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, ErrMutualTLSMissing
+	}
+	certificate := r.TLS.PeerCertificates[0]
+	if opts.ClientCAs == nil {
+		if len(r.TLS.VerifiedChains) == 0 {
+			return nil, fmt.Errorf("%w: the server didn't verify it", ErrMutualTLSInvalid)
+		}
+		return &MutualTLSPeer{Certificate: certificate, Chain: r.TLS.VerifiedChains[0]}, nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := certificate.Verify(x509.VerifyOptions{
+		Roots:         opts.ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMutualTLSInvalid, err)
+	}
+	return &MutualTLSPeer{Certificate: certificate, Chain: chains[0]}, nil
+}
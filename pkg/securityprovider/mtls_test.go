@@ -0,0 +1,180 @@
+package securityprovider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue issues a certificate of the template, for the usage.
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestMutualTLSMiddleware(t *testing.T) {
+	ca := newTestCA(t)
+	spiffeID, err := url.Parse("spiffe://example.com/pets")
+	require.NoError(t, err)
+	clientCert := ca.issue(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "pets-client", Organization: []string{"Example"}},
+		DNSNames: []string{"client.example.com"},
+		URIs:     []*url.URL{spiffeID},
+	}, x509.ExtKeyUsageClientAuth)
+	otherCert := newTestCA(t).issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}}, x509.ExtKeyUsageClientAuth)
+	serverOnlyCert := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "server"}}, x509.ExtKeyUsageServerAuth)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer, ok := MutualTLSPeerFromContext(r.Context())
+		require.True(t, ok)
+		_, _ = io.WriteString(w, peer.Subject().CommonName+" "+peer.DNSNames()[0]+" "+peer.URIs()[0].String())
+	})
+
+	for name, tc := range map[string]struct {
+		clientAuth tls.ClientAuthType
+		opts       MutualTLSOptions
+	}{
+		"verified by the middleware": {
+			clientAuth: tls.RequestClientCert,
+			opts:       MutualTLSOptions{ClientCAs: ca.pool},
+		},
+		"verified by the server": {
+			clientAuth: tls.VerifyClientCertIfGiven,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(NewMutualTLSMiddleware(tc.opts)(handler))
+			server.TLS = &tls.Config{ClientAuth: tc.clientAuth, ClientCAs: ca.pool}
+			server.StartTLS()
+			defer server.Close()
+
+			get := func(certs ...tls.Certificate) (int, string, error) {
+				transport := server.Client().Transport.(*http.Transport).Clone()
+				transport.TLSClientConfig.Certificates = certs
+				resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+				if err != nil {
+					return 0, "", err
+				}
+				defer func() { _ = resp.Body.Close() }()
+				body, _ := io.ReadAll(resp.Body)
+				return resp.StatusCode, string(body), nil
+			}
+
+			status, body, err := get(clientCert)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, "pets-client client.example.com spiffe://example.com/pets", body)
+
+			status, body, err = get()
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Contains(t, body, "no tls client certificate")
+
+			status, body, err = get(otherCert)
+			if tc.clientAuth == tls.VerifyClientCertIfGiven {
+				// The server rejects it in the handshake.
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, status)
+				assert.Contains(t, body, "invalid tls client certificate")
+			}
+
+			status, body, err = get(serverOnlyCert)
+			if tc.clientAuth == tls.VerifyClientCertIfGiven {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusUnauthorized, status, "a certificate must be for client auth")
+				assert.Contains(t, body, "invalid tls client certificate")
+			}
+		})
+	}
+}
+
+func TestAuthenticateMutualTLS(t *testing.T) {
+	_, err := AuthenticateMutualTLS(httptest.NewRequest(http.MethodGet, "http://example.com/pets", nil), MutualTLSOptions{})
+	assert.ErrorIs(t, err, ErrMutualTLSMissing)
+
+	// Without ClientCAs, an unverified certificate isn't accepted.
+	ca := newTestCA(t)
+	cert := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}, IPAddresses: []net.IP{net.IPv4(10, 0, 0, 1)}}, x509.ExtKeyUsageClientAuth)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/pets", nil)
+	req.TLS.PeerCertificates = []*x509.Certificate{leaf}
+	_, err = AuthenticateMutualTLS(req, MutualTLSOptions{})
+	assert.ErrorIs(t, err, ErrMutualTLSInvalid)
+
+	peer, err := AuthenticateMutualTLS(req, MutualTLSOptions{ClientCAs: ca.pool})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", peer.IPAddresses()[0].String())
+	assert.Equal(t, []*x509.Certificate{leaf, ca.cert}, peer.Chain)
+
+	// Mounted for the whole router, the middleware runs before the scopes of
+	// any operation are in the context, and requires a certificate.
+	type scopesKey struct{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	rec := httptest.NewRecorder()
+	NewMutualTLSMiddleware(MutualTLSOptions{ScopesContextKey: scopesKey{}})(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Unless operations without scopes in the context are skipped, as they
+	// don't require the scheme.
+	rec = httptest.NewRecorder()
+	NewMutualTLSMiddleware(MutualTLSOptions{ScopesContextKey: scopesKey{}, SkipOperationsWithoutScheme: true})(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,351 @@
+package securityprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ErrSecurityProviderOAuth2NoTokenURL indicates OAuth2Options without a
+	// TokenURL.
+	ErrSecurityProviderOAuth2NoTokenURL = SecurityProviderError("no token URL specified for oauth2")
+	// ErrSecurityProviderOAuth2NoRefreshToken indicates a refresh-token grant
+	// without a refresh token.
+	ErrSecurityProviderOAuth2NoRefreshToken = SecurityProviderError("no refresh token specified for oauth2")
+)
+
+// defaultOAuth2RefreshBefore is the RefreshBefore of OAuth2Options which
+// have none.
+const defaultOAuth2RefreshBefore = 30 * time.Second
+
+// defaultOAuth2RequestTimeout is the RequestTimeout of OAuth2Options which
+// have none.
+const defaultOAuth2RequestTimeout = 30 * time.Second
+
+// HttpRequestDoer performs HTTP requests, as the HttpRequestDoer of a
+// generated client, or an *http.Client, does.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// OAuth2Options configures the OAuth2 security providers.
+type OAuth2Options struct {
+	// TokenURL is the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID and ClientSecret authenticate the client, with HTTP Basic
+	// authentication, or in the request body with ClientSecretInBody. A
+	// public client, without a ClientSecret, sends its ClientID in the body.
+	ClientID     string
+	ClientSecret string
+	// ClientSecretInBody sends the client credentials in the request body,
+	// for authorization servers which don't support HTTP Basic
+	// authentication.
+	ClientSecretInBody bool
+	// Scopes are requested with the token, when there are any.
+	Scopes []string
+	// EndpointParams are sent to the token endpoint with each token request,
+	// such as an audience.
+	EndpointParams url.Values
+	// RefreshBefore is how long before a token expires that it's refreshed,
+	// while it's still used. Defaults to 30 seconds.
+	RefreshBefore time.Duration
+	// HTTPClient makes the token requests. Defaults to http.DefaultClient.
+	HTTPClient HttpRequestDoer
+	// RequestTimeout bounds each token request, which is shared by the
+	// requests waiting for the token, so isn't canceled with any of them.
+	// Defaults to 30 seconds.
+	RequestTimeout time.Duration
+}
+
+// OAuth2Error is an error response of a token endpoint, as RFC 6749 section
+// 5.2 defines it.
+type OAuth2Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the error code, such as invalid_client, when the response has
+	// one.
+	Code string
+	// Description is the error_description of the response.
+	Description string
+}
+
+// Error implements the error interface.
+func (e *OAuth2Error) Error() string {
+	msg := fmt.Sprintf("oauth2: token request failed with status %d", e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// NewSecurityProviderOAuth2ClientCredentials provides a SecurityProvider,
+// which attaches access tokens of the OAuth2 client credentials grant to
+// api-calls.
+func NewSecurityProviderOAuth2ClientCredentials(opts OAuth2Options) (*SecurityProviderOAuth2, error) {
+	if opts.TokenURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoTokenURL
+	}
+	return newSecurityProviderOAuth2(opts, "client_credentials", ""), nil
+}
+
+// NewSecurityProviderOAuth2RefreshToken provides a SecurityProvider, which
+// attaches access tokens of the OAuth2 refresh token grant to api-calls. A
+// refresh token the authorization server rotates is used from then on.
+func NewSecurityProviderOAuth2RefreshToken(refreshToken string, opts OAuth2Options) (*SecurityProviderOAuth2, error) {
+	if opts.TokenURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoTokenURL
+	}
+	if refreshToken == "" {
+		return nil, ErrSecurityProviderOAuth2NoRefreshToken
+	}
+	return newSecurityProviderOAuth2(opts, "refresh_token", refreshToken), nil
+}
+
+func newSecurityProviderOAuth2(opts OAuth2Options, grantType, refreshToken string) *SecurityProviderOAuth2 {
+	if opts.RefreshBefore == 0 {
+		opts.RefreshBefore = defaultOAuth2RefreshBefore
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = defaultOAuth2RequestTimeout
+	}
+	return &SecurityProviderOAuth2{
+		opts:         opts,
+		grantType:    grantType,
+		refreshToken: refreshToken,
+		now:          time.Now,
+	}
+}
+
+// SecurityProviderOAuth2 sends an OAuth2 access token as part of an
+// Authorization: Bearer header along with a request. The token is cached,
+// and refreshed RefreshBefore it expires; concurrent requests share a single
+// token request.
+type SecurityProviderOAuth2 struct {
+	opts      OAuth2Options
+	grantType string
+	now       func() time.Time
+
+	mu           sync.Mutex
+	refreshToken string
+	token        *oauth2Token
+	// inflight is the token request in progress, if any.
+	inflight *oauth2Fetch
+}
+
+// oauth2Token is an access token, and when it expires, if it does.
+type oauth2Token struct {
+	accessToken string
+	expiry      time.Time
+}
+
+// oauth2Fetch is a token request, whose token and err are set when done is
+// closed.
+type oauth2Fetch struct {
+	done  chan struct{}
+	token *oauth2Token
+	err   error
+}
+
+// Intercept will attach an Authorization header to the request, with an
+// access token, which is requested when there's no cached one.
+func (s *SecurityProviderOAuth2) Intercept(ctx context.Context, req *http.Request) error {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
+}
+
+// Token returns the cached access token, when it hasn't expired, or else
+// requests one. A token due to be refreshed is refreshed in the background,
+// while it's returned.
+func (s *SecurityProviderOAuth2) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	now := s.now()
+	if t := s.token; t != nil && (t.expiry.IsZero() || now.Before(t.expiry)) {
+		if !t.expiry.IsZero() && !now.Before(t.expiry.Add(-s.opts.RefreshBefore)) {
+			s.fetchLocked(ctx)
+		}
+		s.mu.Unlock()
+		return t.accessToken, nil
+	}
+	f := s.fetchLocked(ctx)
+	s.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return "", f.err
+		}
+		return f.token.accessToken, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Doer returns an HttpRequestDoer, for a generated client's WithHTTPClient,
+// which does requests with doer, and retries a request once, with a new
+// access token, when it's rejected with a 401 Unauthorized. A request whose
+// body can't be read again, without GetBody, isn't retried.
+func (s *SecurityProviderOAuth2) Doer(doer HttpRequestDoer) HttpRequestDoer {
+	return oauth2Doer{provider: s, doer: doer}
+}
+
+// fetchLocked returns the token request in progress, starting one when there
+// isn't one. It's called with s.mu held.
+func (s *SecurityProviderOAuth2) fetchLocked(ctx context.Context) *oauth2Fetch {
+	if s.inflight != nil {
+		return s.inflight
+	}
+	f := &oauth2Fetch{done: make(chan struct{})}
+	s.inflight = f
+	refreshToken := s.refreshToken
+	// The request is shared, so it isn't canceled with the caller's ctx, but
+	// only times out.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.opts.RequestTimeout)
+	go func() {
+		defer cancel()
+		token, rotated, err := s.requestToken(ctx, refreshToken)
+		s.mu.Lock()
+		if err == nil {
+			s.token = token
+			if rotated != "" {
+				s.refreshToken = rotated
+			}
+		}
+		f.token, f.err = token, err
+		s.inflight = nil
+		s.mu.Unlock()
+		close(f.done)
+	}()
+	return f
+}
+
+// invalidate drops the cached token, when it's accessToken, which the
+// authorization server no longer accepts.
+func (s *SecurityProviderOAuth2) invalidate(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.token.accessToken == accessToken {
+		s.token = nil
+	}
+}
+
+// requestToken requests an access token from the token endpoint, returning
+// it, and the new refresh token, if it was rotated.
+func (s *SecurityProviderOAuth2) requestToken(ctx context.Context, refreshToken string) (*oauth2Token, string, error) {
+	params := url.Values{}
+	for k, v := range s.opts.EndpointParams {
+		params[k] = v
+	}
+	params.Set("grant_type", s.grantType)
+	if refreshToken != "" {
+		params.Set("refresh_token", refreshToken)
+	}
+	if len(s.opts.Scopes) > 0 {
+		params.Set("scope", strings.Join(s.opts.Scopes, " "))
+	}
+	basicAuth := s.opts.ClientSecret != "" && !s.opts.ClientSecretInBody
+	if !basicAuth && s.opts.ClientID != "" {
+		params.Set("client_id", s.opts.ClientID)
+		if s.opts.ClientSecret != "" {
+			params.Set("client_secret", s.opts.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opts.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, "", fmt.Errorf("oauth2: creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basicAuth {
+		// RFC 6749 section 2.3.1 form-encodes them first.
+		req.SetBasicAuth(url.QueryEscape(s.opts.ClientID), url.QueryEscape(s.opts.ClientSecret))
+	}
+
+	now := s.now()
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("oauth2: token request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, "", fmt.Errorf("oauth2: reading token response: %w", err)
+	}
+
+	var tr struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		RefreshToken     string `json:"refresh_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	jsonErr := json.Unmarshal(body, &tr)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", &OAuth2Error{StatusCode: resp.StatusCode, Code: tr.Error, Description: tr.ErrorDescription}
+	}
+	if jsonErr != nil {
+		return nil, "", fmt.Errorf("oauth2: decoding token response: %w", jsonErr)
+	}
+	if tr.AccessToken == "" {
+		return nil, "", errors.New("oauth2: token response has no access_token")
+	}
+	token := &oauth2Token{accessToken: tr.AccessToken}
+	if tr.ExpiresIn > 0 {
+		token.expiry = now.Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	return token, tr.RefreshToken, nil
+}
+
+// oauth2Doer is the HttpRequestDoer of SecurityProviderOAuth2.Doer.
+type oauth2Doer struct {
+	provider *SecurityProviderOAuth2
+	doer     HttpRequestDoer
+}
+
+// Do does req, retrying it once with a new access token when its access
+// token is rejected.
+func (d oauth2Doer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.doer.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	used, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	d.provider.invalidate(used)
+	token, err := d.provider.Token(req.Context())
+	if err != nil || token == used {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retry.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return d.doer.Do(retry)
+}
@@ -0,0 +1,264 @@
+package securityprovider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer is an OAuth2 token endpoint, issuing tokens "token-1",
+// "token-2", and so on, with expiresIn.
+type tokenServer struct {
+	*httptest.Server
+	requests  atomic.Int32
+	expiresIn int
+	// release, when it's not nil, is waited for before responding.
+	release chan struct{}
+	// check checks each token request.
+	check func(r *http.Request)
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	ts := &tokenServer{expiresIn: 3600}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if ts.check != nil {
+			ts.check(r)
+		}
+		if ts.release != nil {
+			<-ts.release
+		}
+		n := ts.requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d,"refresh_token":"refresh-%d"}`, n, ts.expiresIn, n+1)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestSecurityProviderOAuth2ClientCredentials(t *testing.T) {
+	ts := newTokenServer(t)
+	ts.check = func(r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "my+client", user, "the credentials are form-encoded")
+		assert.Equal(t, "s3cr3t%26", pass)
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "read write", r.PostForm.Get("scope"))
+		assert.Equal(t, "api", r.PostForm.Get("audience"))
+		assert.Empty(t, r.PostForm.Get("client_secret"))
+	}
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{
+		TokenURL:       ts.URL,
+		ClientID:       "my client",
+		ClientSecret:   "s3cr3t&",
+		Scopes:         []string{"read", "write"},
+		EndpointParams: map[string][]string{"audience": {"api"}},
+	})
+	require.NoError(t, err)
+
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/pets", nil)
+		require.NoError(t, p.Intercept(context.Background(), req))
+		assert.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+	}
+	assert.EqualValues(t, 1, ts.requests.Load(), "the token is cached")
+
+	_, err = NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{})
+	assert.ErrorIs(t, err, ErrSecurityProviderOAuth2NoTokenURL)
+}
+
+func TestSecurityProviderOAuth2Refresh(t *testing.T) {
+	ts := newTokenServer(t)
+	ts.expiresIn = 60
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Within RefreshBefore of expiring, it's still used, while it's
+	// refreshed.
+	now = now.Add(45 * time.Second)
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Eventually(t, func() bool {
+		token, err := p.Token(context.Background())
+		return err == nil && token == "token-2"
+	}, time.Second, 10*time.Millisecond)
+
+	// Once it's expired, a new one is waited for.
+	now = now.Add(2 * time.Minute)
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-3", token)
+}
+
+func TestSecurityProviderOAuth2SingleFlight(t *testing.T) {
+	ts := newTokenServer(t)
+	ts.release = make(chan struct{})
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i], _ = p.Token(context.Background())
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(ts.release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, ts.requests.Load())
+	for _, token := range tokens {
+		assert.Equal(t, "token-1", token)
+	}
+}
+
+func TestSecurityProviderOAuth2RequestTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hang:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(hang)
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret", RequestTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	// The caller's context doesn't bound the shared request, but its timeout
+	// does.
+	start := time.Now()
+	_, err = p.Token(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The next call makes another request.
+	_, err = p.Token(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSecurityProviderOAuth2RefreshToken(t *testing.T) {
+	ts := newTokenServer(t)
+	ts.expiresIn = 1
+	var refreshTokens []string
+	ts.check = func(r *http.Request) {
+		_, _, basic := r.BasicAuth()
+		assert.False(t, basic)
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "public", r.PostForm.Get("client_id"))
+		refreshTokens = append(refreshTokens, r.PostForm.Get("refresh_token"))
+	}
+	p, err := NewSecurityProviderOAuth2RefreshToken("refresh-1", OAuth2Options{TokenURL: ts.URL, ClientID: "public"})
+	require.NoError(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err = p.Token(context.Background())
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, []string{"refresh-1", "refresh-2"}, refreshTokens, "the rotated refresh token is used")
+
+	_, err = NewSecurityProviderOAuth2RefreshToken("", OAuth2Options{TokenURL: ts.URL})
+	assert.ErrorIs(t, err, ErrSecurityProviderOAuth2NoRefreshToken)
+}
+
+func TestSecurityProviderOAuth2Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":"invalid_client","error_description":"unknown client"}`)
+	}))
+	defer ts.Close()
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+
+	err = p.Intercept(context.Background(), httptest.NewRequest(http.MethodGet, "/pets", nil))
+	var oauth2Err *OAuth2Error
+	require.ErrorAs(t, err, &oauth2Err)
+	assert.Equal(t, http.StatusUnauthorized, oauth2Err.StatusCode)
+	assert.Equal(t, "invalid_client", oauth2Err.Code)
+	assert.EqualError(t, err, "oauth2: token request failed with status 401: invalid_client: unknown client")
+}
+
+func TestSecurityProviderOAuth2DoerRetriesUnauthorized(t *testing.T) {
+	ts := newTokenServer(t)
+	var bodies []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		// The first token is revoked.
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer api.Close()
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+	doer := p.Doer(http.DefaultClient)
+
+	req, err := http.NewRequest(http.MethodPost, api.URL, strings.NewReader(`{"name":"Fido"}`))
+	require.NoError(t, err)
+	require.NoError(t, p.Intercept(req.Context(), req))
+	resp, err := doer.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{`{"name":"Fido"}`, `{"name":"Fido"}`}, bodies)
+
+	// Requests without a token aren't retried.
+	req, err = http.NewRequest(http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+	resp, err = doer.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Len(t, bodies, 3)
+	assert.EqualValues(t, 2, ts.requests.Load())
+}
+
+func TestSecurityProviderOAuth2DoerRetriesOnce(t *testing.T) {
+	ts := newTokenServer(t)
+	var requests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+	p, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Options{TokenURL: ts.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, p.Intercept(req.Context(), req))
+	resp, err := p.Doer(http.DefaultClient).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.EqualValues(t, 2, requests.Load())
+}
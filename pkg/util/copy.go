@@ -0,0 +1,118 @@
+package util
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// CopySwagger returns a deep copy of a loaded spec, which can be filtered and
+// pruned by generation without changing spec. The $refs of the copy point to
+// copies of their values, which are shared, and recursive, as in spec.
+func CopySwagger(spec *openapi3.T) *openapi3.T {
+	c := copier{copies: map[copied]reflect.Value{}}
+	return c.copy(reflect.ValueOf(spec)).Interface().(*openapi3.T)
+}
+
+// copied identifies a pointer or map which has been copied, by its address
+// and type, as a struct shares its address with its first field.
+type copied struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copier deep copies values, including their unexported fields, such as the
+// map of openapi3.Paths.
+type copier struct {
+	copies map[copied]reflect.Value
+}
+
+func (c copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copied{v.Pointer(), v.Type()}
+		if p, ok := c.copies[key]; ok {
+			return p
+		}
+		p := reflect.New(v.Type().Elem())
+		c.copies[key] = p
+		c.set(p.Elem(), v.Elem())
+		return p
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := copied{v.Pointer(), v.Type()}
+		if m, ok := c.copies[key]; ok {
+			return m
+		}
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[key] = m
+		for iter := v.MapRange(); iter.Next(); {
+			m.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.set(s.Index(i), v.Index(i))
+		}
+		return s
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		i := reflect.New(v.Type()).Elem()
+		i.Set(c.copy(v.Elem()))
+		return i
+	case reflect.Struct, reflect.Array:
+		if !v.CanAddr() {
+			// Its unexported fields are read through their addresses.
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v = addressable
+		}
+		s := reflect.New(v.Type()).Elem()
+		c.set(s, v)
+		return s
+	default:
+		// Scalars are values, and funcs and channels are shared.
+		return v
+	}
+}
+
+// set sets dst, which is addressable, to a copy of src.
+func (c copier) set(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := range src.NumField() {
+			c.set(settable(dst.Field(i)), src.Field(i))
+		}
+	case reflect.Array:
+		for i := range src.Len() {
+			c.set(dst.Index(i), src.Index(i))
+		}
+	default:
+		if !src.CanInterface() {
+			// An unexported field, which can't be read through reflection.
+			src = reflect.NewAt(src.Type(), unsafe.Pointer(src.UnsafeAddr())).Elem()
+		}
+		dst.Set(c.copy(src))
+	}
+}
+
+// settable returns the addressable field f, which can be set even when it's
+// unexported.
+func settable(f reflect.Value) reflect.Value {
+	if f.CanSet() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
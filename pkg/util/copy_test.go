@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopySwagger(t *testing.T) {
+	spec := []byte(`
+openapi: "3.0.0"
+info: {title: Nodes, version: 1.0.0}
+paths:
+  /nodes:
+    get:
+      operationId: listNodes
+      responses:
+        "200":
+          description: The nodes.
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Node"
+components:
+  schemas:
+    Node:
+      type: object
+      x-order: 1
+      properties:
+        next:
+          $ref: "#/components/schemas/Node"
+`)
+	swagger, err := openapi3.NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	c := CopySwagger(swagger)
+	require.NoError(t, c.Validate(openapi3.NewLoader().Context))
+	node := c.Components.Schemas["Node"].Value
+	assert.NotSame(t, swagger.Components.Schemas["Node"].Value, node)
+	// The recursion, and the sharing of a $ref's value, are kept.
+	assert.Same(t, node, node.Properties["next"].Value)
+	assert.Same(t, node, c.Paths.Value("/nodes").Get.Responses.Status(200).Value.Content.Get("application/json").Schema.Value)
+	assert.Equal(t, "#/components/schemas/Node", node.Properties["next"].Ref)
+	assert.Equal(t, map[string]any{"x-order": float64(1)}, node.Extensions)
+
+	// Changing the copy leaves the spec alone.
+	c.Paths.Delete("/nodes")
+	delete(c.Components.Schemas, "Node")
+	node.Extensions["x-order"] = float64(2)
+	assert.NotNil(t, swagger.Paths.Value("/nodes"))
+	require.Contains(t, swagger.Components.Schemas, "Node")
+	assert.Equal(t, float64(1), swagger.Components.Schemas["Node"].Value.Extensions["x-order"])
+}
@@ -3,7 +3,9 @@ package util
 import (
 	"bytes"
 	"fmt"
+	"io/fs"
 	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,9 +15,13 @@ import (
 )
 
 func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
+	return loadSwagger(filePath, nil)
+}
 
+func loadSwagger(filePath string, readFromURI openapi3.ReadFromURIFunc) (swagger *openapi3.T, err error) {
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = readFromURI
 	// Record each element's source location so route registration can be
 	// emitted in the order paths are declared in the spec (issue #1887).
 	loader.IncludeOrigin = true
@@ -28,6 +34,40 @@ func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
 	}
 }
 
+// LoadSwaggerFromFS loads the spec in data, as if it was the file at
+// filePath of fsys, reading the files it refers to from fsys. Nothing is read
+// from the disk or the network, so a spec can be loaded from memory, e.g.
+// with an fstest.MapFS. When data is nil, the spec is read from fsys, too.
+func LoadSwaggerFromFS(fsys fs.FS, filePath string, data []byte) (*openapi3.T, error) {
+	if data == nil {
+		var err error
+		if data, err = fs.ReadFile(fsys, filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = ReadFromFS(fsys)
+	loader.IncludeOrigin = true
+	return loader.LoadFromDataWithPath(data, &url.URL{Path: filePath})
+}
+
+// ReadFromFS returns an openapi3.ReadFromURIFunc which reads the files a spec
+// refers to from fsys. It fails for URLs, and paths outside of fsys.
+func ReadFromFS(fsys fs.FS) openapi3.ReadFromURIFunc {
+	return func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Host != "" || (location.Scheme != "" && location.Scheme != "file") {
+			return nil, fmt.Errorf("can't read %s: only the files of the file system can be referred to", location)
+		}
+		name := strings.TrimPrefix(path.Clean(location.Path), "/")
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("can't read %s: it's outside of the file system", location.Path)
+		}
+		return fs.ReadFile(fsys, name)
+	}
+}
+
 // Deprecated: In kin-openapi v0.126.0 (https://github.com/getkin/kin-openapi/tree/v0.126.0?tab=readme-ov-file#v01260) the Circular Reference Counter functionality was removed, instead resolving all references with backtracking, to avoid needing to provide a limit to reference counts.
 //
 // This is now identital in method as `LoadSwagger`.
@@ -38,10 +78,27 @@ func LoadSwaggerWithCircularReferenceCount(filePath string, _ int) (swagger *ope
 type LoadSwaggerWithOverlayOpts struct {
 	Path   string
 	Strict bool
+	// ReadFromURIFunc, if set, reads the spec and the files and URLs it
+	// refers to, instead of kin-openapi's default, which caches them for the
+	// life of the process.
+	ReadFromURIFunc openapi3.ReadFromURIFunc
+	// Sandbox, if set, confines the loading to the spec and the files of the
+	// sandbox, and fails, listing them, when the spec refers to others.
+	Sandbox *Sandbox
 }
 
 func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, err error) {
-	spec, err := LoadSwagger(filePath)
+	if opts.Sandbox != nil {
+		if isRemote(filePath) {
+			return nil, &SandboxError{Accesses: []string{filePath + ": a URL"}}
+		}
+		if err := opts.Sandbox.check(filePath); err != nil {
+			return nil, err
+		}
+		opts.ReadFromURIFunc = opts.Sandbox.readFromURI(filePath)
+	}
+
+	spec, err := loadSwagger(filePath, opts.ReadFromURIFunc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
 	}
@@ -95,6 +152,7 @@ func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (s
 
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = opts.ReadFromURIFunc
 	loader.IncludeOrigin = true
 
 	swagger, err = loader.LoadFromDataWithPath(b, &url.URL{
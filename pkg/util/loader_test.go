@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSwaggerFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"api/pets.yaml": {Data: []byte(`
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`)},
+	}
+	spec := []byte(`
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: "./pets.yaml#/components/schemas/Pet"
+`)
+
+	swagger, err := LoadSwaggerFromFS(fsys, "api/api.yaml", spec)
+	require.NoError(t, err)
+	pet := swagger.Components.Schemas["Pets"].Value.Items.Value
+	assert.Contains(t, pet.Properties, "name")
+
+	// The spec can be in the file system, too.
+	fsys["api/api.yaml"] = &fstest.MapFile{Data: spec}
+	_, err = LoadSwaggerFromFS(fsys, "api/api.yaml", nil)
+	require.NoError(t, err)
+
+	// Nothing is read from outside of it.
+	for ref, msg := range map[string]string{
+		"../pets.yaml":                  "outside of the file system",
+		"https://example.com/pets.yaml": "only the files of the file system",
+		"./missing.yaml":                "file does not exist",
+	} {
+		_, err := LoadSwaggerFromFS(fsys, "api.yaml", []byte(`
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "`+ref+`#/components/schemas/Pet"
+`))
+		require.Error(t, err, ref)
+		assert.Contains(t, err.Error(), msg, ref)
+	}
+}
@@ -0,0 +1,153 @@
+package util
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Sandbox confines the loading of a spec to the spec, and the files it maps,
+// for hermetic builds, such as Bazel's, where only the declared inputs of a
+// step can be read, and nothing is fetched from the network.
+type Sandbox struct {
+	// Files maps the files the spec may refer to, by their paths relative to
+	// the directory of the spec, e.g. "../common/pets.yaml", to the paths
+	// they're read from.
+	Files map[string]string
+}
+
+// SandboxError lists what a spec, or a configuration, tried to read outside
+// of its Sandbox.
+type SandboxError struct {
+	// Accesses are the URLs and files, each with why it's outside, sorted.
+	Accesses []string
+}
+
+func (e *SandboxError) Error() string {
+	return "hermetic mode doesn't allow reading:\n- " + strings.Join(e.Accesses, "\n- ")
+}
+
+// ReadFile reads the file name, as a spec in the sandbox would refer to it.
+func (s *Sandbox) ReadFile(name string) ([]byte, error) {
+	if isRemote(name) {
+		return nil, &SandboxError{Accesses: []string{name + ": a URL"}}
+	}
+	file, ok := s.file(name)
+	if !ok {
+		return nil, &SandboxError{Accesses: []string{name + ": not in the hermetic files"}}
+	}
+	return os.ReadFile(file)
+}
+
+// file returns the path the file name is read from.
+func (s *Sandbox) file(name string) (string, bool) {
+	name = path.Clean(filepath.ToSlash(name))
+	for key, file := range s.Files {
+		if path.Clean(filepath.ToSlash(key)) == name {
+			return file, true
+		}
+	}
+	return "", false
+}
+
+// check finds all that the spec at specPath, and the files it refers to,
+// would read outside of s. The loader stops at the first file it fails to
+// read, so they're found by following the $refs beforehand.
+func (s *Sandbox) check(specPath string) error {
+	var accesses []string
+	visited := map[string]bool{}
+	var visit func(name string, data []byte)
+	visit = func(name string, data []byte) {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			// The loader reports it.
+			return
+		}
+		for _, ref := range collectRefs(doc) {
+			file, _, _ := strings.Cut(ref, "#")
+			switch {
+			case file == "":
+				continue
+			case isRemote(file):
+				accesses = append(accesses, file+": a URL")
+				continue
+			}
+			file = path.Join(path.Dir(name), file)
+			if visited[file] {
+				continue
+			}
+			visited[file] = true
+			mapped, ok := s.file(file)
+			if !ok {
+				accesses = append(accesses, file+": not in the hermetic files")
+				continue
+			}
+			if data, err := os.ReadFile(mapped); err == nil {
+				visit(file, data)
+			}
+		}
+	}
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		// The loader reports it.
+		return nil
+	}
+	visit(filepath.Base(specPath), data)
+
+	if len(accesses) == 0 {
+		return nil
+	}
+	slices.Sort(accesses)
+	return &SandboxError{Accesses: slices.Compact(accesses)}
+}
+
+// readFromURI returns the openapi3.ReadFromURIFunc of loading the spec at
+// specPath from s.
+func (s *Sandbox) readFromURI(specPath string) openapi3.ReadFromURIFunc {
+	specDir := filepath.Dir(specPath)
+	return func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Host != "" || (location.Scheme != "" && location.Scheme != "file") {
+			return nil, &SandboxError{Accesses: []string{location.String() + ": a URL"}}
+		}
+		file := filepath.FromSlash(location.Path)
+		if filepath.Clean(file) == filepath.Clean(specPath) {
+			return os.ReadFile(file)
+		}
+		name, err := filepath.Rel(specDir, file)
+		if err != nil {
+			return nil, &SandboxError{Accesses: []string{location.Path + ": not in the hermetic files"}}
+		}
+		return s.ReadFile(name)
+	}
+}
+
+// collectRefs returns the $refs of a decoded YAML document.
+func collectRefs(node any) []string {
+	var refs []string
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok {
+			refs = append(refs, ref)
+		}
+		for _, v := range n {
+			refs = append(refs, collectRefs(v)...)
+		}
+	case []any:
+		for _, v := range n {
+			refs = append(refs, collectRefs(v)...)
+		}
+	}
+	return refs
+}
+
+// isRemote reports whether name is a URL, rather than a file.
+func isRemote(name string) bool {
+	u, err := url.Parse(name)
+	return err == nil && u.Scheme != "" && u.Scheme != "file" && len(u.Scheme) > 1
+}
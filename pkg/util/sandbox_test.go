@@ -0,0 +1,91 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandbox(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"api/api.yaml": `
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "../common/pets.yaml#/components/schemas/Pet"
+    Owner:
+      $ref: "./owners.yaml#/components/schemas/Owner"
+    Tag:
+      $ref: "https://example.com/tags.yaml#/components/schemas/Tag"
+`,
+		// The files of the sandbox are read from elsewhere, e.g. from
+		// where Bazel puts them.
+		"out/pets.yaml": `
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        kind:
+          $ref: "./kinds.yaml#/components/schemas/Kind"
+`,
+		"out/kinds.yaml": `
+components:
+  schemas:
+    Kind:
+      type: string
+`,
+		// It's where the spec refers to, but not in the sandbox.
+		"api/owners.yaml": `
+components:
+  schemas:
+    Owner:
+      type: string
+`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	specPath := filepath.Join(dir, "api", "api.yaml")
+	sandbox := &Sandbox{Files: map[string]string{
+		"../common/pets.yaml":  filepath.Join(dir, "out", "pets.yaml"),
+		"../common/kinds.yaml": filepath.Join(dir, "out", "kinds.yaml"),
+	}}
+
+	// All that's read outside of the sandbox is listed.
+	_, err := LoadSwaggerWithOverlay(specPath, LoadSwaggerWithOverlayOpts{Sandbox: sandbox})
+	var sandboxErr *SandboxError
+	require.True(t, errors.As(err, &sandboxErr), err)
+	assert.Equal(t, []string{
+		"https://example.com/tags.yaml: a URL",
+		"owners.yaml: not in the hermetic files",
+	}, sandboxErr.Accesses)
+
+	_, err = LoadSwaggerWithOverlay("https://example.com/api.yaml", LoadSwaggerWithOverlayOpts{Sandbox: sandbox})
+	assert.EqualError(t, err, "hermetic mode doesn't allow reading:\n- https://example.com/api.yaml: a URL")
+
+	// Once they're in the sandbox, the files are read from where it maps
+	// them.
+	require.NoError(t, os.WriteFile(specPath, []byte(`
+openapi: "3.0.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "../common/pets.yaml#/components/schemas/Pet"
+`), 0o644))
+	swagger, err := LoadSwaggerWithOverlay(specPath, LoadSwaggerWithOverlayOpts{Sandbox: sandbox})
+	require.NoError(t, err)
+	kind := swagger.Components.Schemas["Pet"].Value.Properties["kind"].Value
+	assert.Equal(t, "string", kind.Type.Slice()[0])
+}